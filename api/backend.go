@@ -13,6 +13,7 @@ import (
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/smartbch/moeingevm/types"
 	"github.com/smartbch/smartbch/app"
@@ -21,6 +22,7 @@ import (
 	"github.com/smartbch/smartbch/param"
 	"github.com/smartbch/smartbch/staking"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/watcher"
 )
 
 var _ BackendService = &apiBackend{}
@@ -257,6 +259,10 @@ func (backend *apiBackend) GetCurrEpoch() *stakingtypes.Epoch {
 	return backend.app.GetCurrEpoch()
 }
 
+func (backend *apiBackend) WatcherStatus() watcher.Status {
+	return backend.app.GetWatcherStatus()
+}
+
 //[start, end)
 func (backend *apiBackend) GetEpochs(start, end uint64) ([]*stakingtypes.Epoch, error) {
 	if start >= end {
@@ -276,6 +282,17 @@ func (backend *apiBackend) GetEpochs(start, end uint64) ([]*stakingtypes.Epoch,
 	return result, nil
 }
 
+func (backend *apiBackend) GetEpochSnapshot(epochNum uint64) (*stakingtypes.EpochSnapshot, error) {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+
+	snapshot, ok := staking.LoadEpochSnapshot(ctx, int64(epochNum))
+	if !ok {
+		return nil, errors.New("no snapshot for this epoch")
+	}
+	return &snapshot, nil
+}
+
 func (backend *apiBackend) GetEpochList(from string) ([]*stakingtypes.Epoch, error) {
 	switch from {
 	case "watcher":
@@ -435,6 +452,24 @@ func (backend *apiBackend) ValidatorOnlineInfos() stakingtypes.ValidatorOnlineIn
 	return staking.LoadOnlineInfo(ctx)
 }
 
+func (backend *apiBackend) ValidatorUnbondings() []*stakingtypes.ValidatorUnbonding {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	return staking.LoadValidatorUnbondingList(ctx).Unbondings
+}
+
+func (backend *apiBackend) ValidatorUptime(addr common.Address) ([]*stakingtypes.UptimeRecord, []*stakingtypes.DuplicateSigRecord) {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	return staking.GetValidatorUptime(ctx, addr)
+}
+
+func (backend *apiBackend) SlashRecords(fromHeight, toHeight int64) []*stakingtypes.SlashRecord {
+	ctx := backend.app.GetRpcContext()
+	defer ctx.Close(false)
+	return staking.GetSlashRecords(ctx, fromHeight, toHeight)
+}
+
 func (backend *apiBackend) IsArchiveMode() bool {
 	return backend.app.IsArchiveMode()
 }
@@ -456,6 +491,25 @@ func (backend *apiBackend) GetPosVotes() map[[32]byte]*big.Int {
 	return staking.GetPosVotes(ctx, param.XHedgeContractSequence)
 }
 
+// Returns the PoS votes in xHedge at height, the validators at height and the voting powers they project
+// for the next epoch. The PoW nominations are always taken from the next epoch to switch at the latest
+// height, so for a historical height in archive mode, the projection mixes the PoS votes at that height
+// with the current nominations.
+func (backend *apiBackend) GetPosVotesAtHeight(height int64) (map[[32]byte]*big.Int, map[[32]byte]int64, []*stakingtypes.Validator) {
+	ctx := backend.app.GetRpcContextAtHeight(height)
+	defer ctx.Close(false)
+
+	epoch := backend.app.GetNextEpoch(ctx)
+	posVotes := staking.GetPosVotes(ctx, param.XHedgeContractSequence)
+	pubkey2power := staking.ProjectPubkey2Power(ctx, param.XHedgeContractSequence, epoch,
+		backend.app.GetWatcherParams().NumBlocksInEpoch, log.NewNopLogger())
+	return posVotes, pubkey2power, staking.LoadStakingInfo(ctx).Validators
+}
+
+func (backend *apiBackend) PreviewNextValidatorSet() *app.NextValidatorSet {
+	return backend.app.PreviewNextValidatorSet()
+}
+
 func (backend *apiBackend) GetSyncBlock(height int64) (blk []byte, err error) {
 	return backend.app.GetBlockForSync(height)
 }
@@ -16,6 +16,7 @@ import (
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
 	"github.com/smartbch/smartbch/staking/types"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/watcher"
 )
 
 type CallDetail struct {
@@ -121,15 +122,22 @@ type BackendService interface {
 	GetEpochs(start, end uint64) ([]*types.Epoch, error)
 	GetEpochList(from string) ([]*types.Epoch, error)
 	GetCurrEpoch() *types.Epoch
+	GetEpochSnapshot(epochNum uint64) (*types.EpochSnapshot, error)
+	WatcherStatus() watcher.Status
 	GetCCEpochs(start, end uint64) ([]*cctypes.CCEpoch, error)
 	GetSeq(address common.Address) uint64
 	GetPosVotes() map[[32]byte]*big.Int
+	GetPosVotesAtHeight(height int64) (map[[32]byte]*big.Int, map[[32]byte]int64, []*stakingtypes.Validator)
+	PreviewNextValidatorSet() *app.NextValidatorSet
 	GetSyncBlock(height int64) (blk []byte, err error)
 
 	//tendermint info
 	NodeInfo() Info
 	ValidatorsInfo() app.ValidatorsInfo
 	ValidatorOnlineInfos() stakingtypes.ValidatorOnlineInfos
+	ValidatorUnbondings() []*stakingtypes.ValidatorUnbonding
+	ValidatorUptime(addr common.Address) ([]*stakingtypes.UptimeRecord, []*stakingtypes.DuplicateSigRecord)
+	SlashRecords(fromHeight, toHeight int64) []*stakingtypes.SlashRecord
 
 	IsArchiveMode() bool
 }
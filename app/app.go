@@ -18,6 +18,7 @@ import (
 	gethcmn "github.com/ethereum/go-ethereum/common"
 	gethcore "github.com/ethereum/go-ethereum/core"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rlp"
 
@@ -77,6 +78,10 @@ type IApp interface {
 	GetCurrEpoch() *stakingtypes.Epoch
 	GetWatcherEpochList() []*stakingtypes.Epoch
 	GetAppEpochList() []*stakingtypes.Epoch
+	GetNextEpoch(ctx *types.Context) *stakingtypes.Epoch
+	PreviewNextValidatorSet() *NextValidatorSet
+	GetWatcherStatus() watcher.Status
+	GetWatcherParams() param.WatcherParams
 	GetLatestBlockNum() int64
 	SubscribeChainEvent(ch chan<- types.ChainEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*gethtypes.Log) event.Subscription
@@ -107,17 +112,22 @@ type App struct {
 	block *types.Block
 	// Some fields of 'block' are copied to 'blockInfo' in Commit. It will be later used by RpcContext
 	// Thus, eth_call can view the new block's height a little earlier than eth_blockNumber
-	blockInfo       atomic.Value // to store *types.BlockInfo
-	slashValidators [][20]byte   // updated in BeginBlock, used in Commit
-	lastVoters      [][]byte     // updated in BeginBlock, used in Commit
-	lastProposer    [20]byte     // updated in refresh of last block, used in updateValidatorsAndStakingInfo
+	blockInfo                  atomic.Value // to store *types.BlockInfo
+	slashValidators            [][20]byte   // updated in BeginBlock, used in Commit
+	lightClientSlashValidators [][20]byte   // updated in BeginBlock, used in Commit
+	lastVoters                 [][]byte     // updated in BeginBlock, used in Commit
+	lastProposer               [20]byte     // updated in refresh of last block, used in updateValidatorsAndStakingInfo
 	// of current block. It needs to be reloaded in NewApp
 	lastGasUsed     uint64      // updated in last block's postCommit, used in current block's refresh
 	lastGasRefund   uint256.Int // updated in last block's postCommit, used in current block's refresh
 	lastGasFee      uint256.Int // updated in last block's postCommit, used in current block's refresh
 	lastMinGasPrice uint64      // updated in refresh, used in next block's CheckTx and Commit. It needs
 	// to be reloaded in NewApp
-	txid2sigMap map[[32]byte][65]byte //updated in DeliverTx, flushed in refresh
+	maxTxGasLimit uint64                // updated in Commit, used in CheckTx and Commit. It needs to be reloaded in NewApp
+	txid2sigMap   map[[32]byte][65]byte //updated in DeliverTx, flushed in refresh
+	// logs generated by the staking contract outside of any tx, such as the ones of slashing and epoch switching
+	systemLogs     []types.EvmLog // updated and saved to the staking contract in Commit, moved to lastSystemLogs in refresh
+	lastSystemLogs []types.EvmLog // logs of last block, flushed in current block's refresh. It needs to be reloaded in NewApp
 
 	// feeds
 	chainFeed event.Feed // For pub&sub new blocks
@@ -130,8 +140,12 @@ type App struct {
 	frontier    ebp.Frontier // recorded in Commit, used in next block's CheckTx
 
 	//watcher
-	watcher   *watcher.Watcher
-	epochList []*stakingtypes.Epoch // caches the epochs collected by the watcher
+	watcher       *watcher.Watcher
+	watcherParams *param.WatcherParams
+	epochList     []*stakingtypes.Epoch // caches the epochs collected by the watcher
+	// it is set to 1 when BeginBlock is waiting for the watcher to catch up BCH mainnet,
+	// accessed atomically
+	waitingBCHCatchup int32
 	//ccEpochList []*cctypes.CCEpoch
 
 	//util
@@ -234,7 +248,12 @@ func NewApp(config *param.ChainConfig, chainId *uint256.Int, genesisWatcherHeigh
 	}
 
 	/*------set watcher------*/
-	lastEpochEndHeight := stakingInfo.GenesisMainnetBlockHeight + param.StakingNumBlocksInEpoch*stakingInfo.CurrEpochNum
+	watcherParams, err := config.AppConfig.GetWatcherParams()
+	if err != nil {
+		panic(err)
+	}
+	app.watcherParams = watcherParams
+	lastEpochEndHeight := stakingInfo.GenesisMainnetBlockHeight + app.watcherParams.NumBlocksInEpoch*stakingInfo.CurrEpochNum
 	app.watcher = watcher.NewWatcher(app.logger.With("module", "watcher"), lastEpochEndHeight, 0, stakingInfo.CurrEpochNum, app.config)
 	app.logger.Debug(fmt.Sprintf("New watcher: mainnet url(%s), epochNum(%d), lastEpochEndHeight(%d), speedUp(%v)\n",
 		config.AppConfig.MainnetRPCUrl, stakingInfo.CurrEpochNum, lastEpochEndHeight, config.AppConfig.Speedup))
@@ -244,6 +263,8 @@ func NewApp(config *param.ChainConfig, chainId *uint256.Int, genesisWatcherHeigh
 	<-catchupChan
 
 	app.lastMinGasPrice = staking.LoadMinGasPrice(ctx, true)
+	app.maxTxGasLimit = staking.GetGovParam(ctx, staking.GovParamMaxTxGasLimit)
+	app.lastSystemLogs = staking.LoadPendingSystemLogs(ctx)
 	ctx.Close(true)
 	return app
 }
@@ -345,7 +366,7 @@ func (app *App) CheckTx(req abcitypes.RequestCheckTx) abcitypes.ResponseCheckTx
 func (app *App) checkTxWithContext(tx *gethtypes.Transaction, sender gethcmn.Address, txType abcitypes.CheckTxType) abcitypes.ResponseCheckTx {
 	ctx := app.GetCheckTxContext()
 	defer ctx.Close(false)
-	if ok, res := checkGasLimit(tx); !ok {
+	if ok, res := checkGasLimit(tx, app.maxTxGasLimit); !ok {
 		return res
 	}
 	acc := ctx.GetAccount(sender)
@@ -406,12 +427,12 @@ func (app *App) checkTxWithContext(tx *gethtypes.Transaction, sender gethcmn.Add
 	}
 }
 
-func checkGasLimit(tx *gethtypes.Transaction) (ok bool, res abcitypes.ResponseCheckTx) {
+func checkGasLimit(tx *gethtypes.Transaction, maxTxGasLimit uint64) (ok bool, res abcitypes.ResponseCheckTx) {
 	intrinsicGas, err2 := gethcore.IntrinsicGas(tx.Data(), nil, tx.To() == nil, true, true)
 	if err2 != nil || tx.Gas() < intrinsicGas {
 		return false, abcitypes.ResponseCheckTx{Code: GasLimitTooSmall, Info: "gas limit too small"}
 	}
-	if tx.Gas() > param.MaxTxGasLimit {
+	if tx.Gas() > maxTxGasLimit {
 		return false, abcitypes.ResponseCheckTx{Code: GasLimitInvalid, Info: "invalid gas limit"}
 	}
 	return true, abcitypes.ResponseCheckTx{}
@@ -490,9 +511,11 @@ func (app *App) createGenesisAccounts(alloc gethcore.GenesisAlloc) {
 func (app *App) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.ResponseBeginBlock {
 	//app.randomPanic(5000, 7919)
 	for app.block.Timestamp > app.watcher.GetCurrMainnetBlockTimestamp()+12*3600 {
+		atomic.StoreInt32(&app.waitingBCHCatchup, 1)
 		app.logger.Debug("waiting BCH node catchup...", "smartBCH block timestamp", app.block.Timestamp, "BCH block timestamp", app.watcher.GetCurrMainnetBlockTimestamp())
 		time.Sleep(30 * time.Second)
 	}
+	atomic.StoreInt32(&app.waitingBCHCatchup, 0)
 	app.block = &types.Block{
 		Number:    req.Header.Height,
 		Timestamp: req.Header.Time.Unix(),
@@ -515,13 +538,16 @@ func (app *App) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.ResponseBe
 	copy(app.block.Hash[:], req.Hash) // Just use tendermint's block hash
 	copy(app.block.StateRoot[:], req.Header.AppHash)
 	app.currHeight = req.Header.Height
-	// collect slash info, currently only double signing is slashed
+	// collect slash info, the light client attacks are ignored by staking before SlashHistoryForkHeight
 	var addr [20]byte
 	for _, val := range req.ByzantineValidators {
 		//we always slash, without checking the time of bad behavior
 		if val.Type == abcitypes.EvidenceType_DUPLICATE_VOTE {
 			copy(addr[:], val.Validator.Address)
 			app.slashValidators = append(app.slashValidators, addr)
+		} else if val.Type == abcitypes.EvidenceType_LIGHT_CLIENT_ATTACK {
+			copy(addr[:], val.Validator.Address)
+			app.lightClientSlashValidators = append(app.lightClientSlashValidators, addr)
 		}
 	}
 	return abcitypes.ResponseBeginBlock{}
@@ -556,13 +582,36 @@ func (app *App) EndBlock(req abcitypes.RequestEndBlock) abcitypes.ResponseEndBlo
 func (app *App) Commit() abcitypes.ResponseCommit {
 	app.logger.Debug("Enter commit!", "collected txs", app.txEngine.CollectedTxsCount())
 	app.mtx.Lock()
+	app.checkInvariants()
 	app.updateValidatorsAndStakingInfo()
-	app.frontier = app.txEngine.Prepare(app.reorderSeed, 0, param.MaxTxGasLimit)
+	app.frontier = app.txEngine.Prepare(app.reorderSeed, 0, app.maxTxGasLimit)
 	appHash := app.refresh()
 	go app.postCommit(app.syncBlockInfo())
 	return app.buildCommitResponse(appHash)
 }
 
+// Check the invariants on the states left by the last block's staking updates and this block's txs,
+// before the gas fees are taken from the system account.
+func (app *App) checkInvariants() {
+	mode := app.config.AppConfig.InvariantCheck
+	if mode == "" || mode == param.InvariantCheckOff {
+		return
+	}
+	ctx := app.GetRunTxContext()
+	defer ctx.Close(false)
+	errs := staking.CheckInvariants(ctx)
+	undistributed := uint256.NewInt(0).Add(&app.lastGasRefund, &app.lastGasFee)
+	if err := staking.CheckSystemBalance(ctx, undistributed); err != nil {
+		errs = append(errs, err)
+	}
+	for _, err := range errs {
+		app.logger.Error("invariant check failed", "height", app.currHeight, "error", err.Error())
+	}
+	if len(errs) != 0 && mode == param.InvariantCheckHalt {
+		panic(fmt.Sprintf("%d invariants are broken at height %d", len(errs), app.currHeight))
+	}
+}
+
 func (app *App) getBlockRewardAndUpdateSysAcc(ctx *types.Context) *uint256.Int {
 	if !app.lastGasRefund.IsZero() {
 		err := ebp.SubSystemAccBalance(ctx, &app.lastGasRefund)
@@ -609,9 +658,11 @@ func (app *App) updateValidatorsAndStakingInfo() {
 	ctx := app.GetRunTxContext()
 	defer ctx.Close(true) // context must be written back such that txEngine can read it in 'Prepare'
 
-	currValidators, newValidators, currEpochNum := staking.SlashAndReward(ctx, app.slashValidators, app.block.Miner,
+	currValidators, newValidators, currEpochNum, logs := staking.SlashAndReward(ctx, app.slashValidators, app.lightClientSlashValidators, app.block.Miner,
 		app.lastProposer, app.lastVoters, app.getBlockRewardAndUpdateSysAcc(ctx))
+	app.systemLogs = append(app.systemLogs, logs...)
 	app.slashValidators = app.slashValidators[:0]
+	app.lightClientSlashValidators = app.lightClientSlashValidators[:0]
 
 	if param.IsAmber && ctx.IsXHedgeFork() {
 		//make fake epoch after xHedgeFork, change amber to pure pos
@@ -637,26 +688,18 @@ func (app *App) updateValidatorsAndStakingInfo() {
 
 	if len(app.epochList) != 0 {
 		//epoch switch delay time should bigger than 10 mainnet block interval as of block finalization need
-		epochSwitchDelay := param.StakingEpochSwitchDelay
+		epochSwitchDelay := app.watcherParams.EpochSwitchDelay
 		// this 20 is hardcode to fix the 20220520 bch node not upgrade error. don't modify it ever.
 		if currEpochNum == 20 {
 			// make epoch switch delay in epoch 20th 50% longer.
-			epochSwitchDelay = param.StakingEpochSwitchDelay * 10
+			epochSwitchDelay = app.watcherParams.EpochSwitchDelay * 10
 		}
 		if app.block.Timestamp > app.epochList[0].EndTime+epochSwitchDelay {
 			app.logger.Debug(fmt.Sprintf("Switch epoch at block(%d), eppchNum(%d)",
 				app.block.Number, app.epochList[0].Number))
-			var posVotes map[[32]byte]int64
-			var xHedgeSequence = param.XHedgeContractSequence
-			if ctx.IsXHedgeFork() {
-				//deploy xHedge contract before fork
-				posVotes = staking.GetAndClearPosVotes(ctx, xHedgeSequence)
-			}
-			newValidators = staking.SwitchEpoch(ctx, app.epochList[0], posVotes, app.logger)
+			newValidators, _, logs = switchEpoch(ctx, app.epochList[0], app.watcherParams.NumBlocksInEpoch, app.logger)
+			app.systemLogs = append(app.systemLogs, logs...)
 			app.epochList = app.epochList[1:] // possible memory leak here, but the length would not be very large
-			if ctx.IsXHedgeFork() {
-				staking.CreateInitVotes(ctx, xHedgeSequence, newValidators)
-			}
 		}
 	}
 
@@ -672,9 +715,16 @@ func (app *App) updateValidatorsAndStakingInfo() {
 		app.logger.Debug(fmt.Sprintf("Updated validator in commit: address(%s), pubkey(%s), voting power: %d",
 			gethcmn.Address(v.Address).String(), ed25519.PubKey(v.Pubkey[:]), v.VotingPower))
 	}
+	// the MaxTxGasLimit in effect at this height limits this block's txs, which are executed after Commit,
+	// so a governed change applies to the txs of its activation block. CheckTx uses it until the next Commit,
+	// so the txs checked during the activation block are still checked against the old value.
+	app.maxTxGasLimit = staking.GetGovParam(ctx, staking.GovParamMaxTxGasLimit)
+	staking.SavePendingSystemLogs(ctx, app.systemLogs)
 	newInfo := staking.LoadStakingInfo(ctx)
 	newInfo.ValidatorsUpdate = app.validatorUpdate
 	staking.SaveStakingInfo(ctx, newInfo)
+	// the contracts calling the staking contract in this block's txs read this snapshot
+	staking.UpdateReadonlyStates(ctx)
 	//only amber need this
 	app.currValidators = newValidators
 	//log all validators info when validator set update
@@ -685,6 +735,30 @@ func (app *App) updateValidatorsAndStakingInfo() {
 	}
 }
 
+// Switch to the new epoch with the PoS votes in xHedge and the delegated votes. It is also used by
+// PreviewNextValidatorSet, so it must not touch the app's fields.
+// isValid is false if the epoch does not have enough votes to change the validator set
+func switchEpoch(ctx *types.Context, epoch *stakingtypes.Epoch, numBlocksInEpoch int64, logger log.Logger) (newValidators []*stakingtypes.Validator, isValid bool, logs []types.EvmLog) {
+	var posVotes map[[32]byte]int64
+	var xHedgeSequence = param.XHedgeContractSequence
+	if ctx.IsXHedgeFork() {
+		//deploy xHedge contract before fork
+		posVotes = staking.GetAndClearPosVotes(ctx, xHedgeSequence)
+	}
+	if staking.IsDelegationFork(ctx) {
+		posVotes = staking.AddDelegatedVotes(ctx, posVotes, numBlocksInEpoch)
+	}
+	newValidators, isValid, logs = staking.SwitchEpoch(ctx, epoch, posVotes, numBlocksInEpoch, logger)
+	if staking.IsValidatorUnbondingFork(ctx) {
+		// epoch.Number has been set to the new epoch number in SwitchEpoch
+		logs = append(logs, staking.ReleaseMatureValidatorUnbondings(ctx, epoch.Number)...)
+	}
+	if ctx.IsXHedgeFork() {
+		staking.CreateInitVotes(ctx, xHedgeSequence, newValidators)
+	}
+	return
+}
+
 func (app *App) syncBlockInfo() *types.BlockInfo {
 	bi := &types.BlockInfo{
 		Coinbase:  app.block.Miner,
@@ -762,6 +836,9 @@ func (app *App) refresh() (appHash []byte) {
 		copy(prevBlk4MoDB.BlockHash[:], prevBlkInfo.Hash[:])
 		prevBlk4MoDB.BlockInfo = blkInfo
 		prevBlk4MoDB.TxList = app.txEngine.CommittedTxsForMoDB()
+		if len(app.lastSystemLogs) != 0 {
+			prevBlk4MoDB.TxList = append(prevBlk4MoDB.TxList, buildSystemLogsTx(prevBlkInfo, prevBlk4MoDB.TxList, app.lastSystemLogs))
+		}
 		if app.config.AppConfig.NumKeptBlocksInMoDB > 0 && app.currHeight > app.config.AppConfig.NumKeptBlocksInMoDB {
 			app.historyStore.AddBlock(&prevBlk4MoDB, app.currHeight-app.config.AppConfig.NumKeptBlocksInMoDB, app.txid2sigMap)
 		} else {
@@ -775,6 +852,8 @@ func (app *App) refresh() (appHash []byte) {
 		app.publishNewBlock(&prevBlk4MoDB)
 	}
 	//make new
+	app.lastSystemLogs = app.systemLogs
+	app.systemLogs = nil
 	app.recheckCounter = 0 // reset counter before counting the remained TXs which need rechecking
 	app.lastProposer = app.block.Miner
 	app.lastVoters = app.lastVoters[:0]
@@ -796,6 +875,56 @@ func (app *App) publishNewBlock(mdbBlock *modbtypes.Block) {
 	}
 }
 
+// The logs generated outside of any tx are put into a pseudo tx appended to the block, such that they can be
+// queried with eth_getLogs. Its hash is derived from the block hash, and it is not listed in the block's transactions.
+// Its From and To are left empty, so it is not indexed by any address.
+func buildSystemLogsTx(blk *types.Block, txList []modbtypes.Tx, evmLogs []types.EvmLog) modbtypes.Tx {
+	tx := types.Transaction{
+		Hash:             gethcrypto.Keccak256Hash(blk.Hash[:], []byte("system logs")),
+		TransactionIndex: int64(len(txList)),
+		BlockHash:        blk.Hash,
+		BlockNumber:      blk.Number,
+		Status:           gethtypes.ReceiptStatusSuccessful,
+		StatusStr:        "success",
+	}
+	logIndex := 0
+	for _, t := range txList {
+		logIndex += len(t.LogList)
+	}
+	tx.Logs = make([]types.Log, len(evmLogs))
+	for i, l := range evmLogs {
+		tx.Logs[i].Address = l.Address
+		tx.Logs[i].Topics = make([][32]byte, len(l.Topics))
+		for j, topic := range l.Topics {
+			tx.Logs[i].Topics[j] = topic
+		}
+		tx.Logs[i].Data = l.Data
+		tx.Logs[i].BlockNumber = uint64(blk.Number)
+		tx.Logs[i].BlockHash = blk.Hash
+		tx.Logs[i].TxHash = tx.Hash
+		tx.Logs[i].TxIndex = uint(tx.TransactionIndex)
+		tx.Logs[i].Index = uint(logIndex + i)
+	}
+	tx.LogsBloom = ebp.LogsBloom(tx.Logs)
+	content, err := tx.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	mdbTx := modbtypes.Tx{
+		HashId:  tx.Hash,
+		SrcAddr: tx.From,
+		DstAddr: tx.To,
+		Content: content,
+		LogList: make([]modbtypes.Log, len(tx.Logs)),
+	}
+	for i, l := range tx.Logs {
+		mdbTx.LogList[i].Address = l.Address
+		mdbTx.LogList[i].Topics = l.Topics
+	}
+	return mdbTx
+}
+
 func (app *App) ListSnapshots(snapshots abcitypes.RequestListSnapshots) abcitypes.ResponseListSnapshots {
 	return abcitypes.ResponseListSnapshots{}
 }
@@ -813,6 +942,7 @@ func (app *App) ApplySnapshotChunk(chunk abcitypes.RequestApplySnapshotChunk) ab
 }
 
 func (app *App) Stop() {
+	app.watcher.Stop()
 	app.historyStore.Close()
 	app.root.Close()
 	app.scope.Close()
@@ -978,6 +1108,65 @@ func (app *App) GetWatcherEpochList() []*stakingtypes.Epoch {
 	return app.watcher.GetEpochList()
 }
 
+// GetNextEpoch returns the epoch which the next epoch switch will use: the first one queued in the app,
+// or the latest one collected by the watcher
+func (app *App) GetNextEpoch(ctx *types.Context) *stakingtypes.Epoch {
+	epochList := app.GetAppEpochList()
+	if len(epochList) != 0 {
+		return epochList[0]
+	}
+	if param.IsAmber && ctx.IsXHedgeFork() {
+		// amber only uses the fake epochs after xHedgeFork
+		return &stakingtypes.Epoch{}
+	}
+	watcherEpochs := app.GetWatcherEpochList()
+	return watcherEpochs[len(watcherEpochs)-1]
+}
+
+// NextValidatorSet is the result of running the next epoch switch in advance
+type NextValidatorSet struct {
+	Epoch          *stakingtypes.Epoch
+	IsValid        bool // false if the epoch does not have enough votes to change the validator set
+	CurrValidators []*stakingtypes.Validator
+	NewValidators  []*stakingtypes.Validator
+	Updates        []*stakingtypes.Validator // the ones removed have zero voting power
+}
+
+// Run the next epoch switch on a throwaway context with the current PoS votes and staking info. The
+// epoch is the first one waiting for switching, or the one still collected by the watcher, which is
+// the last one in the watcher's list because the ones before it have been sent to the app.
+func (app *App) PreviewNextValidatorSet() *NextValidatorSet {
+	ctx := app.GetRpcContext()
+	defer ctx.Close(false)
+
+	epoch := app.GetNextEpoch(ctx)
+	currValidators := staking.GetActiveValidators(ctx, staking.LoadStakingInfo(ctx).Validators)
+	newValidators, isValid, _ := switchEpoch(ctx, epoch, app.watcherParams.NumBlocksInEpoch, log.NewNopLogger())
+	ret := &NextValidatorSet{
+		Epoch:          epoch,
+		IsValid:        isValid,
+		CurrValidators: currValidators,
+		NewValidators:  currValidators,
+	}
+	if ret.IsValid {
+		ret.NewValidators = newValidators
+		ret.Updates = stakingtypes.GetUpdateValidatorSet(currValidators, newValidators)
+	}
+	return ret
+}
+
+// GetWatcherStatus shows the watcher's progress, and whether BeginBlock is throttled by it
+func (app *App) GetWatcherStatus() watcher.Status {
+	status := app.watcher.GetStatus()
+	status.BeginBlockThrottled = atomic.LoadInt32(&app.waitingBCHCatchup) != 0
+	return status
+}
+
+// GetWatcherParams returns the watcher params of the network, which are validated in NewApp
+func (app *App) GetWatcherParams() param.WatcherParams {
+	return *app.watcherParams
+}
+
 func (app *App) GetBlockForSync(height int64) (blk []byte, err error) {
 	if app.syncDB == nil {
 		return nil, errNoSyncDB
@@ -16,6 +16,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartbch/smartbch/internal/testutils"
+	"github.com/smartbch/smartbch/internal/testutils/bchnode"
+	"github.com/smartbch/smartbch/param"
 	"github.com/smartbch/smartbch/staking"
 	"github.com/smartbch/smartbch/staking/types"
 )
@@ -565,3 +567,139 @@ func TestStakingDetermination(t *testing.T) {
 		}
 	}
 }
+
+func TestPreviewNextValidatorSet(t *testing.T) {
+	key1, _ := testutils.GenKeyAndAddr()
+	_app := testutils.CreateTestApp(key1)
+	defer _app.Destroy()
+
+	ctx := _app.GetRpcContext()
+	epochNum := staking.LoadStakingInfo(ctx).CurrEpochNum
+	ctx.Close(false)
+
+	// the epoch from the watcher has no nominations, so the validator set would not change
+	set := _app.PreviewNextValidatorSet()
+	require.False(t, set.IsValid)
+	require.Equal(t, 1, len(set.CurrValidators))
+	require.Equal(t, set.CurrValidators, set.NewValidators)
+	require.Equal(t, 0, len(set.Updates))
+	require.Equal(t, epochNum+1, set.Epoch.Number)
+
+	// nothing is written back
+	ctx = _app.GetRpcContext()
+	require.Equal(t, epochNum, staking.LoadStakingInfo(ctx).CurrEpochNum)
+	ctx.Close(false)
+}
+
+// The app's watcher follows a mock BCH node, and the app switches to the epochs nominated on it
+func TestEpochSwitchWithMockBCHNode(t *testing.T) {
+	valPubKey := ed25519.GenPrivKey().PubKey()
+	var pubkey [32]byte
+	copy(pubkey[:], valPubKey.Bytes())
+
+	genesisTime := time.Now().Unix()
+	node := bchnode.NewMockBCHNode(genesisTime)
+	node.SetBlockInterval(1)
+	node.MineBlocks(10, pubkey)
+	require.NoError(t, node.Start("127.0.0.1:0"))
+	defer node.Stop()
+
+	// a devnet epoch has 10 BCH blocks, which get finalized with one confirmation
+	startTime := time.Unix(genesisTime+10, 0)
+	_app := testutils.CreateTestAppWithArgs(testutils.TestAppInitArgs{
+		StartTime:     &startTime,
+		ValPubKey:     &valPubKey,
+		MainnetRPCUrl: node.URL(),
+		Network:       param.NetworkDevnet,
+	})
+	defer _app.Destroy()
+
+	loadEpoch := func(epochNum int64) (currEpochNum int64, epoch types.Epoch) {
+		ctx := _app.GetRpcContext()
+		defer ctx.Close(false)
+		epoch, _ = staking.LoadEpoch(ctx, epochNum)
+		return staking.LoadStakingInfo(ctx).CurrEpochNum, epoch
+	}
+	blockTime := func(height int64) int64 {
+		return startTime.Add(testutils.BlockInterval * time.Duration(height)).Unix()
+	}
+
+	// the first epoch ends at genesisTime+10, and the app switches to it after EpochSwitchDelay
+	switchTime := genesisTime + 10 + param.DevnetWatcherParams().EpochSwitchDelay
+	for blockTime(_app.BlockNum()+2) <= switchTime { // ExecTxsInBlock commits two blocks
+		_app.ExecTxsInBlock()
+	}
+	currEpochNum, _ := loadEpoch(1)
+	require.Equal(t, int64(0), currEpochNum)
+	_app.ExecTxsInBlock()
+	currEpochNum, epoch := loadEpoch(1)
+	require.Equal(t, int64(1), currEpochNum)
+	require.Equal(t, int64(1), epoch.StartHeight)
+	require.Equal(t, genesisTime+10, epoch.EndTime)
+	require.Len(t, epoch.Nominations, 1)
+	require.Equal(t, pubkey, epoch.Nominations[0].Pubkey)
+
+	// the second epoch has been over for EpochSwitchDelay when the watcher finalizes it
+	node.MineBlocks(10, pubkey)
+	require.Eventually(t, func() bool {
+		return _app.GetWatcherStatus().PendingEpochs == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	_app.ExecTxsInBlock()
+	currEpochNum, epoch = loadEpoch(2)
+	require.Equal(t, int64(2), currEpochNum)
+	require.Equal(t, int64(11), epoch.StartHeight)
+	require.Len(t, epoch.Nominations, 1)
+	require.Equal(t, pubkey, epoch.Nominations[0].Pubkey)
+}
+
+// The watcher's list has the epochs already sent to the app, and the preview must use the last one, which is
+// still being collected
+func TestPreviewNextValidatorSetWithWatcherHistory(t *testing.T) {
+	valPubKey := ed25519.GenPrivKey().PubKey()
+	var pubkey [32]byte
+	copy(pubkey[:], valPubKey.Bytes())
+
+	genesisTime := time.Now().Unix()
+	node := bchnode.NewMockBCHNode(genesisTime)
+	node.SetBlockInterval(1)
+	node.MineBlocks(10, pubkey)
+	require.NoError(t, node.Start("127.0.0.1:0"))
+	defer node.Stop()
+
+	startTime := time.Unix(genesisTime+10, 0)
+	_app := testutils.CreateTestAppWithArgs(testutils.TestAppInitArgs{
+		StartTime:     &startTime,
+		ValPubKey:     &valPubKey,
+		MainnetRPCUrl: node.URL(),
+		Network:       param.NetworkDevnet,
+	})
+	defer _app.Destroy()
+
+	// the app switches to the first epoch, which stays in the watcher's list
+	switchTime := genesisTime + 10 + param.DevnetWatcherParams().EpochSwitchDelay
+	for startTime.Add(testutils.BlockInterval*time.Duration(_app.BlockNum()+2)).Unix() <= switchTime {
+		_app.ExecTxsInBlock()
+	}
+	_app.ExecTxsInBlock()
+	ctx := _app.GetRpcContext()
+	require.Equal(t, int64(1), staking.LoadStakingInfo(ctx).CurrEpochNum)
+	ctx.Close(false)
+	require.Equal(t, 0, len(_app.GetAppEpochList()))
+
+	// the second epoch is still being collected
+	node.MineBlocks(5, pubkey)
+	require.Eventually(t, func() bool {
+		list := _app.GetWatcherEpochList()
+		return len(list) == 2 && len(list[1].Nominations) == 1 && list[1].Nominations[0].NominatedCount >= 4
+	}, 10*time.Second, 10*time.Millisecond)
+	set := _app.PreviewNextValidatorSet()
+	require.Equal(t, int64(2), set.Epoch.Number)
+	require.Equal(t, int64(11), set.Epoch.StartHeight)
+	require.Len(t, set.Epoch.Nominations, 1)
+	require.Equal(t, pubkey, set.Epoch.Nominations[0].Pubkey)
+	// the only validator gets enough votes
+	require.True(t, set.IsValid)
+	require.Equal(t, 1, len(set.CurrValidators))
+	require.Equal(t, 1, len(set.NewValidators))
+	require.Equal(t, pubkey, set.NewValidators[0].Pubkey)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartbch/moeingads/store/rabbit"
+	"github.com/smartbch/moeingevm/ebp"
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/app"
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking"
+)
+
+// The node must be stopped before running it, because the data dir cannot be opened twice
+func CheckInvariantsCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-invariants",
+		Short: "check the staking invariants against the data dir of a stopped node",
+		Example: `
+smartbchd check-invariants --home=$HOME/.smartbchd
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			appConfig := ctx.Config.AppConfig
+			root, mads := app.CreateRootStore(appConfig.AppDataPath, appConfig.ArchiveMode)
+			defer mads.Close()
+
+			c := types.NewContext(nil, nil)
+			r := rabbit.NewReadOnlyRabbitStore(root)
+			c = c.WithRbt(&r)
+			defer c.Close(false)
+			blk := c.GetCurrBlockBasicInfo()
+			if blk == nil {
+				return errors.New("no block has been committed in " + appConfig.AppDataPath)
+			}
+			c.SetShaGateForkBlock(param.ShaGateForkBlock)
+			c.SetStakingForkBlock(param.StakingForkHeight)
+			c.SetXHedgeForkBlock(param.XHedgeForkBlock)
+			c.SetCurrentHeight(blk.Number)
+
+			amounts := staking.GetStakingAccAmounts(c)
+			fmt.Printf("height: %d\n", blk.Number)
+			fmt.Printf("staked coins: %s\n", amounts.StakedCoins.ToBig())
+			fmt.Printf("pending rewards: %s\n", amounts.PendingRewards.ToBig())
+			fmt.Printf("validator unbondings: %s\n", amounts.ValidatorUnbondings.ToBig())
+			fmt.Printf("kept rewards: %s\n", amounts.KeptRewards.ToBig())
+			fmt.Printf("delegated coins: %s\n", amounts.Delegated.ToBig())
+			fmt.Printf("delegator unbondings: %s\n", amounts.DelegatorUnbondings.ToBig())
+			fmt.Printf("unsettled delegation rewards: %s\n", amounts.UnsettledRewards.ToBig())
+			// the undistributed gas fees are only kept in the memory of a running node
+			fmt.Printf("system balance: %s\n", ebp.GetSystemBalance(c).ToBig())
+			errs := staking.CheckInvariants(c)
+			for _, err := range errs {
+				fmt.Println(err.Error())
+			}
+			if len(errs) != 0 {
+				return fmt.Errorf("%d invariants are broken", len(errs))
+			}
+			fmt.Println("all invariants hold")
+			return nil
+		},
+	}
+	return cmd
+}
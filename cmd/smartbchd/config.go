@@ -71,7 +71,9 @@ func runConfigCmd(cmd *cobra.Command, args []string) error {
 		}
 	} else /*update app.toml*/ {
 		switch key {
-		case "mainnet-rpc-url", "mainnet-rpc-username", "mainnet-rpc-password", "smartbch-rpc-url":
+		case "mainnet-rpc-url", "mainnet-rpc-username", "mainnet-rpc-password", "smartbch-rpc-url",
+			"mainnet-blocks-file", "network", "remote-signer-laddr",
+			"invariant-check":
 			tree.Set(key, value)
 
 		case "watcher-speedup", "use_litedb", "log-validators":
@@ -82,7 +84,9 @@ func runConfigCmd(cmd *cobra.Command, args []string) error {
 			tree.Set(key, boolVal)
 		case "retain-blocks", "retain_interval_blocks", "get_logs_max_results",
 			"blocks_kept_ads", "blocks_kept_modb", "prune_every_n",
-			"recheck_threshold", "sig_cache_size", "trunk_cache_size":
+			"recheck_threshold", "sig_cache_size", "trunk_cache_size",
+			"watcher-finality-depth", "watcher-blocks-in-epoch", "watcher-epoch-switch-delay",
+			"watcher-blocks-to-clear-memory", "watcher-waiting-block-delay-time", "watcher-parallel-fetch-num":
 			uintVal, err := strconv.ParseUint(value, 10, 64)
 			if err != nil {
 				return err
@@ -2,16 +2,23 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/smartbch/smartbch/internal/ethutils"
 	"github.com/smartbch/smartbch/internal/testutils"
 )
 
 const (
-	flagNumber   = "number"
-	flagShowAddr = "show-address"
+	flagNumber      = "number"
+	flagShowAddr    = "show-address"
+	flagKeystoreDir = "keystore-dir"
 )
 
 func GenTestKeysCmd(ctx *Context) *cobra.Command {
@@ -21,6 +28,9 @@ func GenTestKeysCmd(ctx *Context) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			n := viper.GetInt(flagNumber)
 			showAddr := viper.GetBool(flagShowAddr)
+			if dir := viper.GetString(flagKeystoreDir); dir != "" {
+				return genTestKeystoreFiles(dir, n)
+			}
 			for i := 0; i < n; i++ {
 				key, addr := testutils.GenKeyAndAddr()
 				if !showAddr {
@@ -36,5 +46,35 @@ func GenTestKeysCmd(ctx *Context) *cobra.Command {
 
 	cmd.Flags().UintP(flagNumber, "n", 10, "how many test keys to generate")
 	cmd.Flags().Bool(flagShowAddr, false, "show address")
+	cmd.Flags().String(flagKeystoreDir, "", "save the keys as encrypted keystore files in this directory, instead of printing them")
+	cmd.Flags().String(flagPasswordFile, "", "the file containing the keystore passphrase, which is prompted for if omitted")
 	return cmd
 }
+
+// All the keys are encrypted with the same passphrase. Only the addresses are printed.
+func genTestKeystoreFiles(dir string, n int) error {
+	passphrase, err := getPassphrase(0, "Passphrase: ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		hexKey, addr := testutils.GenKeyAndAddr()
+		key, _, err := ethutils.HexToPrivKey(hexKey)
+		if err != nil {
+			return err
+		}
+		keyJSON, err := ethutils.EncryptKeystore(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(dir, "key-"+strings.ToLower(addr.Hex()[2:])+".json")
+		if err = ioutil.WriteFile(file, keyJSON, 0600); err != nil {
+			return err
+		}
+		fmt.Println(addr.Hex(), file)
+	}
+	return nil
+}
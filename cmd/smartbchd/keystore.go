@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/smartbch/smartbch/internal/ethutils"
+)
+
+const (
+	flagKeystore     = "keystore"
+	flagPasswordFile = "password-file"
+)
+
+// Read the passphrases from a file, one per line. It is safer than passing them on the command line.
+func readPassphraseFile(file string) ([]string, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(bz), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], "\r")
+	}
+	return lines, nil
+}
+
+func promptPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	bz, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// Get the i-th passphrase from --password-file, or prompt for it if --password-file is not specified
+func getPassphrase(i int, prompt string) (string, error) {
+	file := viper.GetString(flagPasswordFile)
+	if file == "" {
+		return promptPassphrase(prompt)
+	}
+	passphrases, err := readPassphraseFile(file)
+	if err != nil {
+		return "", err
+	}
+	if i >= len(passphrases) {
+		return "", fmt.Errorf("no passphrase at line %d of %s", i+1, file)
+	}
+	return passphrases[i], nil
+}
+
+// Decrypt the keystore files and return their private keys in hex, in the same format as --unlock
+func unlockKeystoreFiles(files []string) ([]string, error) {
+	hexKeys := make([]string, 0, len(files))
+	for i, file := range files {
+		passphrase, err := getPassphrase(i, fmt.Sprintf("Passphrase of %s: ", file))
+		if err != nil {
+			return nil, err
+		}
+		key, err := ethutils.LoadKeystoreFile(file, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %s", file, err.Error())
+		}
+		hexKeys = append(hexKeys, ethutils.PrivKeyToHex(key))
+	}
+	return hexKeys, nil
+}
+
+// Load the operator's private key from --validator-key, or from --keystore after decrypting it
+func loadOperatorKey() (*ecdsa.PrivateKey, error) {
+	if file := viper.GetString(flagKeystore); file != "" {
+		passphrase, err := getPassphrase(0, "Passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		return ethutils.LoadKeystoreFile(file, passphrase)
+	}
+	if hexKey := viper.GetString(flagValKey); hexKey != "" {
+		priKey, _, err := ethutils.HexToPrivKey(hexKey)
+		return priKey, err
+	}
+	return nil, errors.New(flagValKey + " or " + flagKeystore + " is needed")
+}
@@ -39,6 +39,9 @@ func createSmartbchdCmd() *cobra.Command {
 	rootCmd.AddCommand(GenerateGenesisValidatorCmd(ctx))
 	rootCmd.AddCommand(AddGenesisValidatorCmd(ctx))
 	rootCmd.AddCommand(StakingCmd(ctx))
+	rootCmd.AddCommand(SignerCmd(ctx))
+	rootCmd.AddCommand(CheckInvariantsCmd(ctx))
+	rootCmd.AddCommand(RecordBCHBlocksCmd(ctx))
 	rootCmd.AddCommand(VersionCmd())
 	return rootCmd
 }
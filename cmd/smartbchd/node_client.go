@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartbch/smartbch/staking"
+)
+
+const (
+	flagRpcUrl    = "rpc-url"
+	flagBroadcast = "broadcast"
+)
+
+// A JSON-RPC client of a smartBCH node, used by the staking commands
+type nodeClient struct {
+	client *gethrpc.Client
+}
+
+func dialNode(url string) (*nodeClient, error) {
+	if url == "" {
+		return nil, errors.New(flagRpcUrl + " is missing")
+	}
+	client, err := gethrpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeClient{client: client}, nil
+}
+
+func (c *nodeClient) close() {
+	c.client.Close()
+}
+
+func (c *nodeClient) chainID() (*big.Int, error) {
+	var id hexutil.Big
+	if err := c.client.Call(&id, "eth_chainId"); err != nil {
+		return nil, err
+	}
+	return id.ToInt(), nil
+}
+
+func (c *nodeClient) nonce(addr common.Address) (uint64, error) {
+	var nonce hexutil.Uint64
+	if err := c.client.Call(&nonce, "eth_getTransactionCount", addr, "latest"); err != nil {
+		return 0, err
+	}
+	return uint64(nonce), nil
+}
+
+func (c *nodeClient) sendRawTx(txBytes []byte) (common.Hash, error) {
+	var txHash common.Hash
+	err := c.client.Call(&txHash, "eth_sendRawTransaction", hexutil.Bytes(txBytes))
+	return txHash, err
+}
+
+// call a view method of the staking contract at the latest height
+func (c *nodeClient) callStaking(data []byte) ([]byte, error) {
+	var out hexutil.Bytes
+	args := map[string]interface{}{
+		"to":   common.Address(staking.StakingContractAddress),
+		"data": hexutil.Bytes(data),
+	}
+	if err := c.client.Call(&out, "eth_call", args, "latest"); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) callRaw(method string, args ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.client.Call(&result, method, args...)
+	return result, err
+}
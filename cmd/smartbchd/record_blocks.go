@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/smartbch/smartbch/watcher"
+	"github.com/smartbch/smartbch/watcher/types"
+)
+
+const (
+	flagFromHeight = "from"
+	flagToHeight   = "to"
+	flagOutput     = "output"
+)
+
+func RecordBCHBlocksCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record-bch-blocks",
+		Short: "record BCH mainnet blocks into a JSON or CBOR file, which can be replayed with --mainnet-blocks-file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from := viper.GetInt64(flagFromHeight)
+			to := viper.GetInt64(flagToHeight)
+			if from <= 0 || to < from {
+				return errors.New("invalid height range")
+			}
+			output := viper.GetString(flagOutput)
+			if output == "" {
+				return errors.New("output file is not specified")
+			}
+
+			client := watcher.NewRpcClient(viper.GetString(flagMainnetUrl),
+				viper.GetString(flagMainnetRpcUser), viper.GetString(flagMainnetRpcPassword),
+				"text/plain;", ctx.Logger)
+			if client == nil {
+				return errors.New("mainnet rpc url is not specified")
+			}
+			blocks := make([]*types.BCHBlock, 0, to-from+1)
+			for h := from; h <= to; h++ {
+				blk := client.GetBlockByHeight(h, false)
+				if blk == nil {
+					return fmt.Errorf("failed to get BCH block at height %d", h)
+				}
+				blocks = append(blocks, blk)
+			}
+			if err := watcher.WriteBlockSummaries(output, blocks); err != nil {
+				return err
+			}
+			fmt.Printf("recorded %d blocks into %s\n", len(blocks), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagMainnetUrl, "tcp://:8432", "BCH Mainnet RPC URL")
+	cmd.Flags().String(flagMainnetRpcUser, "user", "BCH Mainnet RPC user name")
+	cmd.Flags().String(flagMainnetRpcPassword, "88888888", "BCH Mainnet RPC user password")
+	cmd.Flags().Int64(flagFromHeight, 0, "the first BCH block height to record")
+	cmd.Flags().Int64(flagToHeight, 0, "the last BCH block height to record")
+	cmd.Flags().String(flagOutput, "bch_blocks.json", "the file to write the recorded blocks into, in CBOR if it ends with .cbor, otherwise in JSON")
+	return cmd
+}
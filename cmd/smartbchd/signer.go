@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	tmcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/cli"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	pvm "github.com/tendermint/tendermint/privval"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	flagNodeAddr  = "node-addr"
+	flagInitState = "init-state"
+
+	remoteSignerWaitTime      = time.Minute
+	remoteSignerRetries       = 50 // 50 * 100ms = 5s total
+	remoteSignerRetryInterval = 100 * time.Millisecond
+	signerDialInterval        = time.Second
+	signerReadWriteTimeout    = 3 * time.Second
+)
+
+// Use the remote signer listened at remoteSignerAddr if it is not empty, otherwise the local key file
+func loadPrivValidator(nodeCfg *tmcfg.Config, remoteSignerAddr string, logger tmlog.Logger) (tmtypes.PrivValidator, error) {
+	if remoteSignerAddr == "" {
+		return pvm.LoadOrGenFilePV(nodeCfg.PrivValidatorKeyFile(), nodeCfg.PrivValidatorStateFile()), nil
+	}
+	genDoc, err := tmtypes.GenesisDocFromFile(nodeCfg.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	listener, err := pvm.NewSignerListener(remoteSignerAddr, logger.With("module", "privval"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for remote signer: %w", err)
+	}
+	client, err := pvm.NewSignerClient(listener, genDoc.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start remote signer client: %w", err)
+	}
+	logger.Info("waiting for remote signer", "addr", remoteSignerAddr)
+	if err = client.WaitForConnection(remoteSignerWaitTime); err != nil {
+		return nil, fmt.Errorf("remote signer is not connected: %w", err)
+	}
+	pubKey, err := client.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey from remote signer: %w", err)
+	}
+	logger.Info("remote signer connected", "pubkey", pubKey)
+	return pvm.NewRetrySignerClient(client, remoteSignerRetries, remoteSignerRetryInterval), nil
+}
+
+// The signer keeps the consensus key on a separate host and dials the validator node, whose
+// remote-signer-laddr must be set. The last signed height, round and step are saved in the state
+// file before a signature is sent out, so it never signs conflicting votes or proposals, even after
+// restarting. That is why the state file must be moved together with the key file.
+func SignerCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "run a remote signer of the validator's consensus key, which dials the validator node",
+		Example: `
+smartbchd signer --node-addr=tcp://10.0.0.2:26659 --chain-id=0x2710
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := ctx.Config.NodeConfig
+			c.SetRoot(viper.GetString(cli.HomeFlag))
+			chainID := viper.GetString(flagChainId)
+			if chainID == "" {
+				return errors.New(flagChainId + " is missing")
+			}
+			keyFile, stateFile := c.PrivValidatorKeyFile(), c.PrivValidatorStateFile()
+			if _, err := os.Stat(keyFile); err != nil {
+				return err
+			}
+			var filePV *pvm.FilePV
+			if _, err := os.Stat(stateFile); err == nil {
+				filePV = pvm.LoadFilePV(keyFile, stateFile)
+			} else if viper.GetBool(flagInitState) {
+				filePV = pvm.LoadFilePVEmptyState(keyFile, stateFile)
+				filePV.Save()
+			} else {
+				return fmt.Errorf("%s is missing, use --%s only if this key has never signed on any host", stateFile, flagInitState)
+			}
+
+			dialer, err := getSignerDialer(viper.GetString(flagNodeAddr))
+			if err != nil {
+				return err
+			}
+			logger := ctx.Logger.With("module", "signer")
+			endpoint := pvm.NewSignerDialerEndpoint(logger, dialer,
+				pvm.SignerDialerEndpointRetryWaitInterval(signerDialInterval),
+				pvm.SignerDialerEndpointConnRetries(math.MaxInt32),
+				pvm.SignerDialerEndpointTimeoutReadWrite(signerReadWriteTimeout))
+			server := pvm.NewSignerServer(endpoint, chainID, filePV)
+			if err = server.Start(); err != nil {
+				return err
+			}
+			pubKey, _ := filePV.GetPubKey()
+			logger.Info("signer started", "pubkey", pubKey, "node", viper.GetString(flagNodeAddr))
+			TrapSignal(func() {
+				_ = server.Stop()
+			})
+			select {}
+		},
+	}
+
+	cmd.Flags().String(flagNodeAddr, "", "the remote-signer-laddr of the validator node, tcp://host:port or unix://path")
+	cmd.Flags().String(flagChainId, "", "the chain id in the genesis file")
+	cmd.Flags().Bool(flagInitState, false, "create an empty state file if it is missing")
+	_ = cmd.MarkFlagRequired(flagNodeAddr)
+	return cmd
+}
+
+func getSignerDialer(nodeAddr string) (pvm.SocketDialer, error) {
+	protocol, address := tmnet.ProtocolAndAddress(nodeAddr)
+	switch protocol {
+	case "unix":
+		return pvm.DialUnixFn(address), nil
+	case "tcp":
+		// the connection is encrypted with an ephemeral key, just like the one used by the listener
+		return pvm.DialTCPFn(address, signerReadWriteTimeout, ed25519.GenPrivKey()), nil
+	default:
+		return nil, fmt.Errorf("invalid node address: %s", nodeAddr)
+	}
+}
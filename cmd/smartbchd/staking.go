@@ -22,12 +22,18 @@ import (
 const (
 	flagRewardTo = "reward-to"
 	flagType     = "type"
+	flagTarget   = "target"
 
 	create              = "create"
 	edit                = "edit"
 	retire              = "retire"
 	increaseMinGasPrice = "increase"
 	decreaseMinGasPrice = "decrease"
+	proposal            = "proposal"
+	vote                = "vote"
+	executeProposal     = "execute-proposal"
+	increaseStake       = "increase-stake"
+	decreaseStake       = "decrease-stake"
 )
 
 func StakingCmd(ctx *Context) *cobra.Command {
@@ -46,34 +52,80 @@ smartbchd staking \
 --gas-price=1000 \
 --type="create" \
 --verbose
+
+smartbchd staking \
+--keystore=UTC--2021-08-01T00-00-00.000000000Z--9887310499db9e65411fc0a57689b4429755c372 \
+--password-file=password.txt \
+--rpc-url=http://127.0.0.1:8545 \
+--type="proposal" \
+--target=12000000000 \
+--broadcast
 `,
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			c := ctx.Config.NodeConfig
 			c.SetRoot(viper.GetString(cli.HomeFlag))
 
 			// get private key
-			priKey, _, err := ethutils.HexToPrivKey(viper.GetString(flagValKey))
+			priKey, err := loadOperatorKey()
 			if err != nil {
 				return fmt.Errorf("private key parse error: " + err.Error())
 			}
+			// the nonce and chain id are fetched from the node if they are not specified
+			var client *nodeClient
+			if viper.GetString(flagRpcUrl) != "" {
+				client, err = dialNode(viper.GetString(flagRpcUrl))
+				if err != nil {
+					return fmt.Errorf("dial node error: %s", err.Error())
+				}
+				defer client.close()
+			}
 			nonce := viper.GetUint64(flagNonce)
-			//todo: get chain id in config.toml
-			//chainID := ctx.Config.ChainID()
-			chainID, err := parseChainID(viper.GetString(flagChainId))
-			if err != nil {
-				return fmt.Errorf("parse chain id errpr: %s", err.Error())
+			if !cmd.Flags().Changed(flagNonce) && client != nil {
+				nonce, err = client.nonce(ethutils.PrivKeyToAddr(priKey))
+				if err != nil {
+					return fmt.Errorf("get nonce error: %s", err.Error())
+				}
+			}
+			var chainID *big.Int
+			if cmd.Flags().Changed(flagChainId) || client == nil {
+				id, err := parseChainID(viper.GetString(flagChainId))
+				if err != nil {
+					return fmt.Errorf("parse chain id errpr: %s", err.Error())
+				}
+				chainID = id.ToBig()
+			} else {
+				chainID, err = client.chainID()
+				if err != nil {
+					return fmt.Errorf("get chain id error: %s", err.Error())
+				}
+			}
+			if viper.GetBool(flagBroadcast) && client == nil {
+				return errors.New(flagRpcUrl + " is needed to broadcast the tx")
 			}
 
 			fType := viper.GetString(flagType)
 			if fType == retire {
 				data := staking.PackRetire()
-				return printSignedTx(big.NewInt(0), data, nonce, priKey, chainID.ToBig())
+				return printSignedTx(client, big.NewInt(0), data, nonce, priKey, chainID)
 			} else if fType == increaseMinGasPrice {
 				data := staking.PackIncreaseMinGasPrice()
-				return printSignedTx(big.NewInt(0), data, nonce, priKey, chainID.ToBig())
+				return printSignedTx(client, big.NewInt(0), data, nonce, priKey, chainID)
 			} else if fType == decreaseMinGasPrice {
 				data := staking.PackDecreaseMinGasPrice()
-				return printSignedTx(big.NewInt(0), data, nonce, priKey, chainID.ToBig())
+				return printSignedTx(client, big.NewInt(0), data, nonce, priKey, chainID)
+			} else if fType == executeProposal {
+				data := staking.PackExecuteProposal()
+				return printSignedTx(client, big.NewInt(0), data, nonce, priKey, chainID)
+			} else if fType == proposal || fType == vote {
+				target, ok := big.NewInt(0).SetString(viper.GetString(flagTarget), 10)
+				if !ok || target.Sign() <= 0 {
+					return errors.New("invalid " + flagTarget)
+				}
+				data := staking.PackProposal(target)
+				if fType == vote {
+					data = staking.PackVote(target)
+				}
+				return printSignedTx(client, big.NewInt(0), data, nonce, priKey, chainID)
 			}
 
 			// get staking coin
@@ -81,6 +133,13 @@ smartbchd staking \
 			if !success {
 				return fmt.Errorf("staking coin parse failed")
 			}
+			if fType == increaseStake {
+				return printSignedTx(client, sCoin.ToBig(), staking.PackIncreaseStake(), nonce, priKey, chainID)
+			} else if fType == decreaseStake {
+				// the withdrawn coins are paid back to rewardTo after the unbonding epochs
+				data := staking.PackDecreaseStake(sCoin.ToBig())
+				return printSignedTx(client, big.NewInt(0), data, nonce, priKey, chainID)
+			}
 			// generate edit validator info
 
 			var intro [32]byte
@@ -94,7 +153,7 @@ smartbchd staking \
 
 			if fType == edit {
 				data := staking.PackEditValidator(rewardTo, intro)
-				return printSignedTx(sCoin.ToBig(), data, nonce, priKey, chainID.ToBig())
+				return printSignedTx(client, sCoin.ToBig(), data, nonce, priKey, chainID)
 			}
 
 			if fType == create {
@@ -109,7 +168,7 @@ smartbchd staking \
 				var pubkey [32]byte
 				copy(pubkey[:], pk)
 				data := staking.PackCreateValidator(rewardTo, intro, pubkey)
-				return printSignedTx(sCoin.ToBig(), data, nonce, priKey, chainID.ToBig())
+				return printSignedTx(client, sCoin.ToBig(), data, nonce, priKey, chainID)
 			}
 
 			return errors.New("invalid staking function type")
@@ -121,22 +180,27 @@ smartbchd staking \
 	cmd.Flags().Int64(flagVotingPower, 0, "voting power")
 	cmd.Flags().String(flagStakingCoin, "0", "staking coin")
 	cmd.Flags().String(flagRewardTo, "", "validator rewardTo address")
-	cmd.Flags().String(flagType, "", "validator function type, including create, edit, retire, increase, decrease")
+	cmd.Flags().String(flagType, "", "validator function type, including create, edit, retire, increase, decrease, proposal, vote, execute-proposal, increase-stake, decrease-stake")
+	cmd.Flags().String(flagTarget, "", "the target min gas price of proposal and vote")
 	cmd.Flags().String(flagIntroduction, "", "introduction")
 	cmd.Flags().Bool(flagVerbose, false, "display verbose information")
 	cmd.Flags().Uint64(flagGasPrice, 1500000000, "specify gas price")
-	cmd.Flags().String(flagChainId, "", "specify gas price")
-	cmd.Flags().Uint64(flagNonce, 1, "specify tx nonce")
+	cmd.Flags().String(flagChainId, "", "specify chain id, fetched from the node if omitted")
+	cmd.Flags().Uint64(flagNonce, 1, "specify tx nonce, fetched from the node if omitted")
 	cmd.Flags().String(flagValKey, "", "specify from address private key")
+	cmd.Flags().String(flagKeystore, "", "the encrypted keystore file of the from address, used instead of --validator-key")
+	cmd.Flags().String(flagPasswordFile, "", "the file containing the keystore passphrase, which is prompted for if omitted")
+	cmd.Flags().String(flagRpcUrl, "", "the JSON-RPC url of a smartBCH node, used to get nonce and chain id")
+	cmd.Flags().Bool(flagBroadcast, false, "send the signed tx to the node specified by --rpc-url")
 
 	_ = cmd.MarkFlagRequired(flagType)
-	_ = cmd.MarkFlagRequired(flagValKey)
-	_ = cmd.MarkFlagRequired(flagChainId)
-	_ = cmd.MarkFlagRequired(flagNonce)
+	cmd.AddCommand(StakingQueryCmd())
+	cmd.AddCommand(StakingSimulateCmd())
 	return cmd
 }
 
-func printSignedTx(value *big.Int, data []byte, nonce uint64, priKey *ecdsa.PrivateKey, chainID *big.Int) error {
+// print the signed tx, and broadcast it with client if --broadcast is specified
+func printSignedTx(client *nodeClient, value *big.Int, data []byte, nonce uint64, priKey *ecdsa.PrivateKey, chainID *big.Int) error {
 	to := common.Address(staking.StakingContractAddress)
 
 	txData := &gethtypes.LegacyTx{
@@ -161,5 +225,12 @@ func printSignedTx(value *big.Int, data []byte, nonce uint64, priKey *ecdsa.Priv
 		out, _ := tx.MarshalJSON()
 		fmt.Println(string(out))
 	}
+	if viper.GetBool(flagBroadcast) {
+		txHash, err := client.sendRawTx(txBytes)
+		if err != nil {
+			return fmt.Errorf("broadcast tx error: %s", err.Error())
+		}
+		fmt.Println("tx hash: " + txHash.Hex())
+	}
 	return nil
 }
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/smartbch/smartbch/staking"
+)
+
+const (
+	flagStart     = "start"
+	flagEnd       = "end"
+	flagValidator = "validator"
+)
+
+func StakingQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "query the staking states from a smartBCH node over JSON-RPC",
+		Example: `
+smartbchd staking query validators --rpc-url=http://127.0.0.1:8545
+smartbchd staking query epochs --start=1 --end=5 --rpc-url=http://127.0.0.1:8545
+smartbchd staking query min-gas-price --rpc-url=http://127.0.0.1:8545
+smartbchd staking query votes --rpc-url=http://127.0.0.1:8545
+`,
+	}
+	cmd.PersistentFlags().String(flagRpcUrl, "http://127.0.0.1:8545", "the JSON-RPC url of a smartBCH node")
+
+	cmd.AddCommand(queryValidatorsCmd())
+	cmd.AddCommand(queryEpochsCmd())
+	cmd.AddCommand(queryMinGasPriceCmd())
+	cmd.AddCommand(queryVotesCmd())
+	return cmd
+}
+
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func queryValidatorsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validators",
+		Short: "print all the validators and the current epoch's info",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := dialNode(viper.GetString(flagRpcUrl))
+			if err != nil {
+				return err
+			}
+			defer client.close()
+			result, err := client.callRaw("sbch_validatorsInfo")
+			if err != nil {
+				return err
+			}
+			return printJSON(result)
+		},
+	}
+}
+
+func queryEpochsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "epochs",
+		Short: "print the epochs in [start, end)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := dialNode(viper.GetString(flagRpcUrl))
+			if err != nil {
+				return err
+			}
+			defer client.close()
+			result, err := client.callRaw("sbch_getEpochs",
+				hexutil.Uint64(viper.GetUint64(flagStart)), hexutil.Uint64(viper.GetUint64(flagEnd)))
+			if err != nil {
+				return err
+			}
+			return printJSON(result)
+		},
+	}
+	cmd.Flags().Uint64(flagStart, 1, "the first epoch number")
+	cmd.Flags().Uint64(flagEnd, 0, "the epoch number after the last one, start+10 if omitted")
+	return cmd
+}
+
+func queryMinGasPriceCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "min-gas-price",
+		Short: "print the current min gas price in wei",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := dialNode(viper.GetString(flagRpcUrl))
+			if err != nil {
+				return err
+			}
+			defer client.close()
+			out, err := client.callStaking(staking.PackGetMinGasPrice())
+			if err != nil {
+				return err
+			}
+			fmt.Println(big.NewInt(0).SetBytes(out).String())
+			return nil
+		},
+	}
+}
+
+type minGasPriceVote struct {
+	Validator common.Address `json:"validator"`
+	Target    string         `json:"target"` // zero if the validator has not voted
+}
+
+func queryVotesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "votes",
+		Short: "print the min gas price targets voted by a validator, or by all the active validators if --validator is omitted",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := dialNode(viper.GetString(flagRpcUrl))
+			if err != nil {
+				return err
+			}
+			defer client.close()
+			var validators []common.Address
+			if addr := viper.GetString(flagValidator); addr != "" {
+				if !common.IsHexAddress(addr) {
+					return fmt.Errorf("invalid validator address: %s", addr)
+				}
+				validators = append(validators, common.HexToAddress(addr))
+			} else {
+				out, err := client.callStaking(staking.PackGetActiveValidators())
+				if err != nil {
+					return err
+				}
+				validators, _, _ = staking.UnpackGetActiveValidatorsReturnData(out)
+			}
+			votes := make([]*minGasPriceVote, 0, len(validators))
+			for _, val := range validators {
+				out, err := client.callStaking(staking.PackGetVote(val))
+				if err != nil {
+					return err
+				}
+				votes = append(votes, &minGasPriceVote{
+					Validator: val,
+					Target:    big.NewInt(0).SetBytes(out).String(),
+				})
+			}
+			return printJSON(votes)
+		},
+	}
+	cmd.Flags().String(flagValidator, "", "the validator's address")
+	return cmd
+}
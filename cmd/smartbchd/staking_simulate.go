@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/smartbch/staking/simulation"
+)
+
+const (
+	flagScenario = "scenario"
+)
+
+func StakingSimulateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "run a staking scenario in memory and print the voting powers, validator updates, slashes and rewards of each epoch",
+		Example: `
+smartbchd staking simulate --scenario=scenario.json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scenarioFile := viper.GetString(flagScenario)
+			if scenarioFile == "" {
+				return errors.New("scenario file is not specified")
+			}
+			bz, err := ioutil.ReadFile(scenarioFile)
+			if err != nil {
+				return err
+			}
+			var scenario simulation.Scenario
+			if err = json.Unmarshal(bz, &scenario); err != nil {
+				return fmt.Errorf("parse scenario error: %s", err.Error())
+			}
+			// the epoch switching logs are dropped to keep the output parsable
+			results, err := simulation.Run(&scenario, log.NewNopLogger())
+			if err != nil {
+				return err
+			}
+			out, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagScenario, "", "the JSON file of the validators at genesis and the epochs to simulate")
+	return cmd
+}
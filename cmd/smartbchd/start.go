@@ -17,13 +17,13 @@ import (
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/p2p"
-	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	tmrpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/smartbch/smartbch/api"
 	"github.com/smartbch/smartbch/app"
+	"github.com/smartbch/smartbch/param"
 	"github.com/smartbch/smartbch/rpc"
 )
 
@@ -42,6 +42,7 @@ const (
 	flagMaxHeaderBytes         = "rpc.max-header-bytes"
 	flagRetainBlocks           = "retain-blocks"
 	flagUnlock                 = "unlock"
+	flagUnlockKeystore         = "unlock-keystore"
 	flagGenesisMainnetHeight   = "mainnet-genesis-height"
 	flagCCGenesisMainnetHeight = "crosschain-genesis-height"
 	flagMainnetUrl             = "mainnet-rpc-url"
@@ -49,10 +50,13 @@ const (
 	flagMainnetRpcPassword     = "mainnet-rpc-password"
 	flagSmartBchUrl            = "smartbch-url"
 	flagWatcherSpeedup         = "watcher-speedup"
+	flagMainnetBlocksFile      = "mainnet-blocks-file"
 	flagRpcOnly                = "rpc-only"
 	flagArchiveMode            = "archive-mode"
 	flagSkipSanityCheck        = "skip-sanity-check"
 	flagWithSyncDB             = "with-syncdb"
+	flagRemoteSignerLaddr      = "remote-signer-laddr"
+	flagInvariantCheck         = "invariant-check"
 )
 
 func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
@@ -89,17 +93,22 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Uint(flagMaxHeaderBytes, uint(defaultRpcCfg.MaxHeaderBytes), "max header bytes of RPC server")
 	cmd.Flags().Uint(flagMaxBodyBytes, uint(defaultRpcCfg.MaxBodyBytes), "max body bytes of RPC server")
 	cmd.Flags().String(flagUnlock, "", "Comma separated list of private keys to unlock (only for testing)")
+	cmd.Flags().String(flagUnlockKeystore, "", "Comma separated list of encrypted keystore files to unlock")
+	cmd.Flags().String(flagPasswordFile, "", "File containing the passphrases of --unlock-keystore, one per line. They are prompted for if omitted")
 	cmd.Flags().String(flagMainnetUrl, "tcp://:8432", "BCH Mainnet RPC URL")
 	cmd.Flags().String(flagMainnetRpcUser, "user", "BCH Mainnet RPC user name")
 	cmd.Flags().String(flagMainnetRpcPassword, "88888888", "BCH Mainnet RPC user password")
 	cmd.Flags().String(flagSmartBchUrl, "tcp://:8545", "SmartBch RPC URL")
 	cmd.Flags().Bool(flagWatcherSpeedup, false, "Watcher Speedup")
+	cmd.Flags().String(flagMainnetBlocksFile, "", "Replay BCH blocks recorded in this JSON/CBOR file, or in the .json and .cbor files of this directory, instead of using the mainnet RPC")
 	cmd.Flags().Bool(flagRpcOnly, false, "Start RPC server even tmnode is not started correctly, only useful for debug purpose")
 	cmd.Flags().String(flagRpcAPI, "eth,web3,net,txpool,sbch,tm", "API's offered over the HTTP-RPC interface")
 	cmd.Flags().String(flagWsAPI, "eth,web3,net,txpool,sbch,tm", "API's offered over the WS-RPC interface")
 	cmd.Flags().Bool(flagArchiveMode, false, "enable archive-mode")
 	cmd.Flags().Bool(flagSkipSanityCheck, false, "skip sanity check when node start")
 	cmd.Flags().Bool(flagWithSyncDB, false, "enable syncdb")
+	cmd.Flags().String(flagRemoteSignerLaddr, "", "listen on this address for a remote signer which keeps the consensus key")
+	cmd.Flags().String(flagInvariantCheck, param.InvariantCheckOff, "check the staking invariants at each commit: off, alert or halt")
 
 	return cmd
 }
@@ -113,6 +122,24 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the keystore passphrases are prompted for before the node starts printing logs
+	var unlockedKeys []string
+	if keys := viper.GetString(flagUnlock); keys != "" {
+		unlockedKeys = strings.Split(keys, ",")
+	}
+	if files := viper.GetString(flagUnlockKeystore); files != "" {
+		keys, err := unlockKeystoreFiles(strings.Split(files, ","))
+		if err != nil {
+			return nil, err
+		}
+		unlockedKeys = append(unlockedKeys, keys...)
+	}
+	if _, err = ctx.Config.AppConfig.GetWatcherParams(); err != nil {
+		return nil, err
+	}
+	if !ctx.Config.AppConfig.IsInvariantCheckValid() {
+		return nil, fmt.Errorf("invalid %s: %s", flagInvariantCheck, ctx.Config.AppConfig.InvariantCheck)
+	}
 	_app := appCreator(ctx.Logger, chainID, ctx.Config)
 	appImpl := _app.(*app.App)
 
@@ -123,7 +150,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	fmt.Printf("This Node ID: %s\n", nodeKey.ID())
 
 	rpcOnly := viper.GetBool(flagRpcOnly)
-	tmNode, err := startTmNode(nodeCfg, nodeKey, _app,
+	tmNode, err := startTmNode(nodeCfg, nodeKey, _app, ctx.Config.AppConfig.RemoteSignerListenAddr,
 		ctx.Logger.With("module", "node"))
 	if err != nil {
 		if !rpcOnly {
@@ -158,13 +185,12 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	rpcAddrSecure := viper.GetString(flagRpcAddrSecure)
 	wsAddrSecure := viper.GetString(flagWsAddrSecure)
 	corsDomain := viper.GetString(flagCorsDomain)
-	unlockedKeys := viper.GetString(flagUnlock)
 	certfileDir := filepath.Join(nodeCfg.RootDir, "nodeCfg/cert.pem")
 	keyfileDir := filepath.Join(nodeCfg.RootDir, "nodeCfg/key.pem")
 	httpAPI := viper.GetString(flagRpcAPI)
 	wsAPI := viper.GetString(flagWsAPI)
 	rpcServer := rpc.NewServer(rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, certfileDir, keyfileDir,
-		serverCfg, rpcBackend, ctx.Logger, strings.Split(unlockedKeys, ","), httpAPI, wsAPI)
+		serverCfg, rpcBackend, ctx.Logger, unlockedKeys, httpAPI, wsAPI)
 
 	if err := rpcServer.Start(); err != nil {
 		return nil, err
@@ -185,11 +211,16 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 func startTmNode(nodeCfg *tmcfg.Config,
 	nodeKey *p2p.NodeKey,
 	_app abci.Application,
+	remoteSignerAddr string,
 	logger tmlog.Logger) (*node.Node, error) {
 
+	privValidator, err := loadPrivValidator(nodeCfg, remoteSignerAddr, logger)
+	if err != nil {
+		return nil, err
+	}
 	tmNode, err := node.NewNode(
 		nodeCfg,
-		pvm.LoadOrGenFilePV(nodeCfg.PrivValidatorKeyFile(), nodeCfg.PrivValidatorStateFile()),
+		privValidator,
 		nodeKey,
 		proxy.NewLocalClientCreator(_app),
 		node.DefaultGenesisDocProviderFunc(nodeCfg),
@@ -22,6 +22,10 @@ func HexToPrivKey(key string) (*ecdsa.PrivateKey, []byte, error) {
 	return privKey, data, err
 }
 
+func PrivKeyToHex(key *ecdsa.PrivateKey) string {
+	return hex.EncodeToString(crypto.FromECDSA(key))
+}
+
 func HexToPubKey(key string) (ed25519.PubKey, []byte, error) {
 	key = strings.TrimSpace(key)
 	key = strings.TrimPrefix(key, "0x")
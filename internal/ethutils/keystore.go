@@ -0,0 +1,38 @@
+package ethutils
+
+import (
+	"crypto/ecdsa"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// Decrypt a geth-compatible keystore file, whose private key is encrypted with a passphrase using scrypt
+func LoadKeystoreFile(path, passphrase string) (*ecdsa.PrivateKey, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey, nil
+}
+
+// Encrypt a private key into the JSON of a geth-compatible keystore file. Use keystore.StandardScryptN and
+// keystore.StandardScryptP unless the keys are only for test.
+func EncryptKeystore(privKey *ecdsa.PrivateKey, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	key := &keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(privKey.PublicKey),
+		PrivateKey: privKey,
+	}
+	return keystore.EncryptKey(key, passphrase, scryptN, scryptP)
+}
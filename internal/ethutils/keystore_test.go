@@ -0,0 +1,35 @@
+package ethutils_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/smartbch/internal/ethutils"
+	"github.com/smartbch/smartbch/internal/testutils"
+)
+
+func TestKeystore(t *testing.T) {
+	hexKey, addr := testutils.GenKeyAndAddr()
+	key, _, err := ethutils.HexToPrivKey(hexKey)
+	require.NoError(t, err)
+	keyJSON, err := ethutils.EncryptKeystore(key, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "key.json")
+	require.NoError(t, ioutil.WriteFile(path, keyJSON, 0600))
+
+	loaded, err := ethutils.LoadKeystoreFile(path, "secret")
+	require.NoError(t, err)
+	require.Equal(t, addr, ethutils.PrivKeyToAddr(loaded))
+	require.Equal(t, key.D, loaded.D)
+	_, err = ethutils.LoadKeystoreFile(path, "wrong")
+	require.Error(t, err)
+}
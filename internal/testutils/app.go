@@ -68,19 +68,22 @@ type TestAppInitArgs struct {
 	PrivKeys    []string
 	ArchiveMode bool
 	WithSyncDB  bool
+	// the watcher follows the BCH node at MainnetRPCUrl, with the watcher params of Network
+	MainnetRPCUrl string
+	Network       string
 }
 
 func CreateTestApp(keys ...string) *TestApp {
 	return createTestApp0(0, time.Now(), ed25519.GenPrivKey().PubKey(), bigutils.NewU256(DefaultInitBalance),
-		keys, false, false)
+		keys, false, false, "", "")
 }
 func CreateTestAppInArchiveMode(keys ...string) *TestApp {
 	return createTestApp0(0, time.Now(), ed25519.GenPrivKey().PubKey(), bigutils.NewU256(DefaultInitBalance),
-		keys, true, false)
+		keys, true, false, "", "")
 }
 func CreateTestAppWithSyncDB(keys ...string) *TestApp {
 	return createTestApp0(0, time.Now(), ed25519.GenPrivKey().PubKey(), bigutils.NewU256(DefaultInitBalance),
-		keys, true, true)
+		keys, true, true, "", "")
 }
 
 func CreateTestAppWithArgs(args TestAppInitArgs) *TestApp {
@@ -105,11 +108,11 @@ func CreateTestAppWithArgs(args TestAppInitArgs) *TestApp {
 	}
 
 	return createTestApp0(startHeight, startTime, pubKey, initAmt, args.PrivKeys,
-		args.ArchiveMode, args.WithSyncDB)
+		args.ArchiveMode, args.WithSyncDB, args.MainnetRPCUrl, args.Network)
 }
 
 func createTestApp0(startHeight int64, startTime time.Time, valPubKey crypto.PubKey, initAmt *uint256.Int, keys []string,
-	archiveMode bool, withSyncDB bool) *TestApp {
+	archiveMode bool, withSyncDB bool, mainnetRPCUrl, network string) *TestApp {
 
 	err := os.RemoveAll(testAdsDir)
 	if err != nil {
@@ -125,6 +128,8 @@ func createTestApp0(startHeight int64, startTime time.Time, valPubKey crypto.Pub
 	params.AppConfig.SyncdbDataPath = testSyncDir
 	params.AppConfig.ArchiveMode = archiveMode
 	params.AppConfig.WithSyncDB = withSyncDB
+	params.AppConfig.MainnetRPCUrl = mainnetRPCUrl
+	params.AppConfig.Network = network
 	_app := app.NewApp(params, bigutils.NewU256(1), 0, 0, nopLogger, true)
 	//_app.Init(nil)
 	//_app.txEngine = ebp.NewEbpTxExec(10, 100, 1, 100, _app.signer)
@@ -0,0 +1,310 @@
+// Package bchnode provides a mock BCH fullnode which speaks the subset of BCHN's JSON-RPC
+// used by the watcher, so that the watcher (or a whole smartbchd) can be tested without BCHN.
+package bchnode
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/smartbch/smartbch/watcher/types"
+)
+
+const (
+	DefaultBlockInterval = 600 // seconds
+
+	// error codes used by BCHN
+	errCodeInvalidParameter = -8
+	errCodeInvalidAddrOrKey = -5
+	errCodeMethodNotFound   = -32601
+	errCodeParseError       = -32700
+)
+
+// MockBCHNode keeps a scriptable chain of blocks. Blocks are mined on the current tip and
+// may carry nominations of validators in their coinbase transactions. Reorg() drops blocks
+// from the tip, and the blocks mined after it form a competing chain.
+type MockBCHNode struct {
+	mtx sync.Mutex
+
+	chain        []*types.BlockInfo // the main chain, indexed by height
+	blocksByHash map[string]*types.BlockInfo
+	txs          map[string]*types.TxInfo
+	nonce        uint64
+
+	blockInterval int64
+
+	listener net.Listener
+	server   *http.Server
+}
+
+var _ http.Handler = (*MockBCHNode)(nil)
+
+// NewMockBCHNode creates a node with only a genesis block, whose timestamp is genesisTime
+func NewMockBCHNode(genesisTime int64) *MockBCHNode {
+	node := &MockBCHNode{
+		blocksByHash:  make(map[string]*types.BlockInfo),
+		txs:           make(map[string]*types.TxInfo),
+		blockInterval: DefaultBlockInterval,
+	}
+	node.addBlock(&types.BlockInfo{
+		Hash:              node.nextHash(),
+		Height:            0,
+		Time:              genesisTime,
+		MedianTime:        genesisTime,
+		PreviousBlockhash: "",
+	}, nil)
+	return node
+}
+
+func (node *MockBCHNode) SetBlockInterval(seconds int64) {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	node.blockInterval = seconds
+}
+
+// Start serves JSON-RPC on listenAddr, such as "127.0.0.1:8432" or "127.0.0.1:0" for a random port
+func (node *MockBCHNode) Start(listenAddr string) error {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return err
+	}
+	node.listener = listener
+	node.server = &http.Server{Handler: node}
+	go func() {
+		_ = node.server.Serve(listener)
+	}()
+	return nil
+}
+
+// URL returns the address to be used as "mainnet-rpc-url" after Start
+func (node *MockBCHNode) URL() string {
+	return "http://" + node.listener.Addr().String()
+}
+
+func (node *MockBCHNode) Stop() {
+	if node.server != nil {
+		_ = node.server.Close()
+	}
+}
+
+func (node *MockBCHNode) Height() int64 {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	return node.tip().Height
+}
+
+// BlockHash returns the hash of the main chain block at height, or "" if there is no such block
+func (node *MockBCHNode) BlockHash(height int64) string {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	if height < 0 || height >= int64(len(node.chain)) {
+		return ""
+	}
+	return node.chain[height].Hash
+}
+
+type WeightedNomination struct {
+	Pubkey [32]byte
+	Weight uint8
+}
+
+// MineBlock appends a block to the tip, whose coinbase transaction nominates the given
+// validators' pubkeys. The new block's hash is returned.
+func (node *MockBCHNode) MineBlock(nominations ...[32]byte) string {
+	var scripts []string
+	for _, pubkey := range nominations {
+		scripts = append(scripts, "OP_RETURN "+types.Identifier+types.Version+hex.EncodeToString(pubkey[:]))
+	}
+	return node.mineBlock(scripts)
+}
+
+// MineWeightedBlock appends a block to the tip, whose coinbase transaction splits its vote
+// among the given validators according to the weights
+func (node *MockBCHNode) MineWeightedBlock(nominations ...WeightedNomination) string {
+	script := "OP_RETURN " + types.Identifier + types.WeightedVersion
+	for _, n := range nominations {
+		script += hex.EncodeToString(n.Pubkey[:]) + hex.EncodeToString([]byte{n.Weight})
+	}
+	return node.mineBlock([]string{script})
+}
+
+// mineBlock appends a block whose coinbase transaction contains OP_RETURN outputs of the scripts
+func (node *MockBCHNode) mineBlock(opReturnScripts []string) string {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	parent := node.tip()
+	blk := &types.BlockInfo{
+		Hash:              node.nextHash(),
+		Height:            parent.Height + 1,
+		Version:           0x20000000,
+		VersionHex:        "20000000",
+		Time:              parent.Time + node.blockInterval,
+		MedianTime:        parent.Time,
+		PreviousBlockhash: parent.Hash,
+	}
+	node.addBlock(blk, []*types.TxInfo{node.newCoinbaseTx(blk, opReturnScripts)})
+	return blk.Hash
+}
+
+// MineBlocks mines n blocks, each of which nominates the given validators' pubkeys
+func (node *MockBCHNode) MineBlocks(n int, nominations ...[32]byte) {
+	for i := 0; i < n; i++ {
+		node.MineBlock(nominations...)
+	}
+}
+
+// Reorg removes the last depth blocks from the main chain. They can still be queried by hash,
+// with -1 confirmations, as BCHN does for orphaned blocks.
+func (node *MockBCHNode) Reorg(depth int) {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	if depth >= len(node.chain) {
+		panic("cannot reorg the genesis block")
+	}
+	node.chain = node.chain[:len(node.chain)-depth]
+}
+
+func (node *MockBCHNode) tip() *types.BlockInfo {
+	return node.chain[len(node.chain)-1]
+}
+
+func (node *MockBCHNode) nextHash() string {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], node.nonce)
+	node.nonce++
+	hash := sha256.Sum256(buf[:])
+	return hex.EncodeToString(hash[:])
+}
+
+func (node *MockBCHNode) addBlock(blk *types.BlockInfo, txs []*types.TxInfo) {
+	for _, tx := range txs {
+		tx.Blockhash = blk.Hash
+		tx.Time = blk.Time
+		tx.BlockTime = blk.Time
+		blk.Tx = append(blk.Tx, *tx)
+		node.txs[tx.TxID] = tx
+	}
+	blk.NumTx = len(blk.Tx)
+	node.chain = append(node.chain, blk)
+	node.blocksByHash[blk.Hash] = blk
+}
+
+func (node *MockBCHNode) newCoinbaseTx(blk *types.BlockInfo, opReturnScripts []string) *types.TxInfo {
+	txid := node.nextHash()
+	tx := &types.TxInfo{
+		TxID:    txid,
+		Hash:    txid,
+		Version: 1,
+		VinList: []map[string]interface{}{{
+			"coinbase": fmt.Sprintf("%08x", blk.Height),
+			"sequence": 0xffffffff,
+		}},
+		VoutList: []types.Vout{{
+			Value: 6.25,
+			N:     0,
+			ScriptPubKey: map[string]interface{}{
+				"asm":  "OP_DUP OP_HASH160 0000000000000000000000000000000000000000 OP_EQUALVERIFY OP_CHECKSIG",
+				"type": "pubkeyhash",
+			},
+		}},
+	}
+	for _, script := range opReturnScripts {
+		tx.VoutList = append(tx.VoutList, types.Vout{
+			N: len(tx.VoutList),
+			ScriptPubKey: map[string]interface{}{
+				"asm":  script,
+				"type": "nulldata",
+			},
+		})
+	}
+	return tx
+}
+
+// confirmations returns how many blocks confirm blk, or -1 if blk is not in the main chain
+func (node *MockBCHNode) confirmations(blk *types.BlockInfo) int {
+	if blk.Height >= int64(len(node.chain)) || node.chain[blk.Height] != blk {
+		return -1
+	}
+	return int(node.tip().Height-blk.Height) + 1
+}
+
+/* ------ JSON-RPC ------ */
+
+type rpcRequest struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	Id     json.RawMessage   `json:"id"`
+}
+
+type rpcResponse struct {
+	Result interface{}         `json:"result"`
+	Error  *types.JsonRpcError `json:"error"`
+	Id     json.RawMessage     `json:"id"`
+}
+
+func (node *MockBCHNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	var resp rpcResponse
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp.Error = &types.JsonRpcError{Code: errCodeParseError, Message: "Parse error"}
+	} else {
+		resp.Id = req.Id
+		resp.Result, resp.Error = node.handle(&req)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&resp)
+}
+
+func (node *MockBCHNode) handle(req *rpcRequest) (interface{}, *types.JsonRpcError) {
+	node.mtx.Lock()
+	defer node.mtx.Unlock()
+	switch req.Method {
+	case "getblockcount":
+		return node.tip().Height, nil
+	case "getblockhash":
+		var height int64
+		if len(req.Params) < 1 || json.Unmarshal(req.Params[0], &height) != nil {
+			return nil, invalidParams()
+		}
+		if height < 0 || height >= int64(len(node.chain)) {
+			return nil, &types.JsonRpcError{Code: errCodeInvalidParameter, Message: "Block height out of range"}
+		}
+		return node.chain[height].Hash, nil
+	case "getblock":
+		var hash string
+		if len(req.Params) < 1 || json.Unmarshal(req.Params[0], &hash) != nil {
+			return nil, invalidParams()
+		}
+		blk, ok := node.blocksByHash[hash]
+		if !ok {
+			return nil, &types.JsonRpcError{Code: errCodeInvalidAddrOrKey, Message: "Block not found"}
+		}
+		result := *blk
+		result.Confirmations = node.confirmations(blk)
+		return &result, nil
+	case "getrawtransaction":
+		var txid string
+		if len(req.Params) < 1 || json.Unmarshal(req.Params[0], &txid) != nil {
+			return nil, invalidParams()
+		}
+		tx, ok := node.txs[txid]
+		if !ok {
+			return nil, &types.JsonRpcError{Code: errCodeInvalidAddrOrKey,
+				Message: "No such mempool or blockchain transaction"}
+		}
+		result := *tx
+		result.Confirmations = node.confirmations(node.blocksByHash[tx.Blockhash])
+		return &result, nil
+	default:
+		return nil, &types.JsonRpcError{Code: errCodeMethodNotFound, Message: "Method not found"}
+	}
+}
+
+func invalidParams() *types.JsonRpcError {
+	return &types.JsonRpcError{Code: errCodeInvalidParameter, Message: "Invalid parameters"}
+}
@@ -0,0 +1,59 @@
+package bchnode
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/smartbch/watcher"
+)
+
+func TestMockBCHNode(t *testing.T) {
+	pubkey1 := [32]byte{0x1}
+	pubkey2 := [32]byte{0x2}
+	node := NewMockBCHNode(1000)
+	node.MineBlocks(3, pubkey1)
+	node.MineBlock(pubkey2)
+	node.MineBlock()
+	require.NoError(t, node.Start("127.0.0.1:0"))
+	defer node.Stop()
+
+	client := watcher.NewRpcClient(node.URL(), "user", "pass", "text/plain;", log.NewNopLogger())
+	require.Equal(t, int64(5), client.GetLatestHeight(false))
+
+	blk := client.GetBlockByHeight(3, false)
+	require.Equal(t, int64(3), blk.Height)
+	require.Equal(t, int64(1000+3*DefaultBlockInterval), blk.Timestamp)
+	require.Equal(t, node.BlockHash(2), fmtHash(blk.ParentBlk))
+	require.Len(t, blk.Nominations, 1)
+	require.Equal(t, pubkey1, blk.Nominations[0].Pubkey)
+	require.Equal(t, pubkey2, client.GetBlockByHeight(4, false).Nominations[0].Pubkey)
+	require.Len(t, client.GetBlockByHeight(5, false).Nominations, 0)
+	require.Nil(t, client.GetBlockByHeight(6, false))
+
+	bi, err := client.GetBlockInfo(node.BlockHash(5))
+	require.NoError(t, err)
+	require.Equal(t, 1, bi.Confirmations)
+	txInfo, err := client.GetTxInfo(bi.Tx[0].TxID, bi.Hash)
+	require.NoError(t, err)
+	require.Equal(t, bi.Hash, txInfo.Blockhash)
+
+	// reorg the last two blocks
+	orphaned := node.BlockHash(4)
+	node.Reorg(2)
+	require.Equal(t, int64(3), client.GetLatestHeight(false))
+	node.MineBlocks(3, pubkey2)
+	require.Equal(t, int64(6), client.GetLatestHeight(false))
+	require.NotEqual(t, orphaned, node.BlockHash(4))
+	bi, err = client.GetBlockInfo(orphaned)
+	require.NoError(t, err)
+	require.Equal(t, -1, bi.Confirmations)
+	blk = client.GetBlockByHeight(4, false)
+	require.Equal(t, node.BlockHash(3), fmtHash(blk.ParentBlk))
+}
+
+func fmtHash(hash [32]byte) string {
+	return hex.EncodeToString(hash[:])
+}
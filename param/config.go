@@ -21,6 +21,11 @@ const (
 	AppDataPath    = "app"
 	ModbDataPath   = "modb"
 	SyncdbDataPath = "syncdb"
+
+	// what to do when the staking invariants are broken at Commit
+	InvariantCheckOff   = "off"
+	InvariantCheckAlert = "alert"
+	InvariantCheckHalt  = "halt"
 )
 
 type AppConfig struct {
@@ -52,12 +57,40 @@ type AppConfig struct {
 	MainnetRPCPassword string `mapstructure:"mainnet-rpc-password"`
 	SmartBchRPCUrl     string `mapstructure:"smartbch-rpc-url"`
 	Speedup            bool   `mapstructure:"watcher-speedup"`
+	// If not empty, the watcher replays the BCH blocks recorded in this JSON/CBOR file (or the .json
+	// and .cbor files in this directory) instead of connecting to a BCH node
+	MainnetBlocksFile string `mapstructure:"mainnet-blocks-file"`
+	// The network profile of watcher params, empty means the network this binary is built for
+	Network string `mapstructure:"network"`
+	// Overrides of the network profile, zero means not overriding
+	WatcherFinalityDepth         int64 `mapstructure:"watcher-finality-depth"`
+	WatcherBlocksInEpoch         int64 `mapstructure:"watcher-blocks-in-epoch"`
+	WatcherEpochSwitchDelay      int64 `mapstructure:"watcher-epoch-switch-delay"`
+	WatcherBlocksToClearMemory   int   `mapstructure:"watcher-blocks-to-clear-memory"`
+	WatcherWaitingBlockDelayTime int   `mapstructure:"watcher-waiting-block-delay-time"`
+	WatcherParallelFetchNum      int   `mapstructure:"watcher-parallel-fetch-num"`
 
 	FrontierGasLimit uint64 `mapstructure:"frontier-gaslimit"`
 
 	ArchiveMode bool `mapstructure:"archive-mode"`
 
 	WithSyncDB bool `mapstructure:"with-syncdb"`
+
+	// If not empty, the validator's consensus key is kept by a remote signer (such as tmkms or
+	// "smartbchd signer") connecting to this address, instead of in priv_validator_key.json
+	RemoteSignerListenAddr string `mapstructure:"remote-signer-laddr"`
+
+	// Check the staking invariants at each Commit, "off" (default), "alert" to log an error or "halt" to panic
+	InvariantCheck string `mapstructure:"invariant-check"`
+}
+
+func (config *AppConfig) IsInvariantCheckValid() bool {
+	switch config.InvariantCheck {
+	case "", InvariantCheckOff, InvariantCheckAlert, InvariantCheckHalt:
+		return true
+	default:
+		return false
+	}
 }
 
 type ChainConfig struct {
@@ -91,6 +124,7 @@ func DefaultAppConfigWithHome(home string) *AppConfig {
 		PruneEveryN:             DefaultPruneEveryN,
 		MainnetRPCPassword:      "123456",
 		FrontierGasLimit:        uint64(BlockMaxGas / 200), //5Million gas
+		InvariantCheck:          InvariantCheckOff,
 	}
 }
 
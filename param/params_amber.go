@@ -40,10 +40,18 @@ const (
 	MinOnlineSignatures            int32  = 400
 	NotOnlineSlashAmountDivisor    uint64 = 10
 	DuplicateSigSlashAMountDivisor uint64 = 5
+	DelegationUnbondingEpochCount  int64  = 2   // undelegated coins can be withdrawn after so many epochs
+	ValidatorUnbondingEpochCount   int64  = 2   // staked coins of a retired validator are paid back after so many epochs
+	JailEpochCount                 int64  = 1   // a jailed validator can unjail itself after so many epochs
+	DowntimeSlashAmountDivisor     uint64 = 100 // used instead of NotOnlineSlashAmountDivisor after JailingForkHeight
+	UptimeHistoryWindowCount       int64  = 20  // how many online windows of signing history are kept for each validator
+	LightClientSlashAmountDivisor  uint64 = 5   // used for the evidences of light client attacks after SlashHistoryForkHeight
+	SlashHistoryMaxCount           int64  = 500 // how many latest slash records are kept
 
 	// network params
-	IsAmber                           bool  = true
-	AmberBlocksInEpochAfterXHedgeFork int64 = 2016 * 10 * 60 / 6
+	IsAmber                           bool   = true
+	AmberBlocksInEpochAfterXHedgeFork int64  = 2016 * 10 * 60 / 6
+	NetworkName                       string = "amber"
 
 	// fork params
 	XHedgeContractSequence uint64 = 0xc94 //0x943F4002b68365fCC8F62eC65c3003aEcd391c0e
@@ -51,4 +59,33 @@ const (
 	ShaGateForkBlock       int64  = 80000000
 	ShaGateSwitch          bool   = false
 	StakingForkHeight      int64  = 80000000
+	DelegationForkHeight   int64  = 80000000
+	StakingViewForkHeight  int64  = 80000000
+	// since which the validators and pending rewards in StakingInfo are stored in their own slots
+	StakingInfoStorageForkHeight int64 = 80000000
+	// since which the staked coins of retired validators are kept in an unbonding queue before paid back
+	ValidatorUnbondingForkHeight int64 = 80000000
+	// since which the staking contract emits events for validator operations, slashing, rewards and epochs
+	StakingEventsForkHeight int64 = 80000000
+	// since which validators missing too many signatures are jailed instead of retired
+	JailingForkHeight int64 = 80000000
+	// since which validators can accumulate their rewards for withdrawing, or compound them into the staked coins
+	RewardClaimingForkHeight int64 = 80000000
+	// since which the validators and the delivered rewards are saved in a snapshot when an epoch starts
+	EpochSnapshotForkHeight int64 = 80000000
+	// since which the whitelisted consensus parameters can be changed by on-chain governance proposals
+	ParamGovernanceForkHeight int64 = 80000000
+	// since which a validator can rotate its consensus pubkey, effective at the next epoch switch
+	KeyRotationForkHeight int64 = 80000000
+	// since which the signed, missed and proposed blocks of each validator are recorded for uptime queries
+	UptimeHistoryForkHeight int64 = 80000000
+	// since which light client attacks are slashed and all the slashes are recorded
+	SlashHistoryForkHeight int64 = 80000000
+	// since which a validator can increase or decrease its staked coins without retiring
+	StakeAdjustmentForkHeight int64 = 80000000
+	// since which the coins burnt by slashing are not put back to stakingAcc when the block reward is distributed
+	SlashBurningFixForkHeight int64 = 80000000
+
+	// BCH mainnet height since which a coinbase transaction can nominate multiple validators with weights
+	WeightedNominationMainnetHeight int64 = 80000000
 )
@@ -56,6 +56,34 @@ smartbch-rpc-url = "{{ .SmartBchRPCUrl }}"
 
 # open epoch get to speedup mainnet block catch, work with "smartbch_rpc_url"
 watcher-speedup = {{ .Speedup }}
+
+# replay the BCH blocks recorded in this JSON/CBOR file (or the .json and .cbor files in this directory)
+# instead of connecting to a BCH node, leave it empty to use "mainnet-rpc-url"
+mainnet-blocks-file = "{{ .MainnetBlocksFile }}"
+
+# the network profile of watcher params: mainnet, amber, testnet or devnet. Only devnet and the network
+# this binary is built for (default) can be used
+network = "{{ .Network }}"
+
+# overrides of the network profile, 0 means using the profile's value.
+# finality depth, blocks in epoch and epoch switch delay are consensus-critical and can
+# only be changed on devnet
+watcher-finality-depth = {{ .WatcherFinalityDepth }}
+watcher-blocks-in-epoch = {{ .WatcherBlocksInEpoch }}
+watcher-epoch-switch-delay = {{ .WatcherEpochSwitchDelay }}
+watcher-blocks-to-clear-memory = {{ .WatcherBlocksToClearMemory }}
+watcher-waiting-block-delay-time = {{ .WatcherWaitingBlockDelayTime }}
+watcher-parallel-fetch-num = {{ .WatcherParallelFetchNum }}
+
+# listen on this address (tcp://host:port or unix://path) for a remote signer, such as tmkms or
+# "smartbchd signer", which keeps the validator's consensus key. Leave it empty to use the local
+# priv_validator_key.json
+remote-signer-laddr = "{{ .RemoteSignerListenAddr }}"
+
+# check that stakingAcc holds all the staked coins, rewards and unbonding coins, and that the slashed
+# coins are booked on BlackHole acc, at each Commit. "off" (default), "alert" to log the discrepancy
+# or "halt" to stop the node
+invariant-check = "{{ .InvariantCheck }}"
 `
 
 var configTemplate *template.Template
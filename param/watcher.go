@@ -0,0 +1,157 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	NetworkMainnet = "mainnet"
+	NetworkAmber   = "amber"
+	NetworkTestnet = "testnet"
+	// NetworkDevnet is a local network whose watcher params are all tunable, such that it can
+	// run with very short epochs. Any other network must be the one this binary is built for.
+	NetworkDevnet = "devnet"
+
+	MaxParallelFetchNum = 100
+)
+
+// The networks which have watcher profiles
+var SupportedNetworks = []string{NetworkMainnet, NetworkAmber, NetworkTestnet, NetworkDevnet}
+
+// WatcherParams controls how the watcher follows BCH mainnet. FinalityDepth, NumBlocksInEpoch and
+// EpochSwitchDelay are consensus-critical: all the nodes of a network must use the same values,
+// so they can only be changed on devnets.
+type WatcherParams struct {
+	FinalityDepth          int64 // a BCH block is finalized when it gets so many confirmations
+	NumBlocksInEpoch       int64
+	EpochSwitchDelay       int64 // seconds to wait after an epoch's EndTime before switching to it
+	NumBlocksToClearMemory int
+	WaitingBlockDelayTime  int // seconds to wait before checking BCH mainnet's new blocks
+	ParallelFetchNum       int
+}
+
+// The profile of the network this binary is built for
+func DefaultWatcherParams() WatcherParams {
+	params, err := GetNetworkWatcherParams(NetworkName)
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// GetNetworkWatcherParams returns the profile of a supported network. The consensus-critical params of
+// the network this binary is built for are StakingNumBlocksInEpoch and StakingEpochSwitchDelay in the
+// params file of its build tag. The profiles of the other public networks are for reference only,
+// because their nodes need binaries built for them.
+func GetNetworkWatcherParams(network string) (WatcherParams, error) {
+	if network == NetworkName {
+		return publicWatcherParams(StakingNumBlocksInEpoch, StakingEpochSwitchDelay), nil
+	}
+	switch network {
+	case NetworkMainnet:
+		return publicWatcherParams(2016, 600*2016/20), nil
+	case NetworkAmber:
+		return publicWatcherParams(2016, 9*2016/20), nil
+	case NetworkTestnet:
+		return publicWatcherParams(30, 3*10+10), nil
+	case NetworkDevnet:
+		return DevnetWatcherParams(), nil
+	}
+	return WatcherParams{}, fmt.Errorf("unknown network %q, the supported networks are: %s",
+		network, strings.Join(SupportedNetworks, ", "))
+}
+
+func publicWatcherParams(numBlocksInEpoch, epochSwitchDelay int64) WatcherParams {
+	return WatcherParams{
+		FinalityDepth:          10,
+		NumBlocksInEpoch:       numBlocksInEpoch,
+		EpochSwitchDelay:       epochSwitchDelay,
+		NumBlocksToClearMemory: 1000,
+		WaitingBlockDelayTime:  2,
+		ParallelFetchNum:       10,
+	}
+}
+
+func DevnetWatcherParams() WatcherParams {
+	return WatcherParams{
+		FinalityDepth:          1,
+		NumBlocksInEpoch:       10,
+		EpochSwitchDelay:       60,
+		NumBlocksToClearMemory: 100,
+		WaitingBlockDelayTime:  1,
+		ParallelFetchNum:       10,
+	}
+}
+
+// GetWatcherParams loads the profile of the configured network, which is NetworkName if not set, and
+// overrides it with the non-zero watcher params in config. The result is validated.
+func (config *AppConfig) GetWatcherParams() (*WatcherParams, error) {
+	network := config.Network
+	if network == "" {
+		network = NetworkName
+	}
+	params, err := GetNetworkWatcherParams(network)
+	if err != nil {
+		return nil, err
+	}
+	if network != NetworkName && network != NetworkDevnet {
+		// the fork heights and other params of a public network are decided by the build tags
+		return nil, fmt.Errorf("network %s needs a binary built for it, this binary is built for %s", network, NetworkName)
+	}
+	locked := params
+
+	if config.WatcherFinalityDepth != 0 {
+		params.FinalityDepth = config.WatcherFinalityDepth
+	}
+	if config.WatcherBlocksInEpoch != 0 {
+		params.NumBlocksInEpoch = config.WatcherBlocksInEpoch
+	}
+	if config.WatcherEpochSwitchDelay != 0 {
+		params.EpochSwitchDelay = config.WatcherEpochSwitchDelay
+	}
+	if config.WatcherBlocksToClearMemory != 0 {
+		params.NumBlocksToClearMemory = config.WatcherBlocksToClearMemory
+	}
+	if config.WatcherWaitingBlockDelayTime != 0 {
+		params.WaitingBlockDelayTime = config.WatcherWaitingBlockDelayTime
+	}
+	if config.WatcherParallelFetchNum != 0 {
+		params.ParallelFetchNum = config.WatcherParallelFetchNum
+	}
+
+	if network != NetworkDevnet {
+		if params.FinalityDepth != locked.FinalityDepth ||
+			params.NumBlocksInEpoch != locked.NumBlocksInEpoch ||
+			params.EpochSwitchDelay != locked.EpochSwitchDelay {
+			return nil, errors.New("consensus-critical watcher params can only be changed on devnet")
+		}
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+func (params *WatcherParams) Validate() error {
+	if params.FinalityDepth < 1 {
+		return errors.New("watcher finality depth must be positive")
+	}
+	if params.NumBlocksInEpoch < 1 {
+		return errors.New("watcher blocks in epoch must be positive")
+	}
+	if params.EpochSwitchDelay < 0 {
+		return errors.New("watcher epoch switch delay must not be negative")
+	}
+	if params.NumBlocksToClearMemory < 1 {
+		return errors.New("watcher blocks to clear memory must be positive")
+	}
+	if params.WaitingBlockDelayTime < 1 {
+		return errors.New("watcher waiting block delay time must be positive")
+	}
+	if params.ParallelFetchNum < 1 || params.ParallelFetchNum > MaxParallelFetchNum {
+		return fmt.Errorf("watcher parallel fetch num must be in [1, %d]", MaxParallelFetchNum)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 
 	motypes "github.com/smartbch/moeingevm/types"
 	sbchapi "github.com/smartbch/smartbch/api"
+	"github.com/smartbch/smartbch/app"
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
 	rpctypes "github.com/smartbch/smartbch/rpc/internal/ethapi"
 	"github.com/smartbch/smartbch/staking"
@@ -35,12 +38,20 @@ type SbchAPI interface {
 	GetEpochs(start, end hexutil.Uint64) ([]*types.Epoch, error)
 	GetEpochList(from string) ([]*StakingEpoch, error)
 	GetCurrEpoch(includesPosVotes *bool) (*StakingEpoch, error)
+	GetPosVotes(height gethrpc.BlockNumber) (*PosVotes, error)
+	PreviewNextValidatorSet() *NextValidatorSet
+	GetValidatorSetAtEpoch(epochNum hexutil.Uint64) ([]*app.Validator, error)
+	GetRewardsAtEpoch(epochNum hexutil.Uint64) ([]*EpochReward, error)
 	GetCCEpochs(start, end hexutil.Uint64) ([]*cctypes.CCEpoch, error)
 	GetCCEpochs2(start, end hexutil.Uint64) ([]*CCEpoch, error) // result is more human-readable
 	HealthCheck(latestBlockTooOldAge hexutil.Uint64) map[string]interface{}
+	WatcherStatus() *WatcherStatus
 	GetTransactionReceipt(hash gethcmn.Hash) (map[string]interface{}, error)
 	Call(args rpctypes.CallArgs, blockNr gethrpc.BlockNumberOrHash) (*CallDetail, error)
 	ValidatorsInfo() json.RawMessage
+	GetValidatorUnbondings() []*ValidatorUnbonding
+	GetValidatorUptime(addr gethcmn.Address, fromHeight, toHeight hexutil.Uint64) (*ValidatorUptime, error)
+	GetSlashEvents(fromHeight, toHeight hexutil.Uint64) ([]*SlashEvent, error)
 	GetSyncBlock(height hexutil.Uint64) (hexutil.Bytes, error)
 }
 
@@ -228,6 +239,25 @@ func (sbch sbchAPI) GetCurrEpoch(includesPosVotes *bool) (*StakingEpoch, error)
 	return ret, nil
 }
 
+// the PoS votes in xHedge at height, and the voting powers they would project if the next epoch switched then.
+// The projection always uses the PoW nominations of the next epoch to switch at the latest height.
+func (sbch sbchAPI) GetPosVotes(height gethrpc.BlockNumber) (*PosVotes, error) {
+	sbch.logger.Debug("sbch_getPosVotes")
+	if height == gethrpc.LatestBlockNumber || height == gethrpc.PendingBlockNumber {
+		height = gethrpc.BlockNumber(sbch.backend.LatestHeight())
+	} else if !sbch.backend.IsArchiveMode() && int64(height) != sbch.backend.LatestHeight() {
+		return nil, errors.New("only the latest height is supported in non-archive mode")
+	}
+	posVotes, pubkey2power, validators := sbch.backend.GetPosVotesAtHeight(int64(height))
+	return castPosVotes(int64(height), posVotes, pubkey2power, validators), nil
+}
+
+// the validator set if the next epoch switched now, which is computed on a throwaway context
+func (sbch sbchAPI) PreviewNextValidatorSet() *NextValidatorSet {
+	sbch.logger.Debug("sbch_previewNextValidatorSet")
+	return castNextValidatorSet(sbch.backend.PreviewNextValidatorSet())
+}
+
 func coinDaysSlotToFloat(coindaysSlot *big.Int) float64 {
 	fCoinDays, _ := big.NewFloat(0).Quo(
 		big.NewFloat(0).SetInt(coindaysSlot),
@@ -236,6 +266,26 @@ func coinDaysSlotToFloat(coindaysSlot *big.Int) float64 {
 	return fCoinDays
 }
 
+// the validators when the epoch started, including the ones with zero voting power
+func (sbch sbchAPI) GetValidatorSetAtEpoch(epochNum hexutil.Uint64) ([]*app.Validator, error) {
+	sbch.logger.Debug("sbch_getValidatorSetAtEpoch")
+	snapshot, err := sbch.backend.GetEpochSnapshot(uint64(epochNum))
+	if err != nil {
+		return nil, err
+	}
+	return app.FromStakingValidators(snapshot.Validators), nil
+}
+
+// the mature rewards delivered when the epoch started
+func (sbch sbchAPI) GetRewardsAtEpoch(epochNum hexutil.Uint64) ([]*EpochReward, error) {
+	sbch.logger.Debug("sbch_getRewardsAtEpoch")
+	snapshot, err := sbch.backend.GetEpochSnapshot(uint64(epochNum))
+	if err != nil {
+		return nil, err
+	}
+	return castEpochRewards(snapshot.Rewards), nil
+}
+
 func (sbch sbchAPI) GetCCEpochs(start, end hexutil.Uint64) ([]*cctypes.CCEpoch, error) {
 	if end == 0 {
 		end = start + 10
@@ -275,14 +325,25 @@ func (sbch sbchAPI) HealthCheck(latestBlockTooOldAge hexutil.Uint64) map[string]
 		msg = err.Error()
 	}
 
+	watcherStatus := castWatcherStatus(sbch.backend.WatcherStatus())
+	if !ok && watcherStatus.BeginBlockThrottled {
+		msg += ", waiting for BCH mainnet to catch up"
+	}
+
 	return map[string]interface{}{
 		"latestBlockHeight":    latestBlockHeight,
 		"latestBlockTimestamp": latestBlockTimestamp,
 		"ok":                   ok,
 		"error":                msg,
+		"watcher":              watcherStatus,
 	}
 }
 
+func (sbch sbchAPI) WatcherStatus() *WatcherStatus {
+	sbch.logger.Debug("sbch_watcherStatus")
+	return castWatcherStatus(sbch.backend.WatcherStatus())
+}
+
 func (sbch sbchAPI) GetTransactionReceipt(hash gethcmn.Hash) (map[string]interface{}, error) {
 	sbch.logger.Debug("sbch_getTransactionReceipt")
 	tx, _, err := sbch.backend.GetTransaction(hash)
@@ -314,6 +375,31 @@ func (sbch sbchAPI) ValidatorsInfo() json.RawMessage {
 	return bytes
 }
 
+func (sbch sbchAPI) GetValidatorUnbondings() []*ValidatorUnbonding {
+	sbch.logger.Debug("sbch_getValidatorUnbondings")
+	return castValidatorUnbondings(sbch.backend.ValidatorUnbondings())
+}
+
+// Only the latest param.UptimeHistoryWindowCount online windows are kept for each validator, so the blocks
+// before them are not counted.
+func (sbch sbchAPI) GetValidatorUptime(addr gethcmn.Address, fromHeight, toHeight hexutil.Uint64) (*ValidatorUptime, error) {
+	sbch.logger.Debug("sbch_getValidatorUptime")
+	if toHeight < fromHeight || toHeight > math.MaxInt64 {
+		return nil, errors.New("invalid height range")
+	}
+	records, duplicateSigs := sbch.backend.ValidatorUptime(addr)
+	return castValidatorUptime(addr, int64(fromHeight), int64(toHeight), records, duplicateSigs), nil
+}
+
+// Only the latest param.SlashHistoryMaxCount slashes are kept
+func (sbch sbchAPI) GetSlashEvents(fromHeight, toHeight hexutil.Uint64) ([]*SlashEvent, error) {
+	sbch.logger.Debug("sbch_getSlashEvents")
+	if toHeight < fromHeight || toHeight > math.MaxInt64 {
+		return nil, errors.New("invalid height range")
+	}
+	return castSlashEvents(sbch.backend.SlashRecords(int64(fromHeight), int64(toHeight))), nil
+}
+
 func (sbch sbchAPI) GetSyncBlock(height hexutil.Uint64) (hexutil.Bytes, error) {
 	sbch.logger.Debug("sbch_getSyncBlock")
 	return sbch.backend.GetSyncBlock(int64(height))
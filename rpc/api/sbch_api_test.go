@@ -508,6 +508,19 @@ func TestGetSyncBlock(t *testing.T) {
 	require.Len(t, syncBlock2.Txid2sigMap, 1)
 }
 
+func TestWatcherStatus(t *testing.T) {
+	_app := testutils.CreateTestApp()
+	defer _app.Destroy()
+	_api := createSbchAPI(_app)
+
+	status := _api.WatcherStatus()
+	require.False(t, status.BeginBlockThrottled)
+	require.Equal(t, hexutil.Uint64(0), status.PendingEpochs)
+
+	health := _api.HealthCheck(0)
+	require.NotNil(t, health["watcher"])
+}
+
 func createSbchAPI(_app *testutils.TestApp) SbchAPI {
 	backend := api.NewBackend(nil, _app.App)
 	return newSbchAPI(backend, _app.Logger())
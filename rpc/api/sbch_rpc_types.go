@@ -1,6 +1,11 @@
 package api
 
 import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
 	gethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/holiman/uint256"
@@ -8,8 +13,11 @@ import (
 
 	motypes "github.com/smartbch/moeingevm/types"
 	sbchapi "github.com/smartbch/smartbch/api"
+	"github.com/smartbch/smartbch/app"
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
+	"github.com/smartbch/smartbch/staking"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/watcher"
 )
 
 // StakingEpoch
@@ -95,6 +103,139 @@ func castTransferInfos(ccTransferInfos []*cctypes.CCTransferInfo) []*CCTransferI
 	return rpcTransferInfos
 }
 
+// WatcherStatus
+
+type WatcherStatus struct {
+	LatestFinalizedHeight        hexutil.Uint64 `json:"latestFinalizedHeight"`
+	LatestMainnetHeight          hexutil.Uint64 `json:"latestMainnetHeight"`
+	CurrentMainnetBlockTimestamp int64          `json:"currentMainnetBlockTimestamp"`
+	PendingEpochs                hexutil.Uint64 `json:"pendingEpochs"`
+	LastRpcError                 string         `json:"lastRpcError"`
+	LastRpcErrorTime             int64          `json:"lastRpcErrorTime"`
+	BeginBlockThrottled          bool           `json:"beginBlockThrottled"`
+}
+
+func castWatcherStatus(status watcher.Status) *WatcherStatus {
+	return &WatcherStatus{
+		LatestFinalizedHeight:        hexutil.Uint64(status.LatestFinalizedHeight),
+		LatestMainnetHeight:          hexutil.Uint64(status.LatestMainnetHeight),
+		CurrentMainnetBlockTimestamp: status.CurrentMainnetBlockTimestamp,
+		PendingEpochs:                hexutil.Uint64(status.PendingEpochs),
+		LastRpcError:                 status.LastRpcError,
+		LastRpcErrorTime:             status.LastRpcErrorTime,
+		BeginBlockThrottled:          status.BeginBlockThrottled,
+	}
+}
+
+// EpochReward
+
+type EpochReward struct {
+	Address  gethcmn.Address `json:"address"`
+	RewardTo gethcmn.Address `json:"rewardTo"`
+	EpochNum hexutil.Uint64  `json:"epochNum"`
+	Amount   *hexutil.Big    `json:"amount"`
+}
+
+func castEpochRewards(rewards []*stakingtypes.EpochReward) []*EpochReward {
+	rpcRewards := make([]*EpochReward, len(rewards))
+	for i, r := range rewards {
+		rpcRewards[i] = &EpochReward{
+			Address:  r.Address,
+			RewardTo: r.RewardTo,
+			EpochNum: hexutil.Uint64(r.EpochNum),
+			Amount:   (*hexutil.Big)(uint256.NewInt(0).SetBytes32(r.Amount[:]).ToBig()),
+		}
+	}
+	return rpcRewards
+}
+
+// ValidatorUnbonding
+
+type ValidatorUnbonding struct {
+	Address        gethcmn.Address `json:"address"`
+	Pubkey         gethcmn.Hash    `json:"pubkey"`
+	RewardTo       gethcmn.Address `json:"rewardTo"`
+	Amount         *hexutil.Big    `json:"amount"`
+	StartEpochNum  hexutil.Uint64  `json:"startEpochNum"`
+	MatureEpochNum hexutil.Uint64  `json:"matureEpochNum"`
+}
+
+func castValidatorUnbondings(unbondings []*stakingtypes.ValidatorUnbonding) []*ValidatorUnbonding {
+	rpcUnbondings := make([]*ValidatorUnbonding, len(unbondings))
+	for i, u := range unbondings {
+		rpcUnbondings[i] = &ValidatorUnbonding{
+			Address:        u.Address,
+			Pubkey:         u.Pubkey,
+			RewardTo:       u.RewardTo,
+			Amount:         (*hexutil.Big)(uint256.NewInt(0).SetBytes32(u.Amount[:]).ToBig()),
+			StartEpochNum:  hexutil.Uint64(u.StartEpochNum),
+			MatureEpochNum: hexutil.Uint64(u.MatureEpochNum),
+		}
+	}
+	return rpcUnbondings
+}
+
+// ValidatorUptime
+
+type ValidatorUptime struct {
+	Address        gethcmn.Address  `json:"address"`
+	FromHeight     hexutil.Uint64   `json:"fromHeight"`
+	ToHeight       hexutil.Uint64   `json:"toHeight"`
+	ActiveBlocks   hexutil.Uint64   `json:"activeBlocks"`
+	SignedBlocks   hexutil.Uint64   `json:"signedBlocks"`
+	MissedBlocks   hexutil.Uint64   `json:"missedBlocks"`
+	ProposedBlocks hexutil.Uint64   `json:"proposedBlocks"`
+	MissedHeights  []hexutil.Uint64 `json:"missedHeights"`
+	DuplicateSigs  []*DuplicateSig  `json:"duplicateSigs"`
+}
+
+type DuplicateSig struct {
+	Height  hexutil.Uint64 `json:"height"`
+	Pubkey  gethcmn.Hash   `json:"pubkey"`
+	Slashed *hexutil.Big   `json:"slashed"`
+}
+
+// count the blocks in [fromHeight, toHeight] covered by the records
+func castValidatorUptime(addr gethcmn.Address, fromHeight, toHeight int64,
+	records []*stakingtypes.UptimeRecord, duplicateSigs []*stakingtypes.DuplicateSigRecord) *ValidatorUptime {
+	uptime := &ValidatorUptime{
+		Address:       addr,
+		FromHeight:    hexutil.Uint64(fromHeight),
+		ToHeight:      hexutil.Uint64(toHeight),
+		MissedHeights: []hexutil.Uint64{},
+		DuplicateSigs: []*DuplicateSig{},
+	}
+	for _, r := range records {
+		for i := int64(0); i < int64(len(r.SignedBitmap))*8; i++ {
+			h := r.StartHeight + i
+			if h < fromHeight || h > toHeight {
+				continue
+			}
+			if staking.IsBitSet(r.SignedBitmap, i) {
+				uptime.ActiveBlocks++
+				uptime.SignedBlocks++
+			} else if staking.IsBitSet(r.MissedBitmap, i) {
+				uptime.ActiveBlocks++
+				uptime.MissedBlocks++
+				uptime.MissedHeights = append(uptime.MissedHeights, hexutil.Uint64(h))
+			}
+			if staking.IsBitSet(r.ProposedBitmap, i) {
+				uptime.ProposedBlocks++
+			}
+		}
+	}
+	for _, d := range duplicateSigs {
+		if d.Height >= fromHeight && d.Height <= toHeight {
+			uptime.DuplicateSigs = append(uptime.DuplicateSigs, &DuplicateSig{
+				Height:  hexutil.Uint64(d.Height),
+				Pubkey:  d.Pubkey,
+				Slashed: (*hexutil.Big)(uint256.NewInt(0).SetBytes32(d.Slashed[:]).ToBig()),
+			})
+		}
+	}
+	return uptime
+}
+
 // CallDetail
 
 type CallDetail struct {
@@ -269,3 +410,125 @@ func castMoLogs(moLogs []motypes.Log) []*CallLog {
 	}
 	return callLogs
 }
+
+// PosVotes
+
+type PosVotes struct {
+	Height              hexutil.Uint64     `json:"height"`
+	Votes               []*PosVoteDetail   `json:"votes"`
+	ProjectedValidators []*ProjectedVoting `json:"projectedValidators"`
+	Warnings            []string           `json:"warnings"`
+}
+
+type PosVoteDetail struct {
+	Pubkey         gethcmn.Hash     `json:"pubkey"`
+	Validator      *gethcmn.Address `json:"validator"` // null if no validator has this pubkey
+	CoinDaysSlot   *hexutil.Big     `json:"coinDaysSlot"`
+	CoinDays       float64          `json:"coinDays"`
+	ProjectedPower int64            `json:"projectedPower"`
+}
+
+type ProjectedVoting struct {
+	Pubkey      gethcmn.Hash    `json:"pubkey"`
+	Validator   gethcmn.Address `json:"validator"`
+	VotingPower int64           `json:"votingPower"`
+}
+
+func castPosVotes(height int64, posVotes map[[32]byte]*big.Int, pubkey2power map[[32]byte]int64,
+	validators []*stakingtypes.Validator) *PosVotes {
+	valMapByPubkey := make(map[[32]byte]*stakingtypes.Validator, len(validators))
+	for _, val := range validators {
+		valMapByPubkey[val.Pubkey] = val
+	}
+	ret := &PosVotes{
+		Height:              hexutil.Uint64(height),
+		Votes:               make([]*PosVoteDetail, 0, len(posVotes)),
+		ProjectedValidators: make([]*ProjectedVoting, 0, len(pubkey2power)),
+		Warnings:            []string{},
+	}
+	for pubkey, coinDays := range posVotes {
+		vote := &PosVoteDetail{
+			Pubkey:         pubkey,
+			CoinDaysSlot:   (*hexutil.Big)(coinDays),
+			CoinDays:       coinDaysSlotToFloat(coinDays),
+			ProjectedPower: pubkey2power[pubkey],
+		}
+		if val, ok := valMapByPubkey[pubkey]; ok {
+			addr := gethcmn.Address(val.Address)
+			vote.Validator = &addr
+			if val.IsRetiring {
+				ret.Warnings = append(ret.Warnings, fmt.Sprintf("votes to %s are ignored because validator %s is retiring",
+					gethcmn.Hash(pubkey).Hex(), addr.Hex()))
+			}
+		} else {
+			ret.Warnings = append(ret.Warnings, fmt.Sprintf("votes to %s are ignored because no validator has this pubkey",
+				gethcmn.Hash(pubkey).Hex()))
+		}
+		ret.Votes = append(ret.Votes, vote)
+	}
+	for pubkey, power := range pubkey2power {
+		ret.ProjectedValidators = append(ret.ProjectedValidators, &ProjectedVoting{
+			Pubkey:      pubkey,
+			Validator:   valMapByPubkey[pubkey].Address,
+			VotingPower: power,
+		})
+	}
+	sort.Slice(ret.Votes, func(i, j int) bool {
+		return bytes.Compare(ret.Votes[i].Pubkey[:], ret.Votes[j].Pubkey[:]) < 0
+	})
+	sort.Slice(ret.ProjectedValidators, func(i, j int) bool {
+		return bytes.Compare(ret.ProjectedValidators[i].Pubkey[:], ret.ProjectedValidators[j].Pubkey[:]) < 0
+	})
+	sort.Strings(ret.Warnings)
+	return ret
+}
+
+// NextValidatorSet
+
+type NextValidatorSet struct {
+	Epoch          *StakingEpoch    `json:"epoch"`
+	IsValid        bool             `json:"isValid"`
+	CurrValidators []*app.Validator `json:"currValidators"`
+	NewValidators  []*app.Validator `json:"newValidators"`
+	Updates        []*app.Validator `json:"updates"`
+}
+
+func castNextValidatorSet(set *app.NextValidatorSet) *NextValidatorSet {
+	return &NextValidatorSet{
+		Epoch:          castStakingEpoch(set.Epoch),
+		IsValid:        set.IsValid,
+		CurrValidators: app.FromStakingValidators(set.CurrValidators),
+		NewValidators:  app.FromStakingValidators(set.NewValidators),
+		Updates:        app.FromStakingValidators(set.Updates),
+	}
+}
+
+// SlashEvent
+
+type SlashEvent struct {
+	Height       hexutil.Uint64  `json:"height"`
+	Validator    gethcmn.Address `json:"validator"`
+	Pubkey       gethcmn.Hash    `json:"pubkey"`
+	EvidenceType string          `json:"evidenceType"`
+	Burnt        *hexutil.Big    `json:"burnt"`
+}
+
+var slashReasonNames = map[uint8]string{
+	staking.SlashReasonDuplicateSig:      "DUPLICATE_VOTE",
+	staking.SlashReasonNotOnline:         "DOWNTIME",
+	staking.SlashReasonLightClientAttack: "LIGHT_CLIENT_ATTACK",
+}
+
+func castSlashEvents(records []*stakingtypes.SlashRecord) []*SlashEvent {
+	rpcEvents := make([]*SlashEvent, len(records))
+	for i, r := range records {
+		rpcEvents[i] = &SlashEvent{
+			Height:       hexutil.Uint64(r.Height),
+			Validator:    r.Validator,
+			Pubkey:       r.Pubkey,
+			EvidenceType: slashReasonNames[r.Reason],
+			Burnt:        (*hexutil.Big)(uint256.NewInt(0).SetBytes32(r.Burnt[:]).ToBig()),
+		}
+	}
+	return rpcEvents
+}
@@ -96,6 +96,223 @@ var ABI = ethutils.MustParseABI(`
       "stateMutability": "nonpayable",
       "type": "function"
     },
+	{
+		"inputs": [
+			{
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			}
+		],
+		"name": "delegate",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "undelegate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "withdrawUnbonded",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "rate",
+				"type": "uint256"
+			}
+		],
+		"name": "setCommissionRate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"internalType": "bytes32",
+				"name": "introduction",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "uint8",
+				"name": "rewardMode",
+				"type": "uint8"
+			}
+		],
+		"name": "editValidator",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "withdrawRewards",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "getRewardAccount",
+		"outputs": [
+			{
+				"internalType": "uint8",
+				"name": "rewardMode",
+				"type": "uint8"
+			},
+			{
+				"internalType": "uint256",
+				"name": "accumulated",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "unjail",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "activationHeight",
+				"type": "uint256"
+			}
+		],
+		"name": "proposeParamChange",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "bool",
+				"name": "approve",
+				"type": "bool"
+			}
+		],
+		"name": "voteParamChange",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "executeParamChange",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "bytes32",
+				"name": "newPubkey",
+				"type": "bytes32"
+			}
+		],
+		"name": "rotateConsensusKey",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "increaseStake",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "decreaseStake",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			}
+		],
+		"name": "getParam",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "newValue",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "activationHeight",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
 	{
 		"inputs": [],
 		"name": "executeProposal",
@@ -111,42 +328,690 @@ var ABI = ethutils.MustParseABI(`
 				"type": "address"
 			}
 		],
-		"name": "getVote",
-		"outputs": [
+		"name": "getVote",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "getValidator",
+		"outputs": [
+			{
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "bytes32",
+				"name": "introduction",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "uint256",
+				"name": "stakedCoins",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "votingPower",
+				"type": "uint256"
+			},
+			{
+				"internalType": "bool",
+				"name": "isRetiring",
+				"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getActiveValidators",
+		"outputs": [
+			{
+				"internalType": "address[]",
+				"name": "validators",
+				"type": "address[]"
+			},
+			{
+				"internalType": "bytes32[]",
+				"name": "pubkeys",
+				"type": "bytes32[]"
+			},
+			{
+				"internalType": "uint256[]",
+				"name": "votingPowers",
+				"type": "uint256[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "getPendingRewards",
+		"outputs": [
+			{
+				"internalType": "uint256[]",
+				"name": "epochNums",
+				"type": "uint256[]"
+			},
+			{
+				"internalType": "uint256[]",
+				"name": "amounts",
+				"type": "uint256[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getCurrEpochNum",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getMinGasPrice",
+		"outputs": [
+			{
+				"internalType": "uint256",
+				"name": "",
+				"type": "uint256"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "target",
+				"type": "uint256"
+			}
+		],
+		"name": "proposal",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "target",
+				"type": "uint256"
+			}
+		],
+		"name": "vote",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "introduction",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "stakedCoins",
+				"type": "uint256"
+			}
+		],
+		"name": "ValidatorCreated",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "introduction",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "addedCoins",
+				"type": "uint256"
+			}
+		],
+		"name": "ValidatorEdited",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "Retired",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint8",
+				"name": "reason",
+				"type": "uint8"
+			}
+		],
+		"name": "Slashed",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "epochNum",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "RewardDistributed",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "epochNum",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "startHeight",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "endTime",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "bool",
+				"name": "isValid",
+				"type": "bool"
+			}
+		],
+		"name": "EpochSwitched",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "oldMinGasPrice",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "newMinGasPrice",
+				"type": "uint256"
+			}
+		],
+		"name": "MinGasPriceChanged",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "target",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "deadline",
+				"type": "uint256"
+			}
+		],
+		"name": "ProposalCreated",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
 			{
+				"indexed": false,
 				"internalType": "uint256",
-				"name": "",
+				"name": "target",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "votingPower",
 				"type": "uint256"
 			}
 		],
-		"stateMutability": "view",
-		"type": "function"
+		"name": "Voted",
+		"type": "event"
 	},
 	{
+		"anonymous": false,
 		"inputs": [
 			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "executor",
+				"type": "address"
+			},
+			{
+				"indexed": false,
 				"internalType": "uint256",
-				"name": "target",
+				"name": "minGasPrice",
 				"type": "uint256"
 			}
 		],
-		"name": "proposal",
-		"outputs": [],
-		"stateMutability": "nonpayable",
-		"type": "function"
+		"name": "ProposalExecuted",
+		"type": "event"
 	},
 	{
+		"anonymous": false,
 		"inputs": [
 			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
 				"internalType": "uint256",
-				"name": "target",
+				"name": "amount",
 				"type": "uint256"
 			}
 		],
-		"name": "vote",
-		"outputs": [],
-		"stateMutability": "nonpayable",
-		"type": "function"
+		"name": "ValidatorUnbondingMatured",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "pubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "releaseEpochNum",
+				"type": "uint256"
+			}
+		],
+		"name": "Jailed",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			}
+		],
+		"name": "Unjailed",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "rewardTo",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "RewardsWithdrawn",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "proposer",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "activationHeight",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "deadline",
+				"type": "uint256"
+			}
+		],
+		"name": "ParamChangeProposed",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "voter",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bool",
+				"name": "approve",
+				"type": "bool"
+			}
+		],
+		"name": "ParamChangeVoted",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "paramId",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "activationHeight",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "bool",
+				"name": "passed",
+				"type": "bool"
+			}
+		],
+		"name": "ParamChangeExecuted",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "oldPubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "newPubkey",
+				"type": "bytes32"
+			}
+		],
+		"name": "ConsensusKeyRotationRequested",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "oldPubkey",
+				"type": "bytes32"
+			},
+			{
+				"indexed": false,
+				"internalType": "bytes32",
+				"name": "newPubkey",
+				"type": "bytes32"
+			}
+		],
+		"name": "ConsensusKeyRotated",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			}
+		],
+		"name": "StakeIncreased",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "amount",
+				"type": "uint256"
+			},
+			{
+				"indexed": false,
+				"internalType": "uint256",
+				"name": "matureEpochNum",
+				"type": "uint256"
+			}
+		],
+		"name": "StakeDecreased",
+		"type": "event"
 	}
 ]
 `)
@@ -157,6 +1022,9 @@ func PackCreateValidator(rewardTo gethcmn.Address, intro [32]byte, pubKey [32]by
 func PackEditValidator(rewardTo gethcmn.Address, intro [32]byte) []byte {
 	return ABI.MustPack("editValidator", rewardTo, intro)
 }
+func PackEditValidatorWithRewardMode(rewardTo gethcmn.Address, intro [32]byte, rewardMode uint8) []byte {
+	return ABI.MustPack("editValidator0", rewardTo, intro, rewardMode) // the overloaded editValidator
+}
 func PackRetire() []byte {
 	return ABI.MustPack("retire")
 }
@@ -178,6 +1046,64 @@ func PackExecuteProposal() []byte {
 func PackGetVote(validator gethcmn.Address) []byte {
 	return ABI.MustPack("getVote", validator)
 }
+func PackDelegate(pubkey [32]byte) []byte {
+	return ABI.MustPack("delegate", pubkey)
+}
+func PackUndelegate(pubkey [32]byte, amount *big.Int) []byte {
+	return ABI.MustPack("undelegate", pubkey, amount)
+}
+func PackWithdrawUnbonded() []byte {
+	return ABI.MustPack("withdrawUnbonded")
+}
+func PackSetCommissionRate(rate *big.Int) []byte {
+	return ABI.MustPack("setCommissionRate", rate)
+}
+func PackWithdrawRewards() []byte {
+	return ABI.MustPack("withdrawRewards")
+}
+func PackUnjail() []byte {
+	return ABI.MustPack("unjail")
+}
+func PackProposeParamChange(paramId, value, activationHeight *big.Int) []byte {
+	return ABI.MustPack("proposeParamChange", paramId, value, activationHeight)
+}
+func PackVoteParamChange(approve bool) []byte {
+	return ABI.MustPack("voteParamChange", approve)
+}
+func PackExecuteParamChange() []byte {
+	return ABI.MustPack("executeParamChange")
+}
+func PackRotateConsensusKey(newPubkey [32]byte) []byte {
+	return ABI.MustPack("rotateConsensusKey", newPubkey)
+}
+func PackIncreaseStake() []byte {
+	return ABI.MustPack("increaseStake")
+}
+func PackDecreaseStake(amount *big.Int) []byte {
+	return ABI.MustPack("decreaseStake", amount)
+}
+
+func PackGetValidator(validator gethcmn.Address) []byte {
+	return ABI.MustPack("getValidator", validator)
+}
+func PackGetActiveValidators() []byte {
+	return ABI.MustPack("getActiveValidators")
+}
+func PackGetPendingRewards(validator gethcmn.Address) []byte {
+	return ABI.MustPack("getPendingRewards", validator)
+}
+func PackGetCurrEpochNum() []byte {
+	return ABI.MustPack("getCurrEpochNum")
+}
+func PackGetMinGasPrice() []byte {
+	return ABI.MustPack("getMinGasPrice")
+}
+func PackGetRewardAccount(validator gethcmn.Address) []byte {
+	return ABI.MustPack("getRewardAccount", validator)
+}
+func PackGetParam(paramId *big.Int) []byte {
+	return ABI.MustPack("getParam", paramId)
+}
 
 func PackSumVotingPower(addrList []gethcmn.Address) []byte {
 	return ABI.MustPack("sumVotingPower", addrList)
@@ -186,3 +1112,35 @@ func UnpackSumVotingPowerReturnData(data []byte) (*big.Int, *big.Int) {
 	ret := ABI.MustUnpack("sumVotingPower", data)
 	return ret[0].(*big.Int), ret[1].(*big.Int)
 }
+
+func UnpackGetValidatorReturnData(data []byte) (rewardTo gethcmn.Address, pubkey, intro [32]byte,
+	stakedCoins, votingPower *big.Int, isRetiring bool) {
+	ret := ABI.MustUnpack("getValidator", data)
+	return ret[0].(gethcmn.Address), ret[1].([32]byte), ret[2].([32]byte),
+		ret[3].(*big.Int), ret[4].(*big.Int), ret[5].(bool)
+}
+func UnpackGetActiveValidatorsReturnData(data []byte) ([]gethcmn.Address, [][32]byte, []*big.Int) {
+	ret := ABI.MustUnpack("getActiveValidators", data)
+	return ret[0].([]gethcmn.Address), ret[1].([][32]byte), ret[2].([]*big.Int)
+}
+func UnpackGetRewardAccountReturnData(data []byte) (rewardMode uint8, accumulated *big.Int) {
+	ret := ABI.MustUnpack("getRewardAccount", data)
+	return ret[0].(uint8), ret[1].(*big.Int)
+}
+func UnpackGetParamReturnData(data []byte) (value, newValue, activationHeight *big.Int) {
+	ret := ABI.MustUnpack("getParam", data)
+	return ret[0].(*big.Int), ret[1].(*big.Int), ret[2].(*big.Int)
+}
+func UnpackGetPendingRewardsReturnData(data []byte) (epochNums, amounts []*big.Int) {
+	ret := ABI.MustUnpack("getPendingRewards", data)
+	return ret[0].([]*big.Int), ret[1].([]*big.Int)
+}
+
+// encode the return values of a view method
+func packReturnData(name string, args ...interface{}) []byte {
+	bz, err := ABI.GetABI().Methods[name].Outputs.Pack(args...)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -0,0 +1,695 @@
+package staking
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------selector------*/
+	/*interface Staking {
+		// following methods are enabled after DelegationForkHeight
+		//c3254c23
+		function delegate(bytes32 pubkey) external payable;
+		//5e10b83c
+		function undelegate(bytes32 pubkey, uint256 amount) external;
+		//6e373bef
+		function withdrawUnbonded() external;
+		//19fac8fd
+		function setCommissionRate(uint256 rate) external;
+	}*/
+	SelectorDelegate          = [4]byte{0xc3, 0x25, 0x4c, 0x23}
+	SelectorUndelegate        = [4]byte{0x5e, 0x10, 0xb8, 0x3c}
+	SelectorWithdrawUnbonded  = [4]byte{0x6e, 0x37, 0x3b, 0xef}
+	SelectorSetCommissionRate = [4]byte{0x19, 0xfa, 0xc8, 0xfd}
+
+	delegationPoolSlotHashPrefix = [4]byte{'p', 'o', 'o', 'l'}
+	delegationSlotHashPrefix     = [4]byte{'d', 'l', 'g', 't'}
+	unbondingSlotHashPrefix      = [4]byte{'u', 'n', 'b', 'd'}
+	unbondingPoolSlotHashPrefix  = [4]byte{'u', 'b', 'p', 'l'}
+
+	/*------param------*/
+	MinimumDelegationAmount        = uint256.NewInt(Uint64_1e18 / 100) // 0.01 BCH
+	DefaultCommissionRate   uint64 = 1000                              // 10%
+	MaxCommissionRate       uint64 = 10000                             // 100%
+	MaxCommissionRateChange uint64 = 500                               // 5% in one epoch
+
+	GasOfDelegationOp uint64 = 100_000
+
+	// used to change delegated coins into coindays: the average delegated coins during an epoch are
+	// regarded as locked for the whole epoch
+	MainnetBlockInterval int64 = 600
+
+	RewardPerSharePrecision = uint256.NewInt(Uint64_1e18)
+
+	/*------error info------*/
+	DelegationAmountTooSmall     = errors.New("delegation amount smaller than allowed lowest value")
+	DelegationNotEnough          = errors.New("delegated coins are not enough")
+	NoMatureUnbonding            = errors.New("no mature unbonding")
+	CommissionRateTooBig         = errors.New("commission rate bigger than allowed highest value")
+	CommissionRateChangeTooBig   = errors.New("commission rate change exceeds the allowable range")
+	CommissionRateChangedInEpoch = errors.New("commission rate has been changed in current epoch")
+	DelegatedCoinsAllSlashed     = errors.New("all the delegated coins of the validator have been slashed")
+	DelegationAmountOverflow     = errors.New("delegation amount causes an overflow")
+	StakingAccBalanceNotEnough   = errors.New("balance of staking account is not enough")
+)
+
+func IsDelegationFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.DelegationForkHeight
+}
+
+// delegate tx.Value to the validator with pubkey, and settle the delegator's rewards
+func delegate(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfDelegationOp
+	callData := tx.Data[4:]
+	if len(callData) < 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var pubkey [32]byte
+	copy(pubkey[:], callData[:32])
+	amount := uint256.NewInt(0).SetBytes32(tx.Value[:])
+	if amount.Lt(MinimumDelegationAmount) {
+		outData = []byte(DelegationAmountTooSmall.Error())
+		return
+	}
+
+	stakingAcc, info := LoadStakingAccAndInfo(ctx)
+	val := info.GetValidatorByPubkey(pubkey)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.IsRetiring {
+		outData = []byte(ValidatorInRetiring.Error())
+		return
+	}
+	pool := LoadDelegationPool(ctx, pubkey)
+	totalDelegated := uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:])
+	totalShares := uint256.NewInt(0).SetBytes32(pool.TotalShares[:])
+	if totalDelegated.IsZero() && !totalShares.IsZero() {
+		// the left shares are worth nothing, and cannot be mixed with new coins
+		outData = []byte(DelegatedCoinsAllSlashed.Error())
+		return
+	}
+	shares, ok := coinsToShares(&pool, amount, false)
+	newTotalShares, overflow := uint256.NewInt(0).AddOverflow(totalShares, shares)
+	if !ok || overflow {
+		outData = []byte(DelegationAmountOverflow.Error())
+		return
+	}
+	dlg := LoadDelegation(ctx, pubkey, tx.From)
+	// the rewards are paid from stakingAcc, which must hold them before the delegated coins are added
+	if stakingAcc.Balance().Lt(unsettledRewards(&pool, &dlg)) {
+		outData = []byte(StakingAccBalanceNotEnough.Error())
+		return
+	}
+	status, outData = transferStakedCoins(ctx, tx, stakingAcc)
+	if status != StatusSuccess {
+		return
+	}
+
+	if err := settleDelegationRewards(ctx, &pool, &dlg); err != nil {
+		status = StatusFailed
+		outData = []byte(err.Error())
+		return
+	}
+	updateCoinBlocks(ctx, &pool)
+	dlgShares := uint256.NewInt(0).SetBytes32(dlg.Shares[:])
+	if dlgShares.IsZero() {
+		pool.NumDelegators++
+	}
+	dlgShares.Add(dlgShares, shares) // no more than newTotalShares
+	dlg.Shares = dlgShares.Bytes32()
+	dlg.RewardDebt = accumulatedRewards(&pool, dlgShares).Bytes32()
+	pool.TotalDelegated = totalDelegated.Add(totalDelegated, amount).Bytes32() // bounded by the coins in stakingAcc
+	pool.TotalShares = newTotalShares.Bytes32()
+
+	SaveDelegation(ctx, dlg)
+	SaveDelegationPool(ctx, pool)
+	addToDelegatorTotal(ctx, SlotTotalDelegated, amount)
+	return
+}
+
+// undelegate some coins from the validator with pubkey, and settle the delegator's rewards. The undelegated
+// coins can be withdrawn after DelegationUnbondingEpochCount epochs. A zero amount just settles the rewards.
+func undelegate(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfDelegationOp
+	callData := tx.Data[4:]
+	if len(callData) < 64 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var pubkey [32]byte
+	copy(pubkey[:], callData[:32])
+	amount := uint256.NewInt(0).SetBytes(callData[32:64])
+
+	dlg := LoadDelegation(ctx, pubkey, tx.From)
+	pool := LoadDelegationPool(ctx, pubkey)
+	dlgShares := uint256.NewInt(0).SetBytes32(dlg.Shares[:])
+	totalDelegated := uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:])
+	totalShares := uint256.NewInt(0).SetBytes32(pool.TotalShares[:])
+	if dlgShares.IsZero() || (!amount.IsZero() && totalDelegated.IsZero()) {
+		outData = []byte(DelegationNotEnough.Error())
+		return
+	}
+	// round up, so the delegators left in the pool never pay for the rounding. The shares are no more than
+	// the delegator's ones, iff amount is no more than the coins they are worth.
+	shares, ok := coinsToShares(&pool, amount, true)
+	if !ok || dlgShares.Lt(shares) {
+		outData = []byte(DelegationNotEnough.Error())
+		return
+	}
+	newTotalDelegated, overflow1 := uint256.NewInt(0).SubOverflow(totalDelegated, amount)
+	newTotalShares, overflow2 := uint256.NewInt(0).SubOverflow(totalShares, shares)
+	if overflow1 || overflow2 {
+		outData = []byte(DelegationNotEnough.Error())
+		return
+	}
+
+	if err := settleDelegationRewards(ctx, &pool, &dlg); err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	if !amount.IsZero() {
+		updateCoinBlocks(ctx, &pool)
+		dlgShares.Sub(dlgShares, shares)
+		pool.TotalDelegated = newTotalDelegated.Bytes32()
+		pool.TotalShares = newTotalShares.Bytes32()
+		subFromDelegatorTotal(ctx, SlotTotalDelegated, amount)
+		info := LoadStakingInfo(ctx)
+		AddUnbonding(ctx, tx.From, pool.Pubkey, amount, info.CurrEpochNum+param.DelegationUnbondingEpochCount)
+	}
+	if dlgShares.IsZero() {
+		pool.NumDelegators--
+		DeleteDelegation(ctx, pubkey, tx.From)
+	} else {
+		dlg.Shares = dlgShares.Bytes32()
+		dlg.RewardDebt = accumulatedRewards(&pool, dlgShares).Bytes32()
+		SaveDelegation(ctx, dlg)
+	}
+	SaveDelegationPool(ctx, pool)
+
+	status = StatusSuccess
+	return
+}
+
+// withdraw all the mature unbonding coins of the sender
+func withdrawUnbonded(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfDelegationOp
+
+	info := LoadStakingInfo(ctx)
+	list := LoadUnbondingList(ctx, tx.From)
+	matured := uint256.NewInt(0)
+	remained := make([]*types.Unbonding, 0, len(list.Unbondings))
+	// several entries may share the same pool, and the pools are saved in the order of the entries
+	maturedPools := make([]*types.UnbondingPool, 0, len(list.Unbondings))
+	poolMapBySlot := make(map[string]*types.UnbondingPool)
+	for _, u := range list.Unbondings {
+		if u.MatureEpochNum > info.CurrEpochNum {
+			remained = append(remained, u)
+			continue
+		}
+		slot := getSlotForUnbondingPool(u.Pubkey, u.MatureEpochNum)
+		pool, ok := poolMapBySlot[slot]
+		if !ok {
+			p := LoadUnbondingPool(ctx, u.Pubkey, u.MatureEpochNum)
+			pool = &p
+			poolMapBySlot[slot] = pool
+			maturedPools = append(maturedPools, pool)
+		}
+		matured.Add(matured, takeUnbondingShares(pool, uint256.NewInt(0).SetBytes32(u.Shares[:])))
+	}
+	if len(maturedPools) == 0 {
+		outData = []byte(NoMatureUnbonding.Error())
+		return
+	}
+	// the coins may have been all slashed, and then only the entries are removed
+	if err := transferFromStakingAcc(ctx, tx.From, matured); err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	for _, pool := range maturedPools {
+		SaveUnbondingPool(ctx, *pool)
+	}
+	subFromDelegatorTotal(ctx, SlotDelegatorUnbondings, matured)
+	list.Unbondings = remained
+	SaveUnbondingList(ctx, list)
+
+	status = StatusSuccess
+	return
+}
+
+// a validator sets the commission rate taken from its delegators' rewards. Once there are delegated coins,
+// the rate can be changed only once in an epoch and by at most MaxCommissionRateChange.
+func setCommissionRate(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+	callData := tx.Data[4:]
+	if len(callData) < 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	rate := uint256.NewInt(0).SetBytes(callData[:32])
+	if !rate.IsUint64() || rate.Uint64() > MaxCommissionRate {
+		outData = []byte(CommissionRateTooBig.Error())
+		return
+	}
+
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	pool := LoadDelegationPool(ctx, val.Pubkey)
+	if !uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:]).IsZero() {
+		if pool.CommissionEpochNum == info.CurrEpochNum {
+			outData = []byte(CommissionRateChangedInEpoch.Error())
+			return
+		}
+		if rate.Uint64() > pool.CommissionRate+MaxCommissionRateChange ||
+			rate.Uint64()+MaxCommissionRateChange < pool.CommissionRate {
+			outData = []byte(CommissionRateChangeTooBig.Error())
+			return
+		}
+	}
+	pool.CommissionRate = rate.Uint64()
+	pool.CommissionEpochNum = info.CurrEpochNum
+	SaveDelegationPool(ctx, pool)
+
+	status = StatusSuccess
+	return
+}
+
+// the shares worth 'amount' coins in the pool, which are as many as the coins before any slashing. It returns
+// false if the shares overflow, which happens only if 'amount' is too large.
+func coinsToShares(pool *types.DelegationPool, amount *uint256.Int, roundUp bool) (*uint256.Int, bool) {
+	totalShares := uint256.NewInt(0).SetBytes32(pool.TotalShares[:])
+	if totalShares.IsZero() || amount.IsZero() {
+		return amount.Clone(), true
+	}
+	totalDelegated := uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:])
+	shares, overflow := uint256.NewInt(0).MulOverflow(amount, totalShares)
+	if overflow {
+		return nil, false
+	}
+	if roundUp {
+		if _, overflow = shares.AddOverflow(shares, totalDelegated); overflow {
+			return nil, false
+		}
+		shares.SubUint64(shares, 1)
+	}
+	return shares.Div(shares, totalDelegated), true
+}
+
+// the rewards accumulated by 'shares' since the pool was created
+func accumulatedRewards(pool *types.DelegationPool, shares *uint256.Int) *uint256.Int {
+	rewards := uint256.NewInt(0).SetBytes32(pool.RewardPerShare[:])
+	rewards.Mul(rewards, shares)
+	return rewards.Div(rewards, RewardPerSharePrecision)
+}
+
+// the rewards accumulated by the delegator since last settlement
+func unsettledRewards(pool *types.DelegationPool, dlg *types.Delegation) *uint256.Int {
+	rewards := accumulatedRewards(pool, uint256.NewInt(0).SetBytes32(dlg.Shares[:]))
+	return rewards.Sub(rewards, uint256.NewInt(0).SetBytes32(dlg.RewardDebt[:]))
+}
+
+// pay the rewards accumulated since last settlement to the delegator. dlg.RewardDebt must be updated after
+// dlg.Shares is changed. Nothing is changed if stakingAcc cannot pay the rewards.
+func settleDelegationRewards(ctx *mevmtypes.Context, pool *types.DelegationPool, dlg *types.Delegation) error {
+	rewards := unsettledRewards(pool, dlg)
+	if !rewards.IsZero() {
+		if err := transferFromStakingAcc(ctx, dlg.Delegator, rewards); err != nil {
+			return err
+		}
+		subFromDelegatorTotal(ctx, SlotUnsettledRewards, rewards)
+	}
+	dlg.RewardDebt = accumulatedRewards(pool, uint256.NewInt(0).SetBytes32(dlg.Shares[:])).Bytes32()
+	return nil
+}
+
+// Nothing is changed if the balance of stakingAcc is less than amount
+func transferFromStakingAcc(ctx *mevmtypes.Context, to [20]byte, amount *uint256.Int) error {
+	stakingAcc := ctx.GetAccount(StakingContractAddress)
+	if stakingAcc == nil {
+		return StakingAccBalanceNotEnough
+	}
+	stakingAccBalance := stakingAcc.Balance()
+	if _, overflow := stakingAccBalance.SubOverflow(stakingAccBalance, amount); overflow {
+		return StakingAccBalanceNotEnough
+	}
+	stakingAcc.UpdateBalance(stakingAccBalance)
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+
+	acc := ctx.GetAccount(to)
+	if acc == nil {
+		acc = mevmtypes.ZeroAccountInfo()
+	}
+	balance := acc.Balance()
+	balance.Add(balance, amount)
+	acc.UpdateBalance(balance)
+	ctx.SetAccount(to, acc)
+	return nil
+}
+
+// Split a validator's reward with its delegators when the reward accrues, by the ratio of the delegated coins
+// to all the coins staked on it at that time. The validator takes its commission from the delegators' share,
+// which is added to RewardPerShare at once, so the ones delegating later cannot share it. The delegators'
+// share is kept in stakingAcc until they settle it, and the validator's share is returned.
+func splitRewardWithDelegators(ctx *mevmtypes.Context, val *types.Validator, reward *uint256.Int) *uint256.Int {
+	pool := LoadDelegationPool(ctx, val.Pubkey)
+	totalDelegated := uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:])
+	if totalDelegated.IsZero() {
+		return reward
+	}
+	allCoins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+	allCoins.Add(allCoins, totalDelegated)
+	share := uint256.NewInt(0).Mul(reward, totalDelegated)
+	share.Div(share, allCoins)
+	commission := uint256.NewInt(0).Mul(share, uint256.NewInt(pool.CommissionRate))
+	commission.Div(commission, uint256.NewInt(MaxCommissionRate))
+	share.Sub(share, commission)
+
+	totalShares := uint256.NewInt(0).SetBytes32(pool.TotalShares[:])
+	incr := uint256.NewInt(0).Mul(share, RewardPerSharePrecision)
+	incr.Div(incr, totalShares)
+	if incr.IsZero() {
+		return reward
+	}
+	rewardPerShare := uint256.NewInt(0).SetBytes32(pool.RewardPerShare[:])
+	pool.RewardPerShare = rewardPerShare.Add(rewardPerShare, incr).Bytes32()
+	SaveDelegationPool(ctx, pool)
+	// the dust caused by rounding goes to the validator
+	allocated := incr.Mul(incr, totalShares)
+	allocated.Div(allocated, RewardPerSharePrecision)
+	addToDelegatorTotal(ctx, SlotUnsettledRewards, allocated)
+	return uint256.NewInt(0).Sub(reward, allocated)
+}
+
+// Slash the delegated coins by the same ratio as the validator's staked coins are slashed, which lowers the
+// value of every delegator's shares. The undelegated coins which are still unbonding are slashed by the same
+// ratio, so the delegators cannot escape the slashing by undelegating before the evidence is handled.
+func slashDelegatedCoins(ctx *mevmtypes.Context, pubkey [32]byte, currEpochNum int64, slashed, staked *uint256.Int) *uint256.Int {
+	pool := LoadDelegationPool(ctx, pubkey)
+	totalDelegated := uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:])
+	amount := uint256.NewInt(0).Mul(totalDelegated, slashed)
+	amount.Div(amount, staked)
+	if !amount.IsZero() {
+		updateCoinBlocks(ctx, &pool)
+		pool.TotalDelegated = totalDelegated.Sub(totalDelegated, amount).Bytes32()
+		SaveDelegationPool(ctx, pool)
+		subFromDelegatorTotal(ctx, SlotTotalDelegated, amount)
+	}
+	// the unbondings matured at current epoch can be withdrawn, so they are not slashed
+	for epochNum := currEpochNum + 1; epochNum <= currEpochNum+param.DelegationUnbondingEpochCount; epochNum++ {
+		unbondingPool := LoadUnbondingPool(ctx, pool.Pubkey, epochNum)
+		totalAmount := uint256.NewInt(0).SetBytes32(unbondingPool.TotalAmount[:])
+		slashedAmount := uint256.NewInt(0).Mul(totalAmount, slashed)
+		slashedAmount.Div(slashedAmount, staked)
+		if slashedAmount.IsZero() {
+			continue
+		}
+		unbondingPool.TotalAmount = totalAmount.Sub(totalAmount, slashedAmount).Bytes32()
+		SaveUnbondingPool(ctx, unbondingPool)
+		subFromDelegatorTotal(ctx, SlotDelegatorUnbondings, slashedAmount)
+		amount.Add(amount, slashedAmount)
+	}
+	return amount
+}
+
+// remove 'shares' from the pool and return the coins they are worth
+func takeUnbondingShares(pool *types.UnbondingPool, shares *uint256.Int) *uint256.Int {
+	totalAmount := uint256.NewInt(0).SetBytes32(pool.TotalAmount[:])
+	totalShares := uint256.NewInt(0).SetBytes32(pool.TotalShares[:])
+	if totalShares.Lt(shares) { // never happens: the shares are taken from the pool when the entry is added
+		shares = totalShares.Clone()
+	}
+	amount := uint256.NewInt(0)
+	if !totalShares.IsZero() {
+		amount.Mul(totalAmount, shares)
+		amount.Div(amount, totalShares)
+	}
+	pool.TotalAmount = totalAmount.Sub(totalAmount, amount).Bytes32()
+	pool.TotalShares = totalShares.Sub(totalShares, shares).Bytes32()
+	return amount
+}
+
+// the delegated coins accumulated over the blocks since 'startHeight', when current epoch started
+func getCoinBlocks(ctx *mevmtypes.Context, pool *types.DelegationPool, startHeight int64) *uint256.Int {
+	coinBlocks := uint256.NewInt(0)
+	if pool.CoinBlocksHeight > startHeight {
+		coinBlocks.SetBytes32(pool.CoinBlocks[:])
+	} else {
+		pool.CoinBlocksHeight = startHeight // CoinBlocks was accumulated in previous epochs
+	}
+	if ctx.Height > pool.CoinBlocksHeight {
+		blocks := uint256.NewInt(uint64(ctx.Height - pool.CoinBlocksHeight))
+		coinBlocks.Add(coinBlocks, blocks.Mul(blocks, uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:])))
+	}
+	return coinBlocks
+}
+
+// must be called before pool.TotalDelegated is changed
+func updateCoinBlocks(ctx *mevmtypes.Context, pool *types.DelegationPool) {
+	pool.CoinBlocks = getCoinBlocks(ctx, pool, loadEpochStartHeight(ctx)).Bytes32()
+	pool.CoinBlocksHeight = ctx.Height
+}
+
+func loadEpochStartHeight(ctx *mevmtypes.Context) int64 {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotEpochStartHeight)
+	if len(bz) == 0 {
+		return param.DelegationForkHeight
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// called by SwitchEpoch, and the delegated coins are accumulated again from this height
+func saveEpochStartHeight(ctx *mevmtypes.Context) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(ctx.Height))
+	ctx.SetStorageAt(StakingContractSequence, SlotEpochStartHeight, b[:])
+}
+
+// The delegators' coins in stakingAcc are kept in the slots keyed by the validators and the delegators, so
+// their totals are booked in SlotTotalDelegated, SlotDelegatorUnbondings and SlotUnsettledRewards, which
+// are checked by the invariant checker.
+func loadDelegatorTotal(ctx *mevmtypes.Context, slot string) *uint256.Int {
+	total := uint256.NewInt(0)
+	if bz := ctx.GetStorageAt(StakingContractSequence, slot); len(bz) != 0 {
+		total.SetBytes32(bz)
+	}
+	return total
+}
+
+func addToDelegatorTotal(ctx *mevmtypes.Context, slot string, amount *uint256.Int) {
+	total := loadDelegatorTotal(ctx, slot)
+	bz32 := total.Add(total, amount).Bytes32()
+	ctx.SetStorageAt(StakingContractSequence, slot, bz32[:])
+}
+
+// the total never underflows unless it is not booked correctly, which is reported by the invariant checker
+func subFromDelegatorTotal(ctx *mevmtypes.Context, slot string, amount *uint256.Int) {
+	total := loadDelegatorTotal(ctx, slot)
+	if _, overflow := total.SubOverflow(total, amount); overflow {
+		total.Clear()
+	}
+	bz32 := total.Bytes32()
+	ctx.SetStorageAt(StakingContractSequence, slot, bz32[:])
+}
+
+// AddDelegatedVotes changes the coins delegated to validators into coindays and adds them to posVotes. The
+// delegated coins are averaged over the blocks since current epoch started, so the ones delegated just before
+// the epoch switch get few votes, and then they are regarded as locked during a whole epoch, which has
+// numBlocksInEpoch BCH blocks.
+func AddDelegatedVotes(ctx *mevmtypes.Context, posVotes map[[32]byte]int64, numBlocksInEpoch int64) map[[32]byte]int64 {
+	if posVotes == nil {
+		posVotes = make(map[[32]byte]int64)
+	}
+	startHeight := loadEpochStartHeight(ctx)
+	if ctx.Height <= startHeight {
+		return posVotes
+	}
+	info := LoadStakingInfo(ctx)
+	epochDuration := uint256.NewInt(uint64(numBlocksInEpoch * MainnetBlockInterval))
+	elapsedBlocks := uint256.NewInt(uint64(ctx.Height - startHeight))
+	for _, val := range info.Validators {
+		pool := LoadDelegationPool(ctx, val.Pubkey)
+		coindays := getCoinBlocks(ctx, &pool, startHeight)
+		coindays.Mul(coindays, epochDuration)
+		coindays.Div(coindays, elapsedBlocks)
+		coindays.Div(coindays, CoindayUnit)
+		if !coindays.IsZero() {
+			posVotes[val.Pubkey] += int64(coindays.Uint64())
+		}
+	}
+	return posVotes
+}
+
+func getSlotForDelegationPool(pubkey [32]byte) string {
+	key := sha256.Sum256(append(delegationPoolSlotHashPrefix[:], pubkey[:]...))
+	return string(key[:])
+}
+
+func getSlotForDelegation(pubkey [32]byte, delegator [20]byte) string {
+	bz := append(delegationSlotHashPrefix[:], pubkey[:]...)
+	key := sha256.Sum256(append(bz, delegator[:]...))
+	return string(key[:])
+}
+
+func getSlotForUnbondingList(delegator [20]byte) string {
+	key := sha256.Sum256(append(unbondingSlotHashPrefix[:], delegator[:]...))
+	return string(key[:])
+}
+
+func getSlotForUnbondingPool(pubkey [32]byte, matureEpochNum int64) string {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(matureEpochNum))
+	bz := append(unbondingPoolSlotHashPrefix[:], pubkey[:]...)
+	key := sha256.Sum256(append(bz, b[:]...))
+	return string(key[:])
+}
+
+// Returns a pool with DefaultCommissionRate if the validator has never been delegated to
+func LoadDelegationPool(ctx *mevmtypes.Context, pubkey [32]byte) (pool types.DelegationPool) {
+	pubkey = GetDelegationPubkey(ctx, pubkey)
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForDelegationPool(pubkey))
+	if len(bz) == 0 {
+		return types.DelegationPool{
+			Pubkey:             pubkey,
+			CommissionRate:     DefaultCommissionRate,
+			CommissionEpochNum: -1,
+		}
+	}
+	_, err := pool.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func SaveDelegationPool(ctx *mevmtypes.Context, pool types.DelegationPool) {
+	bz, err := pool.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForDelegationPool(pool.Pubkey), bz)
+}
+
+func LoadDelegation(ctx *mevmtypes.Context, pubkey [32]byte, delegator [20]byte) (dlg types.Delegation) {
+	pubkey = GetDelegationPubkey(ctx, pubkey)
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForDelegation(pubkey, delegator))
+	if len(bz) == 0 {
+		return types.Delegation{Delegator: delegator, Pubkey: pubkey}
+	}
+	_, err := dlg.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func SaveDelegation(ctx *mevmtypes.Context, dlg types.Delegation) {
+	bz, err := dlg.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForDelegation(dlg.Pubkey, dlg.Delegator), bz)
+}
+
+func DeleteDelegation(ctx *mevmtypes.Context, pubkey [32]byte, delegator [20]byte) {
+	pubkey = GetDelegationPubkey(ctx, pubkey)
+	ctx.DeleteStorageAt(StakingContractSequence, getSlotForDelegation(pubkey, delegator))
+}
+
+func LoadUnbondingList(ctx *mevmtypes.Context, delegator [20]byte) (list types.UnbondingList) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForUnbondingList(delegator))
+	if len(bz) == 0 {
+		return types.UnbondingList{Delegator: delegator}
+	}
+	_, err := list.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// An empty list is deleted
+func SaveUnbondingList(ctx *mevmtypes.Context, list types.UnbondingList) {
+	if len(list.Unbondings) == 0 {
+		ctx.DeleteStorageAt(StakingContractSequence, getSlotForUnbondingList(list.Delegator))
+		return
+	}
+	bz, err := list.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForUnbondingList(list.Delegator), bz)
+}
+
+// AddUnbonding puts the coins undelegated from the validator with pubkey into the unbonding pool of
+// matureEpochNum, and records the delegator's shares of it
+func AddUnbonding(ctx *mevmtypes.Context, delegator [20]byte, pubkey [32]byte, amount *uint256.Int, matureEpochNum int64) {
+	pool := LoadUnbondingPool(ctx, pubkey, matureEpochNum)
+	totalAmount := uint256.NewInt(0).SetBytes32(pool.TotalAmount[:])
+	totalShares := uint256.NewInt(0).SetBytes32(pool.TotalShares[:])
+	shares := amount.Clone()
+	if !totalShares.IsZero() && !totalAmount.IsZero() {
+		shares.Mul(amount, totalShares)
+		shares.Div(shares, totalAmount)
+	} else if !totalShares.IsZero() {
+		// the pool is all slashed, which happens only if the delegation pool is all slashed and then nothing
+		// can be undelegated. Just in case, the worthless shares are dropped.
+		totalShares.Clear()
+	}
+	pool.TotalAmount = totalAmount.Add(totalAmount, amount).Bytes32()
+	pool.TotalShares = totalShares.Add(totalShares, shares).Bytes32()
+	SaveUnbondingPool(ctx, pool)
+	addToDelegatorTotal(ctx, SlotDelegatorUnbondings, amount)
+
+	list := LoadUnbondingList(ctx, delegator)
+	list.Unbondings = append(list.Unbondings, &types.Unbonding{
+		Pubkey:         pubkey,
+		Amount:         amount.Bytes32(),
+		MatureEpochNum: matureEpochNum,
+		Shares:         shares.Bytes32(),
+	})
+	SaveUnbondingList(ctx, list)
+}
+
+func LoadUnbondingPool(ctx *mevmtypes.Context, pubkey [32]byte, matureEpochNum int64) (pool types.UnbondingPool) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForUnbondingPool(pubkey, matureEpochNum))
+	if len(bz) == 0 {
+		return types.UnbondingPool{Pubkey: pubkey, MatureEpochNum: matureEpochNum}
+	}
+	_, err := pool.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// A pool without shares is deleted
+func SaveUnbondingPool(ctx *mevmtypes.Context, pool types.UnbondingPool) {
+	if uint256.NewInt(0).SetBytes32(pool.TotalShares[:]).IsZero() {
+		ctx.DeleteStorageAt(StakingContractSequence, getSlotForUnbondingPool(pool.Pubkey, pool.MatureEpochNum))
+		return
+	}
+	bz, err := pool.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForUnbondingPool(pool.Pubkey, pool.MatureEpochNum), bz)
+}
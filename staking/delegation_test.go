@@ -0,0 +1,351 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/moeingads/store"
+	"github.com/smartbch/moeingads/store/rabbit"
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func bch(n uint64) *uint256.Int {
+	return uint256.NewInt(0).Mul(uint256.NewInt(n), uint256.NewInt(Uint64_1e18))
+}
+
+func setupDelegationCtx(validatorPubkey [32]byte, validatorAddr, delegator common.Address) *types.Context {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(param.DelegationForkHeight)
+	stakingAcc := types.ZeroAccountInfo()
+	stakingAcc.UpdateBalance(bch(4))
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+	delegatorAcc := types.ZeroAccountInfo()
+	delegatorAcc.UpdateBalance(bch(10))
+	ctx.SetAccount(delegator, delegatorAcc)
+	SaveStakingInfo(ctx, stakingtypes.StakingInfo{
+		CurrEpochNum: 1,
+		Validators: []*stakingtypes.Validator{{
+			Address:     validatorAddr,
+			Pubkey:      validatorPubkey,
+			RewardTo:    validatorAddr,
+			VotingPower: 1,
+			StakedCoins: bch(4).Bytes32(),
+		}},
+	})
+	return ctx
+}
+
+// execute a staking tx in a block at currBlock, which may be nil if the method does not use it
+func execStakingTx(ctx *types.Context, currBlock *types.BlockInfo, from common.Address, value *uint256.Int,
+	data []byte) (int, []types.EvmLog, string) {
+	tx := &types.TxToRun{
+		BasicTx: types.BasicTx{
+			From:  from,
+			To:    StakingContractAddress,
+			Value: value.Bytes32(),
+			Data:  data,
+		},
+	}
+	status, logs, _, outData := (&StakingContractExecutor{}).Execute(ctx, currBlock, tx)
+	return status, logs, string(outData)
+}
+
+func TestDelegation(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, delegator)
+
+	// not enabled before the fork
+	ctx.SetCurrentHeight(param.DelegationForkHeight - 1)
+	status, _, out := execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, InvalidSelector.Error(), out)
+	ctx.SetCurrentHeight(param.DelegationForkHeight)
+
+	status, _, out = execStakingTx(ctx, nil, delegator, bch(4), PackDelegate([32]byte{0x02}))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, NoSuchValidator.Error(), out)
+	status, _, out = execStakingTx(ctx, nil, delegator, uint256.NewInt(1), PackDelegate(pubkey))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, DelegationAmountTooSmall.Error(), out)
+
+	status, _, _ = execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, bch(6), ctx.GetAccount(delegator).Balance())
+	require.Equal(t, bch(8), ctx.GetAccount(StakingContractAddress).Balance())
+	pool := LoadDelegationPool(ctx, pubkey)
+	require.Equal(t, bch(4).Bytes32(), pool.TotalDelegated)
+	require.Equal(t, int64(1), pool.NumDelegators)
+	require.Equal(t, bch(4).Bytes32(), LoadDelegation(ctx, pubkey, delegator).Shares)
+
+	// half of the reward belongs to the delegators, and the validator takes 10% of it as commission
+	info := LoadStakingInfo(ctx)
+	validatorShare := splitRewardWithDelegators(ctx, info.Validators[0], bch(2))
+	require.Equal(t, uint256.NewInt(11*Uint64_1e18/10), validatorShare)
+	stakingAcc := ctx.GetAccount(StakingContractAddress)
+	stakingAcc.UpdateBalance(uint256.NewInt(0).Add(bch(8), bch(2)))
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+
+	// a zero amount only settles the rewards
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, big.NewInt(0)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint256.NewInt(0).Add(bch(6), uint256.NewInt(9*Uint64_1e18/10)), ctx.GetAccount(delegator).Balance())
+	// no more rewards to be settled
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, big.NewInt(0)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint256.NewInt(0).Add(bch(6), uint256.NewInt(9*Uint64_1e18/10)), ctx.GetAccount(delegator).Balance())
+
+	status, _, out = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, bch(5).ToBig()))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, DelegationNotEnough.Error(), out)
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, bch(4).ToBig()))
+	require.Equal(t, StatusSuccess, status)
+	pool = LoadDelegationPool(ctx, pubkey)
+	require.True(t, uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:]).IsZero())
+	require.Equal(t, int64(0), pool.NumDelegators)
+	require.Equal(t, [32]byte{}, LoadDelegation(ctx, pubkey, delegator).Shares)
+	list := LoadUnbondingList(ctx, delegator)
+	require.Equal(t, 1, len(list.Unbondings))
+	require.Equal(t, 1+param.DelegationUnbondingEpochCount, list.Unbondings[0].MatureEpochNum)
+
+	// cannot withdraw before the unbonding period ends
+	status, _, out = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackWithdrawUnbonded())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, NoMatureUnbonding.Error(), out)
+	info = LoadStakingInfo(ctx)
+	info.CurrEpochNum += param.DelegationUnbondingEpochCount
+	SaveStakingInfo(ctx, info)
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackWithdrawUnbonded())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint256.NewInt(0).Add(bch(10), uint256.NewInt(9*Uint64_1e18/10)), ctx.GetAccount(delegator).Balance())
+	require.Equal(t, 0, len(LoadUnbondingList(ctx, delegator).Unbondings))
+}
+
+func TestOversizedUndelegate(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	delegator := common.Address{0xde, 0x01}
+	ctx := setupDelegationCtx(pubkey, common.Address{0xad, 0x01}, delegator)
+	status, _, _ := execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+
+	// amount*TotalShares wraps to zero shares
+	huge := new(big.Int).Lsh(big.NewInt(1), 236)
+	for _, amount := range []*big.Int{huge, new(big.Int).Add(bch(4).ToBig(), big.NewInt(1))} {
+		status, _, out := execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, amount))
+		require.Equal(t, StatusFailed, status)
+		require.Equal(t, DelegationNotEnough.Error(), out)
+	}
+	pool := LoadDelegationPool(ctx, pubkey)
+	require.Equal(t, bch(4).Bytes32(), pool.TotalDelegated)
+	require.Equal(t, bch(4).Bytes32(), pool.TotalShares)
+	require.Equal(t, 0, len(LoadUnbondingList(ctx, delegator).Unbondings))
+
+	// stakingAcc never pays more than its balance
+	AddUnbonding(ctx, delegator, pubkey, bch(9), 0)
+	status, _, out := execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackWithdrawUnbonded())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, StakingAccBalanceNotEnough.Error(), out)
+	require.Equal(t, bch(8), ctx.GetAccount(StakingContractAddress).Balance())
+	require.Equal(t, 1, len(LoadUnbondingList(ctx, delegator).Unbondings))
+}
+
+func TestSetCommissionRate(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, delegator)
+
+	status, _, out := execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackSetCommissionRate(big.NewInt(100)))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, NoSuchValidator.Error(), out)
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackSetCommissionRate(big.NewInt(10001)))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, CommissionRateTooBig.Error(), out)
+
+	// can be changed freely without delegations
+	status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackSetCommissionRate(big.NewInt(3000)))
+	require.Equal(t, StatusSuccess, status)
+	status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackSetCommissionRate(big.NewInt(2000)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint64(2000), LoadDelegationPool(ctx, pubkey).CommissionRate)
+
+	status, _, _ = execStakingTx(ctx, nil, delegator, bch(1), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackSetCommissionRate(big.NewInt(2100)))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, CommissionRateChangedInEpoch.Error(), out)
+
+	info := LoadStakingInfo(ctx)
+	info.CurrEpochNum++
+	SaveStakingInfo(ctx, info)
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackSetCommissionRate(big.NewInt(2600)))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, CommissionRateChangeTooBig.Error(), out)
+	status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackSetCommissionRate(big.NewInt(1500)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint64(1500), LoadDelegationPool(ctx, pubkey).CommissionRate)
+}
+
+func TestRewardSplitAtAccrual(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	sniper := common.Address{0xde, 0x02}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, delegator)
+	sniperAcc := types.ZeroAccountInfo()
+	sniperAcc.UpdateBalance(bch(10))
+	ctx.SetAccount(sniper, sniperAcc)
+
+	status, _, _ := execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	// the delegators' share is split out when the reward accrues, with 10% commission taken
+	info := LoadStakingInfo(ctx)
+	distributeToValidator(ctx, &info, info.GetCurrRewardMapByAddr(), bch(2), info.Validators[0])
+	require.Equal(t, uint256.NewInt(11*Uint64_1e18/10).Bytes32(), info.PendingRewards[0].Amount)
+	SaveStakingInfo(ctx, info)
+	stakingAcc := ctx.GetAccount(StakingContractAddress)
+	stakingAcc.UpdateBalance(uint256.NewInt(0).Add(bch(8), bch(2)))
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+
+	// the one delegating after the reward accrued cannot share it
+	status, _, _ = execStakingTx(ctx, nil, sniper, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	status, _, _ = execStakingTx(ctx, nil, sniper, uint256.NewInt(0), PackUndelegate(pubkey, big.NewInt(0)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, bch(6), ctx.GetAccount(sniper).Balance())
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, big.NewInt(0)))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint256.NewInt(0).Add(bch(6), uint256.NewInt(9*Uint64_1e18/10)), ctx.GetAccount(delegator).Balance())
+}
+
+func TestSlashDelegatedCoins(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	other := common.Address{0xde, 0x02}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, delegator)
+	otherAcc := types.ZeroAccountInfo()
+	otherAcc.UpdateBalance(bch(10))
+	ctx.SetAccount(other, otherAcc)
+
+	status, _, _ := execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	// a quarter of the validator's coins is slashed, and so are the delegated coins
+	info := LoadStakingInfo(ctx)
+	require.Equal(t, bch(2), Slash(ctx, &info, pubkey, bch(1)))
+	SaveStakingInfo(ctx, info)
+	require.Equal(t, bch(6), ctx.GetAccount(StakingContractAddress).Balance())
+	pool := LoadDelegationPool(ctx, pubkey)
+	require.Equal(t, bch(3).Bytes32(), pool.TotalDelegated)
+	require.Equal(t, bch(4).Bytes32(), pool.TotalShares)
+
+	// the new coins get the shares by the slashed price
+	status, _, _ = execStakingTx(ctx, nil, other, bch(3), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, bch(4).Bytes32(), LoadDelegation(ctx, pubkey, other).Shares)
+	status, _, out := execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, uint256.NewInt(0).AddUint64(bch(3), 1).ToBig()))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, DelegationNotEnough.Error(), out)
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, bch(3).ToBig()))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, [32]byte{}, LoadDelegation(ctx, pubkey, delegator).Shares)
+	require.Equal(t, bch(3), uint256.NewInt(0).SetBytes32(LoadUnbondingList(ctx, delegator).Unbondings[0].Amount[:]))
+
+	// all the delegated coins are gone with the validator's coins, including the unbonding ones, and no one
+	// can delegate to it again
+	info = LoadStakingInfo(ctx)
+	require.Equal(t, bch(9), Slash(ctx, &info, pubkey, bch(4)))
+	SaveStakingInfo(ctx, info)
+	pool = LoadDelegationPool(ctx, pubkey)
+	require.True(t, uint256.NewInt(0).SetBytes32(pool.TotalDelegated[:]).IsZero())
+	status, _, out = execStakingTx(ctx, nil, delegator, bch(1), PackDelegate(pubkey))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, DelegatedCoinsAllSlashed.Error(), out)
+	status, _, _ = execStakingTx(ctx, nil, other, uint256.NewInt(0), PackUndelegate(pubkey, big.NewInt(0)))
+	require.Equal(t, StatusSuccess, status)
+}
+
+func TestSlashUnbondingCoins(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	delegator := common.Address{0xde, 0x01}
+	ctx := setupDelegationCtx(pubkey, common.Address{0xad, 0x01}, delegator)
+
+	status, _, _ := execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, bch(4).ToBig()))
+	require.Equal(t, StatusSuccess, status)
+	matureEpochNum := 1 + param.DelegationUnbondingEpochCount
+	unbondingPool := LoadUnbondingPool(ctx, pubkey, matureEpochNum)
+	require.Equal(t, bch(4).Bytes32(), unbondingPool.TotalAmount)
+	require.Equal(t, bch(4).Bytes32(), unbondingPool.TotalShares)
+
+	// undelegating before the evidence is handled does not escape the slashing
+	info := LoadStakingInfo(ctx)
+	require.Equal(t, bch(2), Slash(ctx, &info, pubkey, bch(1)))
+	SaveStakingInfo(ctx, info)
+	unbondingPool = LoadUnbondingPool(ctx, pubkey, matureEpochNum)
+	require.Equal(t, bch(3).Bytes32(), unbondingPool.TotalAmount)
+	require.Equal(t, bch(4).Bytes32(), unbondingPool.TotalShares)
+	require.Equal(t, bch(6), ctx.GetAccount(StakingContractAddress).Balance())
+
+	// the mature ones are not slashed
+	info = LoadStakingInfo(ctx)
+	info.CurrEpochNum = matureEpochNum
+	require.Equal(t, bch(1), Slash(ctx, &info, pubkey, bch(1)))
+	SaveStakingInfo(ctx, info)
+	require.Equal(t, bch(3).Bytes32(), LoadUnbondingPool(ctx, pubkey, matureEpochNum).TotalAmount)
+
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackWithdrawUnbonded())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, bch(9), ctx.GetAccount(delegator).Balance())
+	require.Equal(t, bch(2), ctx.GetAccount(StakingContractAddress).Balance())
+	require.Equal(t, [32]byte{}, LoadUnbondingPool(ctx, pubkey, matureEpochNum).TotalShares)
+	require.Equal(t, 0, len(LoadUnbondingList(ctx, delegator).Unbondings))
+}
+
+func TestAddDelegatedVotes(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	other := common.Address{0xde, 0x02}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, delegator)
+	otherAcc := types.ZeroAccountInfo()
+	otherAcc.UpdateBalance(bch(10))
+	ctx.SetAccount(other, otherAcc)
+	startHeight := param.DelegationForkHeight
+	epochDays := param.StakingNumBlocksInEpoch * MainnetBlockInterval / (24 * 60 * 60)
+
+	posVotes := AddDelegatedVotes(ctx, nil, param.StakingNumBlocksInEpoch)
+	require.Equal(t, 0, len(posVotes))
+
+	status, _, _ := execStakingTx(ctx, nil, delegator, bch(3), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	// no votes before any block passes
+	require.Equal(t, 0, len(AddDelegatedVotes(ctx, nil, param.StakingNumBlocksInEpoch)))
+	ctx.SetCurrentHeight(startHeight + 50)
+	status, _, _ = execStakingTx(ctx, nil, other, bch(2), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	// the coins delegated in the second half of the epoch get half of the votes
+	ctx.SetCurrentHeight(startHeight + 100)
+	posVotes = AddDelegatedVotes(ctx, map[[32]byte]int64{pubkey: 10, {0x02}: 20}, param.StakingNumBlocksInEpoch)
+	require.Equal(t, 10+4*epochDays, posVotes[pubkey])
+	require.Equal(t, int64(20), posVotes[[32]byte{0x02}])
+
+	// the coins are accumulated again in the next epoch
+	saveEpochStartHeight(ctx)
+	ctx.SetCurrentHeight(startHeight + 150)
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, bch(1).ToBig()))
+	require.Equal(t, StatusSuccess, status)
+	ctx.SetCurrentHeight(startHeight + 200)
+	posVotes = AddDelegatedVotes(ctx, nil, param.StakingNumBlocksInEpoch)
+	require.Equal(t, 9*epochDays/2, posVotes[pubkey])
+}
@@ -0,0 +1,47 @@
+package staking
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var epochSnapshotSlotHashPrefix = [4]byte{'e', 's', 'n', 'p'}
+
+func IsEpochSnapshotFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.EpochSnapshotForkHeight
+}
+
+func getSlotForEpochSnapshot(epochNum int64) string {
+	var buf [12]byte
+	copy(buf[:4], epochSnapshotSlotHashPrefix[:])
+	binary.BigEndian.PutUint64(buf[4:], uint64(epochNum))
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func SaveEpochSnapshot(ctx *mevmtypes.Context, snapshot *types.EpochSnapshot) {
+	bz, err := snapshot.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForEpochSnapshot(snapshot.EpochNum), bz)
+}
+
+// The snapshots are only saved for the epochs started after EpochSnapshotForkHeight
+func LoadEpochSnapshot(ctx *mevmtypes.Context, epochNum int64) (snapshot types.EpochSnapshot, ok bool) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForEpochSnapshot(epochNum))
+	if len(bz) == 0 {
+		return
+	}
+	_, err := snapshot.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	ok = true
+	return
+}
@@ -0,0 +1,45 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestEpochSnapshot(t *testing.T) {
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx([32]byte{0x01}, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetCurrentHeight(param.EpochSnapshotForkHeight)
+
+	info := LoadStakingInfo(ctx)
+	info.PendingRewards = []*stakingtypes.PendingReward{
+		{Address: validatorAddr, EpochNum: 0, Amount: uint256.NewInt(100).Bytes32()},
+	}
+	SaveStakingInfo(ctx, info)
+	SwitchEpoch(ctx, &stakingtypes.Epoch{}, nil, param.StakingNumBlocksInEpoch, log.NewNopLogger())
+
+	_, ok := LoadEpochSnapshot(ctx, 1)
+	require.False(t, ok)
+	snapshot, ok := LoadEpochSnapshot(ctx, 2)
+	require.True(t, ok)
+	require.Equal(t, int64(2), snapshot.EpochNum)
+	require.Equal(t, 1, len(snapshot.Validators))
+	require.Equal(t, [20]byte(validatorAddr), snapshot.Validators[0].Address)
+	require.Equal(t, bch(4).Bytes32(), snapshot.Validators[0].StakedCoins)
+	require.Equal(t, 1, len(snapshot.Rewards))
+	require.Equal(t, [20]byte(validatorAddr), snapshot.Rewards[0].RewardTo)
+	require.Equal(t, int64(0), snapshot.Rewards[0].EpochNum)
+	require.Equal(t, uint256.NewInt(100).Bytes32(), snapshot.Rewards[0].Amount)
+
+	// no snapshots before the fork
+	ctx.SetCurrentHeight(param.EpochSnapshotForkHeight - 1)
+	SwitchEpoch(ctx, &stakingtypes.Epoch{}, nil, param.StakingNumBlocksInEpoch, log.NewNopLogger())
+	_, ok = LoadEpochSnapshot(ctx, 3)
+	require.False(t, ok)
+}
@@ -0,0 +1,271 @@
+package staking
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------event------*/
+	/*interface Staking {
+		// following events are emitted after StakingEventsForkHeight
+		event ValidatorCreated(address indexed validator, address indexed rewardTo, bytes32 pubkey, bytes32 introduction, uint256 stakedCoins);
+		event ValidatorEdited(address indexed validator, address indexed rewardTo, bytes32 introduction, uint256 addedCoins);
+		event Retired(address indexed validator);
+		event Slashed(bytes32 indexed pubkey, uint256 amount, uint8 reason);
+		event RewardDistributed(address indexed validator, address indexed rewardTo, uint256 epochNum, uint256 amount);
+		event EpochSwitched(uint256 indexed epochNum, uint256 startHeight, uint256 endTime, bool isValid);
+		event MinGasPriceChanged(uint256 oldMinGasPrice, uint256 newMinGasPrice);
+		event ProposalCreated(address indexed validator, uint256 target, uint256 deadline);
+		event Voted(address indexed validator, uint256 target, uint256 votingPower);
+		event ProposalExecuted(address indexed executor, uint256 minGasPrice);
+		// emitted when the staked coins of a retired validator are paid back to rewardTo
+		event ValidatorUnbondingMatured(address indexed validator, address indexed rewardTo, bytes32 pubkey, uint256 amount);
+		// following events are emitted after both StakingEventsForkHeight and JailingForkHeight
+		event Jailed(address indexed validator, bytes32 pubkey, uint256 releaseEpochNum);
+		event Unjailed(address indexed validator);
+		// following events are emitted after both StakingEventsForkHeight and RewardClaimingForkHeight
+		event RewardsWithdrawn(address indexed validator, address indexed rewardTo, uint256 amount);
+		// following events are emitted after both StakingEventsForkHeight and ParamGovernanceForkHeight
+		event ParamChangeProposed(address indexed proposer, uint256 indexed paramId, uint256 value, uint256 activationHeight, uint256 deadline);
+		event ParamChangeVoted(address indexed voter, bool approve);
+		event ParamChangeExecuted(uint256 indexed paramId, uint256 value, uint256 activationHeight, bool passed);
+		// following events are emitted after both StakingEventsForkHeight and KeyRotationForkHeight
+		event ConsensusKeyRotationRequested(address indexed validator, bytes32 oldPubkey, bytes32 newPubkey);
+		event ConsensusKeyRotated(address indexed validator, bytes32 oldPubkey, bytes32 newPubkey);
+		// following events are emitted after both StakingEventsForkHeight and StakeAdjustmentForkHeight
+		event StakeIncreased(address indexed validator, uint256 amount);
+		event StakeDecreased(address indexed validator, uint256 amount, uint256 matureEpochNum);
+	}*/
+	HashOfEventValidatorCreated              [32]byte = common.HexToHash("0x91a2b9e7772d4c633124aa133a6d3db1df6eba1e9fec56b20926c4615c2bcab4")
+	HashOfEventValidatorEdited               [32]byte = common.HexToHash("0x86f5da313e882fe8fcb82a0df11d142c0c5fab818a7edd967dd8d041f1f85bcc")
+	HashOfEventRetired                       [32]byte = common.HexToHash("0xda9d2e31afb0de4b09fe65662e5e025013186f59dd81d4946248587a2f77c6df")
+	HashOfEventSlashed                       [32]byte = common.HexToHash("0xbe0e64c77f3ca02e715b47d277f7d92f7351a751b87255e41ca5f02b63297603")
+	HashOfEventRewardDistributed             [32]byte = common.HexToHash("0xd53ea54cd361f7c49d3ca11bfcb71b4783448ade1b32513783ee19776f2adf31")
+	HashOfEventEpochSwitched                 [32]byte = common.HexToHash("0x24aa189732b5003037e2bb8580d68f5d51dc70d24966ae64f3a682156a38c133")
+	HashOfEventMinGasPriceChanged            [32]byte = common.HexToHash("0x55723bb24bc4cfc1bee08fef8a03a8cdeb93c04f89b662a6b53391c91e6128b4")
+	HashOfEventProposalCreated               [32]byte = common.HexToHash("0x5fa1acbdcb8142d84e87165149f77e37c91bcf8055862afe7b9e7c6bac70404b")
+	HashOfEventVoted                         [32]byte = common.HexToHash("0xea66f58e474bc09f580000e81f31b334d171db387d0c6098ba47bd897741679b")
+	HashOfEventProposalExecuted              [32]byte = common.HexToHash("0x7bfd03e44f31b1867e10c41d419cd8073b4719d321ccc5c3a3c79ac2babab3c3")
+	HashOfEventValidatorUnbondingMatured     [32]byte = common.HexToHash("0x7cb35ba209ef165c42312479d6ebc9598bc58774f44f8e67306c95081e929ed2")
+	HashOfEventJailed                        [32]byte = common.HexToHash("0x19b39d69b9f40f52b1b25f31c6cdc1c7da0c52dc6a1ba690030a13c85341ff13")
+	HashOfEventUnjailed                      [32]byte = common.HexToHash("0xfa5039497ad9ba11f0eb5239b2614e925541bbcc0cf3476dd68e1927c86d33ff")
+	HashOfEventRewardsWithdrawn              [32]byte = common.HexToHash("0xd01566b65da2d62cb66f3844588c99d9a95b38394ccdc9c3f7604536e653cf1b")
+	HashOfEventParamChangeProposed           [32]byte = common.HexToHash("0xb37a9fcebcc27b0de61ef37881ff2cf51bd976378a1176e19f37c5540584c42f")
+	HashOfEventParamChangeVoted              [32]byte = common.HexToHash("0x174e2aab93eb24e920d692adb6beb5c4235d3f61e8a9e9c13a1e117995547a68")
+	HashOfEventParamChangeExecuted           [32]byte = common.HexToHash("0xc192820cc531530d3250d66c0ee5607fb26758044120da4b8fd48187a6599da6")
+	HashOfEventConsensusKeyRotationRequested [32]byte = common.HexToHash("0xa3f033b06cf24da054bf18623e43aaa577b28be20d287f7be7f8868fa1692913")
+	HashOfEventConsensusKeyRotated           [32]byte = common.HexToHash("0xa4cf7a0e269281e634f9bf6e80da9919fe9e89ca5b65b1a624fd8ccad849b0b2")
+	HashOfEventStakeIncreased                [32]byte = common.HexToHash("0x8b0ed825817a2e696c9a931715af4609fc60e1701f09c89ee7645130e937eb2d")
+	HashOfEventStakeDecreased                [32]byte = common.HexToHash("0x3c3bf06223167c735090f086f81aa7fb429e136c104164154bb5f0f079108bd9")
+)
+
+// the 'reason' field of the Slashed event
+const (
+	SlashReasonDuplicateSig      uint8 = 0
+	SlashReasonNotOnline         uint8 = 1
+	SlashReasonLightClientAttack uint8 = 2
+)
+
+func IsStakingEventsFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.StakingEventsForkHeight
+}
+
+// build a log of the staking contract, whose data are the 32-byte words in 'data'
+func buildStakingEvmLog(eventHash [32]byte, indexedTopics []common.Hash, data ...[32]byte) mevmtypes.EvmLog {
+	evmLog := mevmtypes.EvmLog{
+		Address: StakingContractAddress,
+		Topics:  make([]common.Hash, 0, 1+len(indexedTopics)),
+		Data:    make([]byte, 0, 32*len(data)),
+	}
+	evmLog.Topics = append(evmLog.Topics, eventHash)
+	evmLog.Topics = append(evmLog.Topics, indexedTopics...)
+	for _, word := range data {
+		evmLog.Data = append(evmLog.Data, word[:]...)
+	}
+	return evmLog
+}
+
+func addressToWord(addr [20]byte) (word [32]byte) {
+	copy(word[12:], addr[:])
+	return
+}
+
+func uint64ToWord(n uint64) [32]byte {
+	return uint256.NewInt(n).Bytes32()
+}
+
+func boolToWord(b bool) (word [32]byte) {
+	if b {
+		word[31] = 1
+	}
+	return
+}
+
+func buildValidatorCreatedEvmLog(val *types.Validator) mevmtypes.EvmLog {
+	var intro [32]byte
+	copy(intro[:], val.Introduction)
+	return buildStakingEvmLog(HashOfEventValidatorCreated,
+		[]common.Hash{addressToWord(val.Address), addressToWord(val.RewardTo)},
+		val.Pubkey, intro, val.StakedCoins)
+}
+
+func buildValidatorEditedEvmLog(val *types.Validator, addedCoins [32]byte) mevmtypes.EvmLog {
+	var intro [32]byte
+	copy(intro[:], val.Introduction)
+	return buildStakingEvmLog(HashOfEventValidatorEdited,
+		[]common.Hash{addressToWord(val.Address), addressToWord(val.RewardTo)},
+		intro, addedCoins)
+}
+
+func buildRetiredEvmLog(validator [20]byte) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventRetired, []common.Hash{addressToWord(validator)})
+}
+
+func buildSlashedEvmLog(pubkey [32]byte, amount *uint256.Int, reason uint8) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventSlashed, []common.Hash{pubkey},
+		amount.Bytes32(), uint64ToWord(uint64(reason)))
+}
+
+func buildRewardDistributedEvmLog(val *types.Validator, epochNum int64, amount *uint256.Int) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventRewardDistributed,
+		[]common.Hash{addressToWord(val.Address), addressToWord(val.RewardTo)},
+		uint64ToWord(uint64(epochNum)), amount.Bytes32())
+}
+
+func buildEpochSwitchedEvmLog(epoch *types.Epoch, isValid bool) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventEpochSwitched, []common.Hash{uint64ToWord(uint64(epoch.Number))},
+		uint64ToWord(uint64(epoch.StartHeight)), uint64ToWord(uint64(epoch.EndTime)), boolToWord(isValid))
+}
+
+func buildMinGasPriceChangedEvmLog(oldMinGasPrice, newMinGasPrice uint64) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventMinGasPriceChanged, nil,
+		uint64ToWord(oldMinGasPrice), uint64ToWord(newMinGasPrice))
+}
+
+func buildProposalCreatedEvmLog(validator [20]byte, target, deadline uint64) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventProposalCreated, []common.Hash{addressToWord(validator)},
+		uint64ToWord(target), uint64ToWord(deadline))
+}
+
+func buildVotedEvmLog(validator [20]byte, target, votingPower uint64) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventVoted, []common.Hash{addressToWord(validator)},
+		uint64ToWord(target), uint64ToWord(votingPower))
+}
+
+func buildProposalExecutedEvmLog(executor [20]byte, minGasPrice uint64) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventProposalExecuted, []common.Hash{addressToWord(executor)},
+		uint64ToWord(minGasPrice))
+}
+
+func buildValidatorUnbondingMaturedEvmLog(u *types.ValidatorUnbonding) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventValidatorUnbondingMatured,
+		[]common.Hash{addressToWord(u.Address), addressToWord(u.RewardTo)},
+		u.Pubkey, u.Amount)
+}
+
+func buildJailedEvmLog(j *types.JailedValidator) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventJailed, []common.Hash{addressToWord(j.Address)},
+		j.Pubkey, uint64ToWord(uint64(j.ReleaseEpochNum)))
+}
+
+func buildUnjailedEvmLog(validator [20]byte) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventUnjailed, []common.Hash{addressToWord(validator)})
+}
+
+func buildRewardsWithdrawnEvmLog(val *types.Validator, amount *uint256.Int) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventRewardsWithdrawn,
+		[]common.Hash{addressToWord(val.Address), addressToWord(val.RewardTo)},
+		amount.Bytes32())
+}
+
+func buildParamChangeProposedEvmLog(p *types.ParamProposal) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventParamChangeProposed,
+		[]common.Hash{addressToWord(p.Proposer), uint64ToWord(p.ParamID)},
+		uint64ToWord(p.Value), uint64ToWord(uint64(p.ActivationHeight)), uint64ToWord(p.Deadline))
+}
+
+func buildParamChangeVotedEvmLog(voter [20]byte, approve bool) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventParamChangeVoted, []common.Hash{addressToWord(voter)},
+		boolToWord(approve))
+}
+
+func buildParamChangeExecutedEvmLog(p *types.ParamProposal, passed bool) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventParamChangeExecuted, []common.Hash{uint64ToWord(p.ParamID)},
+		uint64ToWord(p.Value), uint64ToWord(uint64(p.ActivationHeight)), boolToWord(passed))
+}
+
+func buildConsensusKeyRotationRequestedEvmLog(validator [20]byte, oldPubkey, newPubkey [32]byte) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventConsensusKeyRotationRequested, []common.Hash{addressToWord(validator)},
+		oldPubkey, newPubkey)
+}
+
+func buildConsensusKeyRotatedEvmLog(validator [20]byte, oldPubkey, newPubkey [32]byte) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventConsensusKeyRotated, []common.Hash{addressToWord(validator)},
+		oldPubkey, newPubkey)
+}
+
+func buildStakeIncreasedEvmLog(validator [20]byte, amount *uint256.Int) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventStakeIncreased, []common.Hash{addressToWord(validator)},
+		amount.Bytes32())
+}
+
+func buildStakeDecreasedEvmLog(validator [20]byte, amount *uint256.Int, matureEpochNum int64) mevmtypes.EvmLog {
+	return buildStakingEvmLog(HashOfEventStakeDecreased, []common.Hash{addressToWord(validator)},
+		amount.Bytes32(), uint64ToWord(uint64(matureEpochNum)))
+}
+
+// The logs emitted in a block's Commit are written into the history store in the next block's Commit,
+// so they are kept in the contract's storage to survive a restart in between
+func LoadPendingSystemLogs(ctx *mevmtypes.Context) []mevmtypes.EvmLog {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotPendingSystemLogs)
+	if len(bz) == 0 {
+		return nil
+	}
+	var list types.SystemLogList
+	_, err := list.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	logs := make([]mevmtypes.EvmLog, len(list.Logs))
+	for i, l := range list.Logs {
+		logs[i].Address = l.Address
+		logs[i].Topics = make([]common.Hash, len(l.Topics))
+		for j, topic := range l.Topics {
+			logs[i].Topics[j] = topic
+		}
+		logs[i].Data = l.Data
+	}
+	return logs
+}
+
+// The storage is only touched when there are pending logs or it must be cleared
+func SavePendingSystemLogs(ctx *mevmtypes.Context, logs []mevmtypes.EvmLog) {
+	if len(logs) == 0 {
+		if len(ctx.GetStorageAt(StakingContractSequence, SlotPendingSystemLogs)) != 0 {
+			ctx.DeleteStorageAt(StakingContractSequence, SlotPendingSystemLogs)
+		}
+		return
+	}
+	list := types.SystemLogList{Logs: make([]*types.SystemLog, len(logs))}
+	for i, l := range logs {
+		list.Logs[i] = &types.SystemLog{
+			Address: l.Address,
+			Topics:  make([][32]byte, len(l.Topics)),
+			Data:    l.Data,
+		}
+		for j, topic := range l.Topics {
+			list.Logs[i].Topics[j] = topic
+		}
+	}
+	bz, err := list.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, SlotPendingSystemLogs, bz)
+}
@@ -0,0 +1,169 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func unpackEventData(t *testing.T, name string, data []byte) []interface{} {
+	ret, err := ABI.GetABI().Unpack(name, data)
+	require.NoError(t, err)
+	return ret
+}
+
+func TestStakingEventHashes(t *testing.T) {
+	events := ABI.GetABI().Events
+	require.Equal(t, common.Hash(HashOfEventValidatorCreated), events["ValidatorCreated"].ID)
+	require.Equal(t, common.Hash(HashOfEventValidatorEdited), events["ValidatorEdited"].ID)
+	require.Equal(t, common.Hash(HashOfEventRetired), events["Retired"].ID)
+	require.Equal(t, common.Hash(HashOfEventSlashed), events["Slashed"].ID)
+	require.Equal(t, common.Hash(HashOfEventRewardDistributed), events["RewardDistributed"].ID)
+	require.Equal(t, common.Hash(HashOfEventEpochSwitched), events["EpochSwitched"].ID)
+	require.Equal(t, common.Hash(HashOfEventMinGasPriceChanged), events["MinGasPriceChanged"].ID)
+	require.Equal(t, common.Hash(HashOfEventProposalCreated), events["ProposalCreated"].ID)
+	require.Equal(t, common.Hash(HashOfEventVoted), events["Voted"].ID)
+	require.Equal(t, common.Hash(HashOfEventProposalExecuted), events["ProposalExecuted"].ID)
+	require.Equal(t, common.Hash(HashOfEventValidatorUnbondingMatured), events["ValidatorUnbondingMatured"].ID)
+	require.Equal(t, common.Hash(HashOfEventJailed), events["Jailed"].ID)
+	require.Equal(t, common.Hash(HashOfEventUnjailed), events["Unjailed"].ID)
+	require.Equal(t, common.Hash(HashOfEventRewardsWithdrawn), events["RewardsWithdrawn"].ID)
+	require.Equal(t, common.Hash(HashOfEventParamChangeProposed), events["ParamChangeProposed"].ID)
+	require.Equal(t, common.Hash(HashOfEventParamChangeVoted), events["ParamChangeVoted"].ID)
+	require.Equal(t, common.Hash(HashOfEventParamChangeExecuted), events["ParamChangeExecuted"].ID)
+	require.Equal(t, common.Hash(HashOfEventConsensusKeyRotationRequested), events["ConsensusKeyRotationRequested"].ID)
+	require.Equal(t, common.Hash(HashOfEventConsensusKeyRotated), events["ConsensusKeyRotated"].ID)
+	require.Equal(t, common.Hash(HashOfEventStakeIncreased), events["StakeIncreased"].ID)
+	require.Equal(t, common.Hash(HashOfEventStakeDecreased), events["StakeDecreased"].ID)
+}
+
+func TestValidatorOpEvents(t *testing.T) {
+	ctx := setupDelegationCtx([32]byte{0x01}, common.Address{0xad, 0x01}, common.Address{0xde, 0x01})
+	ctx.SetCurrentHeight(param.StakingEventsForkHeight)
+	sender := common.Address{0xad, 0x02}
+	rewardTo := common.Address{0xbe, 0x02}
+	acc := types.ZeroAccountInfo()
+	acc.UpdateBalance(bch(200))
+	ctx.SetAccount(sender, acc)
+
+	status, logs, _ := execStakingTx(ctx, &types.BlockInfo{}, sender, bch(150),
+		PackCreateValidator(rewardTo, [32]byte{'v', 'a', 'l'}, [32]byte{0x02}))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventValidatorCreated), logs[0].Topics[0])
+	require.Equal(t, common.BytesToHash(sender[:]), logs[0].Topics[1])
+	require.Equal(t, common.BytesToHash(rewardTo[:]), logs[0].Topics[2])
+	data := unpackEventData(t, "ValidatorCreated", logs[0].Data)
+	require.Equal(t, [32]byte{0x02}, data[0])
+	require.Equal(t, [32]byte{'v', 'a', 'l'}, data[1])
+	require.Equal(t, bch(150).ToBig(), data[2])
+
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{}, sender, bch(1), PackEditValidator(common.Address{}, [32]byte{}))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventValidatorEdited), logs[0].Topics[0])
+	require.Equal(t, common.BytesToHash(rewardTo[:]), logs[0].Topics[2])
+	data = unpackEventData(t, "ValidatorEdited", logs[0].Data)
+	require.Equal(t, bch(1).ToBig(), data[1])
+
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{}, sender, uint256.NewInt(0), PackRetire())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventRetired), logs[0].Topics[0])
+	require.Equal(t, common.BytesToHash(sender[:]), logs[0].Topics[1])
+
+	// no events before the fork
+	ctx.SetCurrentHeight(param.StakingEventsForkHeight - 1)
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{}, common.Address{0xad, 0x01}, uint256.NewInt(0), PackRetire())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 0, len(logs))
+}
+
+func TestProposalEvents(t *testing.T) {
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx([32]byte{0x01}, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetXHedgeForkBlock(0) // the proposal methods are enabled after xHedge fork
+	ctx.SetCurrentHeight(param.StakingEventsForkHeight)
+	target := DefaultMinGasPrice * 2
+
+	status, logs, _ := execStakingTx(ctx, &types.BlockInfo{Timestamp: 100}, validatorAddr, uint256.NewInt(0), PackProposal(big.NewInt(int64(target))))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventProposalCreated), logs[0].Topics[0])
+	data := unpackEventData(t, "ProposalCreated", logs[0].Data)
+	require.Equal(t, new(big.Int).SetUint64(target), data[0])
+	require.Equal(t, new(big.Int).SetUint64(100+DefaultProposalDuration), data[1])
+
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{Timestamp: 200}, validatorAddr, uint256.NewInt(0), PackVote(big.NewInt(int64(target))))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventVoted), logs[0].Topics[0])
+	data = unpackEventData(t, "Voted", logs[0].Data)
+	require.Equal(t, big.NewInt(1), data[1])
+
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{Timestamp: int64(100 + DefaultProposalDuration)}, validatorAddr, uint256.NewInt(0), PackExecuteProposal())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 2, len(logs))
+	require.Equal(t, common.Hash(HashOfEventProposalExecuted), logs[0].Topics[0])
+	require.Equal(t, common.Hash(HashOfEventMinGasPriceChanged), logs[1].Topics[0])
+	data = unpackEventData(t, "MinGasPriceChanged", logs[1].Data)
+	require.Equal(t, new(big.Int).SetUint64(DefaultMinGasPrice), data[0])
+	require.Equal(t, new(big.Int).SetUint64(target), data[1])
+}
+
+func TestSlashAndRewardEvents(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetStakingForkBlock(0) // the duplicate signers are slashed after staking fork
+	ctx.SetCurrentHeight(param.StakingEventsForkHeight)
+
+	info := LoadStakingInfo(ctx)
+	info.CurrEpochNum = 10
+	info.PendingRewards = []*stakingtypes.PendingReward{
+		{Address: validatorAddr, EpochNum: 1, Amount: uint256.NewInt(100).Bytes32()},
+		{Address: validatorAddr, EpochNum: 10, Amount: uint256.NewInt(200).Bytes32()},
+	}
+	logs, _ := deliverMintRewardInEpoch(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventRewardDistributed), logs[0].Topics[0])
+	require.Equal(t, common.BytesToHash(validatorAddr[:]), logs[0].Topics[1])
+	data := unpackEventData(t, "RewardDistributed", logs[0].Data)
+	require.Equal(t, big.NewInt(1), data[0])
+	require.Equal(t, big.NewInt(100), data[1])
+
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	_, _, _, logs = SlashAndReward(ctx, [][20]byte{consAddr}, nil, [20]byte{}, [20]byte{}, nil, nil)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventSlashed), logs[0].Topics[0])
+	require.Equal(t, common.Hash(pubkey), logs[0].Topics[1])
+	data = unpackEventData(t, "Slashed", logs[0].Data)
+	require.Equal(t, bch(4).ToBig(), data[0]) // all the staked coins are slashed
+	require.Equal(t, SlashReasonDuplicateSig, data[1])
+}
+
+func TestPendingSystemLogs(t *testing.T) {
+	ctx := setupDelegationCtx([32]byte{0x01}, common.Address{0xad, 0x01}, common.Address{0xde, 0x01})
+	require.Nil(t, LoadPendingSystemLogs(ctx))
+
+	logs := []types.EvmLog{
+		buildStakeIncreasedEvmLog(common.Address{0xad, 0x01}, bch(2)),
+		buildSlashedEvmLog([32]byte{0x01}, bch(1), SlashReasonDuplicateSig),
+	}
+	SavePendingSystemLogs(ctx, logs)
+	require.Equal(t, logs, LoadPendingSystemLogs(ctx))
+
+	SavePendingSystemLogs(ctx, nil)
+	require.Nil(t, LoadPendingSystemLogs(ctx))
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, SlotPendingSystemLogs))
+}
@@ -0,0 +1,151 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/smartbch/moeingevm/ebp"
+	mevmtypes "github.com/smartbch/moeingevm/types"
+)
+
+const (
+	InvariantStakingBalance = "staking-balance"
+	InvariantAllBurnt       = "all-burnt"
+	InvariantSystemBalance  = "system-balance"
+)
+
+// An InvariantViolation reports a balance which does not match the expected one, and the discrepancy between them
+type InvariantViolation struct {
+	Name        string
+	Expected    *uint256.Int
+	Actual      *uint256.Int
+	Discrepancy *big.Int // Actual - Expected
+}
+
+func newInvariantViolation(name string, expected, actual *uint256.Int) *InvariantViolation {
+	return &InvariantViolation{
+		Name:        name,
+		Expected:    expected,
+		Actual:      actual,
+		Discrepancy: big.NewInt(0).Sub(actual.ToBig(), expected.ToBig()),
+	}
+}
+
+func (v *InvariantViolation) Error() string {
+	return fmt.Sprintf("invariant %s is broken: actual(%s), expected(%s), discrepancy: %s",
+		v.Name, v.Actual.ToBig(), v.Expected.ToBig(), v.Discrepancy)
+}
+
+// The coins kept in stakingAcc
+type StakingAccAmounts struct {
+	StakedCoins         *uint256.Int
+	PendingRewards      *uint256.Int
+	ValidatorUnbondings *uint256.Int
+	KeptRewards         *uint256.Int
+	Delegated           *uint256.Int
+	DelegatorUnbondings *uint256.Int
+	UnsettledRewards    *uint256.Int
+}
+
+func (a *StakingAccAmounts) Total() *uint256.Int {
+	total := uint256.NewInt(0).Add(a.StakedCoins, a.PendingRewards)
+	total.Add(total, a.ValidatorUnbondings)
+	total.Add(total, a.KeptRewards)
+	total.Add(total, a.Delegated)
+	total.Add(total, a.DelegatorUnbondings)
+	return total.Add(total, a.UnsettledRewards)
+}
+
+// Sum up the coins kept in stakingAcc. The collected fees are distributed to the pending rewards at once,
+// so they are included in PendingRewards. The delegators' coins are read from the totals booked in slots,
+// because their pools outlive the validators cleared from StakingInfo.
+func GetStakingAccAmounts(ctx *mevmtypes.Context) *StakingAccAmounts {
+	amounts := &StakingAccAmounts{
+		StakedCoins:         uint256.NewInt(0),
+		PendingRewards:      uint256.NewInt(0),
+		ValidatorUnbondings: uint256.NewInt(0),
+		KeptRewards:         uint256.NewInt(0),
+		Delegated:           uint256.NewInt(0),
+		DelegatorUnbondings: uint256.NewInt(0),
+		UnsettledRewards:    uint256.NewInt(0),
+	}
+	info := LoadStakingInfo(ctx)
+	for _, val := range info.Validators {
+		amounts.StakedCoins.Add(amounts.StakedCoins, uint256.NewInt(0).SetBytes32(val.StakedCoins[:]))
+		if IsRewardClaimingFork(ctx) {
+			acc := LoadRewardAccount(ctx, val.Address)
+			amounts.KeptRewards.Add(amounts.KeptRewards, uint256.NewInt(0).SetBytes32(acc.Accumulated[:]))
+		}
+	}
+	if IsDelegationFork(ctx) {
+		amounts.Delegated = loadDelegatorTotal(ctx, SlotTotalDelegated)
+		amounts.DelegatorUnbondings = loadDelegatorTotal(ctx, SlotDelegatorUnbondings)
+		amounts.UnsettledRewards = loadDelegatorTotal(ctx, SlotUnsettledRewards)
+	}
+	for _, rwd := range info.PendingRewards {
+		amounts.PendingRewards.Add(amounts.PendingRewards, uint256.NewInt(0).SetBytes32(rwd.Amount[:]))
+	}
+	if IsValidatorUnbondingFork(ctx) {
+		for _, u := range LoadValidatorUnbondingList(ctx).Unbondings {
+			amounts.ValidatorUnbondings.Add(amounts.ValidatorUnbondings, uint256.NewInt(0).SetBytes32(u.Amount[:]))
+		}
+	}
+	return amounts
+}
+
+func loadAllBurnt(ctx *mevmtypes.Context) *uint256.Int {
+	allBurnt := uint256.NewInt(0)
+	if bz := ctx.GetStorageAt(StakingContractSequence, SlotAllBurnt); len(bz) != 0 {
+		allBurnt.SetBytes32(bz)
+	}
+	return allBurnt
+}
+
+// stakingAcc's balance must equal the coins kept in it. Before SlashBurningFixForkHeight, the coins slashed
+// in a block with collected fees were put back to stakingAcc, so its balance can only be checked to be no
+// less than the coins kept in it.
+func CheckStakingBalance(ctx *mevmtypes.Context) error {
+	expected := GetStakingAccAmounts(ctx).Total()
+	acc := ctx.GetAccount(StakingContractAddress)
+	if acc == nil {
+		acc = mevmtypes.ZeroAccountInfo()
+	}
+	balance := acc.Balance()
+	if balance.Lt(expected) || (IsSlashBurningFixFork(ctx) && !balance.Eq(expected)) {
+		return newInvariantViolation(InvariantStakingBalance, expected, balance)
+	}
+	return nil
+}
+
+// The slashed coins are booked in SlotAllBurnt when they are sent to BlackHole acc, which also receives
+// the burnt fees, so its balance must be no less than all the slashed coins.
+func CheckAllBurnt(ctx *mevmtypes.Context) error {
+	allBurnt := loadAllBurnt(ctx)
+	balance := ebp.GetBlackHoleBalance(ctx)
+	if balance.Lt(allBurnt) {
+		return newInvariantViolation(InvariantAllBurnt, allBurnt, balance)
+	}
+	return nil
+}
+
+// The system account must hold the gas fees and refunds which are not distributed yet
+func CheckSystemBalance(ctx *mevmtypes.Context, undistributed *uint256.Int) error {
+	balance := ebp.GetSystemBalance(ctx)
+	if balance.Lt(undistributed) {
+		return newInvariantViolation(InvariantSystemBalance, undistributed, balance)
+	}
+	return nil
+}
+
+// Check the invariants of stakingAcc and BlackHole acc, and return the broken ones
+func CheckInvariants(ctx *mevmtypes.Context) (errs []error) {
+	if err := CheckStakingBalance(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := CheckAllBurnt(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
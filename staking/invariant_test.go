@@ -0,0 +1,126 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, delegator)
+	ctx.SetStakingForkBlock(0) // the duplicate signers are slashed after staking fork
+	for _, h := range []int64{param.SlashBurningFixForkHeight - 1, param.SlashBurningFixForkHeight} {
+		ctx.SetCurrentHeight(h)
+		require.Equal(t, 0, len(CheckInvariants(ctx)))
+
+		// more coins than the booked ones are only allowed before the fork
+		stakingAcc := ctx.GetAccount(StakingContractAddress)
+		stakingAcc.UpdateBalance(bch(5))
+		ctx.SetAccount(StakingContractAddress, stakingAcc)
+		errs := CheckInvariants(ctx)
+		if h < param.SlashBurningFixForkHeight {
+			require.Equal(t, 0, len(errs))
+		} else {
+			require.Equal(t, 1, len(errs))
+			violation := errs[0].(*InvariantViolation)
+			require.Equal(t, InvariantStakingBalance, violation.Name)
+			require.Equal(t, bch(4), violation.Expected)
+			require.Equal(t, bch(5), violation.Actual)
+			require.Equal(t, bch(1).ToBig(), violation.Discrepancy)
+		}
+
+		stakingAcc.UpdateBalance(bch(3))
+		ctx.SetAccount(StakingContractAddress, stakingAcc)
+		errs = CheckInvariants(ctx)
+		require.Equal(t, 1, len(errs))
+		violation := errs[0].(*InvariantViolation)
+		require.Equal(t, big.NewInt(0).Neg(bch(1).ToBig()), violation.Discrepancy)
+
+		stakingAcc.UpdateBalance(bch(4))
+		ctx.SetAccount(StakingContractAddress, stakingAcc)
+	}
+
+	// the delegators' coins are booked in the aggregate slots all the way
+	status, _, _ := execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 0, len(CheckInvariants(ctx)))
+	info := LoadStakingInfo(ctx)
+	distributeToValidator(ctx, &info, info.GetCurrRewardMapByAddr(), bch(2), info.Validators[0])
+	SaveStakingInfo(ctx, info)
+	stakingAcc := ctx.GetAccount(StakingContractAddress)
+	stakingAcc.UpdateBalance(uint256.NewInt(0).Add(bch(8), bch(2)))
+	ctx.SetAccount(StakingContractAddress, stakingAcc)
+	require.Equal(t, 0, len(CheckInvariants(ctx)))
+	require.Equal(t, uint256.NewInt(9*Uint64_1e18/10), GetStakingAccAmounts(ctx).UnsettledRewards)
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackUndelegate(pubkey, bch(4).ToBig()))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 0, len(CheckInvariants(ctx)))
+	amounts := GetStakingAccAmounts(ctx)
+	require.True(t, amounts.Delegated.IsZero())
+	require.True(t, amounts.UnsettledRewards.IsZero())
+	require.Equal(t, bch(4), amounts.DelegatorUnbondings)
+	info = LoadStakingInfo(ctx)
+	Slash(ctx, &info, pubkey, bch(1))
+	SaveStakingInfo(ctx, info)
+	require.Equal(t, 0, len(CheckInvariants(ctx)))
+	require.Equal(t, bch(3), GetStakingAccAmounts(ctx).DelegatorUnbondings)
+	info = LoadStakingInfo(ctx)
+	info.CurrEpochNum += param.DelegationUnbondingEpochCount
+	SaveStakingInfo(ctx, info)
+	status, _, _ = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackWithdrawUnbonded())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 0, len(CheckInvariants(ctx)))
+	require.True(t, GetStakingAccAmounts(ctx).DelegatorUnbondings.IsZero())
+
+	// slashed coins are moved to BlackHole acc and booked in SlotAllBurnt
+	ctx.SetCurrentHeight(param.SlashHistoryForkHeight)
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	SlashAndReward(ctx, [][20]byte{consAddr}, nil, [20]byte{}, [20]byte{}, nil, nil)
+	require.False(t, loadAllBurnt(ctx).IsZero())
+	require.Equal(t, 0, len(CheckInvariants(ctx)))
+	incrAllBurnt(ctx, uint256.NewInt(1))
+	errs := CheckInvariants(ctx)
+	require.Equal(t, 1, len(errs))
+	violation := errs[0].(*InvariantViolation)
+	require.Equal(t, InvariantAllBurnt, violation.Name)
+	require.Equal(t, big.NewInt(-1), violation.Discrepancy)
+
+	require.Nil(t, CheckSystemBalance(ctx, uint256.NewInt(0)))
+	require.NotNil(t, CheckSystemBalance(ctx, uint256.NewInt(1)))
+}
+
+func TestSlashWithBlockReward(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	for _, h := range []int64{param.SlashBurningFixForkHeight - 1, param.SlashBurningFixForkHeight} {
+		ctx := setupDelegationCtx(pubkey, validatorAddr, delegator)
+		ctx.SetStakingForkBlock(0)
+		ctx.SetCurrentHeight(h)
+		if IsDelegationFork(ctx) {
+			status, _, _ := execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(pubkey))
+			require.Equal(t, StatusSuccess, status)
+		}
+
+		// the collected fees are added to stakingAcc after the slashed coins are burnt from it
+		SlashAndReward(ctx, [][20]byte{consAddr}, nil, [20]byte{}, [20]byte{}, [][]byte{consAddr[:]}, bch(2))
+		require.False(t, loadAllBurnt(ctx).IsZero())
+		if IsSlashBurningFixFork(ctx) {
+			require.Equal(t, GetStakingAccAmounts(ctx).Total(), ctx.GetAccount(StakingContractAddress).Balance())
+		}
+		require.Equal(t, 0, len(CheckInvariants(ctx)))
+		require.Nil(t, CheckSystemBalance(ctx, uint256.NewInt(0)))
+	}
+}
@@ -0,0 +1,147 @@
+package staking
+
+import (
+	"errors"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------selector------*/
+	/*interface Staking {
+		// following methods are enabled after JailingForkHeight
+		//f679d305
+		function unjail() external;
+	}*/
+	SelectorUnjail = [4]byte{0xf6, 0x79, 0xd3, 0x05}
+
+	/*------error info------*/
+	ValidatorNotJailed   = errors.New("validator not jailed")
+	ValidatorStillJailed = errors.New("validator still jailed")
+)
+
+func IsJailingFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.JailingForkHeight
+}
+
+func LoadJailedValidatorList(ctx *mevmtypes.Context) (list types.JailedValidatorList) {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotJailedValidators)
+	if len(bz) == 0 {
+		return
+	}
+	_, err := list.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// An empty list is deleted
+func SaveJailedValidatorList(ctx *mevmtypes.Context, list types.JailedValidatorList) {
+	if len(list.Jailed) == 0 {
+		ctx.DeleteStorageAt(StakingContractSequence, SlotJailedValidators)
+		return
+	}
+	bz, err := list.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, SlotJailedValidators, bz)
+}
+
+// Put the validators into jail, from which they can be released after JailEpochCount epochs
+func jailValidators(ctx *mevmtypes.Context, currEpochNum int64, vals []*types.Validator) {
+	list := LoadJailedValidatorList(ctx)
+	jailedMap := list.GetMapByPubkey()
+	for _, val := range vals {
+		if _, ok := jailedMap[val.Pubkey]; ok {
+			continue
+		}
+		list.Jailed = append(list.Jailed, &types.JailedValidator{
+			Address:         val.Address,
+			Pubkey:          val.Pubkey,
+			JailedEpochNum:  currEpochNum,
+			ReleaseEpochNum: currEpochNum + param.JailEpochCount,
+		})
+	}
+	SaveJailedValidatorList(ctx, list)
+}
+
+// A jailed validator has zero voting power but it is not useless, so remove it from uselessValMap.
+// If a jailed validator retires, it is useless and its jail entry is dropped.
+func keepJailedValidators(ctx *mevmtypes.Context, info *types.StakingInfo, uselessValMap map[[20]byte]struct{}) {
+	list := LoadJailedValidatorList(ctx)
+	if len(list.Jailed) == 0 {
+		return
+	}
+	jailedMap := list.GetMapByPubkey()
+	retiredSet := make(map[[32]byte]struct{})
+	for _, val := range info.Validators {
+		if _, ok := uselessValMap[val.Address]; !ok {
+			continue
+		}
+		if _, ok := jailedMap[val.Pubkey]; !ok {
+			continue
+		}
+		if val.IsRetiring {
+			retiredSet[val.Pubkey] = struct{}{}
+		} else {
+			delete(uselessValMap, val.Address)
+		}
+	}
+	if len(retiredSet) == 0 {
+		return
+	}
+	newList := make([]*types.JailedValidator, 0, len(list.Jailed))
+	for _, j := range list.Jailed {
+		if _, ok := retiredSet[j.Pubkey]; !ok {
+			newList = append(newList, j)
+		}
+	}
+	list.Jailed = newList
+	SaveJailedValidatorList(ctx, list)
+}
+
+// a jailed validator releases itself after ReleaseEpochNum, then it can get elected at the next epoch
+func unjail(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.IsRetiring {
+		outData = []byte(ValidatorInRetiring.Error())
+		return
+	}
+	list := LoadJailedValidatorList(ctx)
+	idx := -1
+	for i, j := range list.Jailed {
+		if j.Pubkey == val.Pubkey {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		outData = []byte(ValidatorNotJailed.Error())
+		return
+	}
+	if info.CurrEpochNum < list.Jailed[idx].ReleaseEpochNum {
+		outData = []byte(ValidatorStillJailed.Error())
+		return
+	}
+	list.Jailed = append(list.Jailed[:idx], list.Jailed[idx+1:]...)
+	SaveJailedValidatorList(ctx, list)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildUnjailedEvmLog(val.Address))
+	}
+
+	status = StatusSuccess
+	return
+}
@@ -0,0 +1,138 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+// make the validator miss all the signatures in an online window which ends at current height
+func setupOfflineValidator(ctx *types.Context, pubkey [32]byte) {
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	SaveOnlineInfo(ctx, stakingtypes.ValidatorOnlineInfos{
+		StartHeight: ctx.Height - param.OnlineWindowSize,
+		OnlineInfos: []*stakingtypes.OnlineInfo{{ValidatorConsensusAddress: consAddr}},
+	})
+}
+
+func TestJailAndUnjail(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetStakingForkBlock(0) // the online infos are handled after staking fork
+	ctx.SetCurrentHeight(param.JailingForkHeight)
+
+	setupOfflineValidator(ctx, pubkey)
+	_, newValidators, _, logs := SlashAndReward(ctx, nil, nil, [20]byte{}, [20]byte{}, nil, nil)
+	require.Equal(t, 0, len(newValidators))
+	info := LoadStakingInfo(ctx)
+	require.False(t, info.Validators[0].IsRetiring)
+	require.Equal(t, int64(0), info.Validators[0].VotingPower)
+	require.Equal(t, bch(3).Bytes32(), info.Validators[0].StakedCoins) // a small downtime slash
+	list := LoadJailedValidatorList(ctx)
+	require.Equal(t, 1, len(list.Jailed))
+	require.Equal(t, pubkey, list.Jailed[0].Pubkey)
+	require.Equal(t, int64(1), list.Jailed[0].JailedEpochNum)
+	require.Equal(t, 1+param.JailEpochCount, list.Jailed[0].ReleaseEpochNum)
+	require.Equal(t, 2, len(logs))
+	require.Equal(t, common.Hash(HashOfEventSlashed), logs[0].Topics[0])
+	require.Equal(t, common.Hash(HashOfEventJailed), logs[1].Topics[0])
+	require.Equal(t, common.BytesToHash(validatorAddr[:]), logs[1].Topics[1])
+
+	// a jailed validator is not useless
+	clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, 1, len(info.Validators))
+
+	status, _, outData := execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackUnjail())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, ValidatorStillJailed.Error(), outData)
+
+	info.CurrEpochNum = 1 + param.JailEpochCount
+	SaveStakingInfo(ctx, info)
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{}, validatorAddr, uint256.NewInt(0), PackUnjail())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventUnjailed), logs[0].Topics[0])
+	require.Equal(t, 0, len(LoadJailedValidatorList(ctx).Jailed))
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, SlotJailedValidators))
+
+	status, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackUnjail())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, ValidatorNotJailed.Error(), outData)
+}
+
+func TestRetireWhenJailed(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetCurrentHeight(param.JailingForkHeight)
+
+	info := LoadStakingInfo(ctx)
+	jailValidators(ctx, info.CurrEpochNum, info.Validators)
+	info.Validators[0].VotingPower = 0
+	SaveStakingInfo(ctx, info)
+
+	// nominations to jailed validators are ignored
+	epoch := &stakingtypes.Epoch{Nominations: []*stakingtypes.Nomination{{Pubkey: pubkey, NominatedCount: 100}}}
+	jailedList := LoadJailedValidatorList(ctx)
+	_, pubkey2power := getPubkey2Power(info, epoch, nil, jailedList.GetMapByPubkey(), param.MaxActiveValidatorCount, log.NewNopLogger())
+	require.Equal(t, 0, len(pubkey2power))
+
+	status, _, _ := execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackRetire())
+	require.Equal(t, StatusSuccess, status)
+	status, _, outData := execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackUnjail())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, ValidatorInRetiring.Error(), outData)
+
+	info = LoadStakingInfo(ctx)
+	clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, 0, len(info.Validators))
+	require.Equal(t, 0, len(LoadJailedValidatorList(ctx).Jailed))
+}
+
+func TestClearUnelectedWhenJailed(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	ctx := setupDelegationCtx(pubkey, common.Address{0xad, 0x01}, common.Address{0xde, 0x01})
+	ctx.SetCurrentHeight(param.JailingForkHeight)
+
+	info := LoadStakingInfo(ctx)
+	jailValidators(ctx, info.CurrEpochNum, info.Validators)
+	info.Validators[0].VotingPower = 0
+	unelected := &stakingtypes.Validator{
+		Address:  common.Address{0xad, 0x02},
+		Pubkey:   [32]byte{0x02},
+		RewardTo: common.Address{0xad, 0x02},
+	}
+	info.Validators = append(info.Validators, unelected)
+	SaveStakingInfo(ctx, info)
+
+	// only the jailed validator is kept, the unelected one is still cleared
+	clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, 1, len(info.Validators))
+	require.Equal(t, pubkey, info.Validators[0].Pubkey)
+	require.Equal(t, 1, len(LoadJailedValidatorList(ctx).Jailed))
+}
+
+func TestDuplicateSigTombstone(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	ctx := setupDelegationCtx(pubkey, common.Address{0xad, 0x01}, common.Address{0xde, 0x01})
+	ctx.SetCurrentHeight(param.JailingForkHeight)
+
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	SlashAndReward(ctx, [][20]byte{consAddr}, nil, [20]byte{}, [20]byte{}, nil, nil)
+	info := LoadStakingInfo(ctx)
+	require.True(t, info.Validators[0].IsRetiring)
+	require.Equal(t, int64(0), info.Validators[0].VotingPower)
+	require.Equal(t, 0, len(LoadJailedValidatorList(ctx).Jailed))
+}
@@ -0,0 +1,252 @@
+package staking
+
+import (
+	"crypto/sha256"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------selector------*/
+	/*interface Staking {
+		// following methods are enabled after KeyRotationForkHeight
+		//3dfad0da
+		function rotateConsensusKey(bytes32 newPubkey) external;
+	}*/
+	SelectorRotateConsensusKey = [4]byte{0x3d, 0xfa, 0xd0, 0xda}
+
+	consensusKeyAliasSlotHashPrefix = [4]byte{'c', 'k', 'e', 'y'}
+	rotatedConsAddrSlotHashPrefix   = [4]byte{'c', 'k', 'a', 'd'}
+)
+
+func IsKeyRotationFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.KeyRotationForkHeight
+}
+
+func getSlotForConsensusKeyAlias(pubkey [32]byte) string {
+	key := sha256.Sum256(append(consensusKeyAliasSlotHashPrefix[:], pubkey[:]...))
+	return string(key[:])
+}
+
+func getSlotForRotatedConsAddr(consAddr [20]byte) string {
+	key := sha256.Sum256(append(rotatedConsAddrSlotHashPrefix[:], consAddr[:]...))
+	return string(key[:])
+}
+
+// Returns the pubkey which a validator had before its first rotation, if 'pubkey' has ever been
+// rotated from or to. Otherwise 'pubkey' itself is returned.
+func GetDelegationPubkey(ctx *mevmtypes.Context, pubkey [32]byte) [32]byte {
+	if !IsKeyRotationFork(ctx) {
+		return pubkey
+	}
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForConsensusKeyAlias(pubkey))
+	if len(bz) == 0 {
+		return pubkey
+	}
+	var origPubkey [32]byte
+	copy(origPubkey[:], bz)
+	return origPubkey
+}
+
+func LoadConsensusKeyRotationList(ctx *mevmtypes.Context) (list types.ConsensusKeyRotationList) {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotConsensusKeyRotations)
+	if len(bz) == 0 {
+		return
+	}
+	_, err := list.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// An empty list is deleted
+func SaveConsensusKeyRotationList(ctx *mevmtypes.Context, list types.ConsensusKeyRotationList) {
+	if len(list.Rotations) == 0 {
+		ctx.DeleteStorageAt(StakingContractSequence, SlotConsensusKeyRotations)
+		return
+	}
+	bz, err := list.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, SlotConsensusKeyRotations, bz)
+}
+
+// Nominations and votes for a rotated-from pubkey are counted for the validator's current pubkey, because
+// the miners and the voters may keep using the old one for several epochs after the rotation.
+func resolveRotatedPubkeys(ctx *mevmtypes.Context, info types.StakingInfo, nominations []*types.Nomination,
+	posVotes map[[32]byte]int64) ([]*types.Nomination, map[[32]byte]int64) {
+	currPubkeyByOrig := make(map[[32]byte][32]byte, len(info.Validators))
+	for _, val := range info.Validators {
+		currPubkeyByOrig[GetDelegationPubkey(ctx, val.Pubkey)] = val.Pubkey
+	}
+	resolve := func(pubkey [32]byte) [32]byte {
+		if currPubkey, ok := currPubkeyByOrig[GetDelegationPubkey(ctx, pubkey)]; ok {
+			return currPubkey
+		}
+		return pubkey
+	}
+	// the nominations in the epoch are not modified, and the ones resolved to the same pubkey are merged
+	resolvedNominations := make([]*types.Nomination, 0, len(nominations))
+	nominationMap := make(map[[32]byte]*types.Nomination, len(nominations))
+	for _, n := range nominations {
+		pubkey := resolve(n.Pubkey)
+		if resolved, ok := nominationMap[pubkey]; ok {
+			resolved.NominatedCount += n.NominatedCount
+			continue
+		}
+		resolved := &types.Nomination{Pubkey: pubkey, NominatedCount: n.NominatedCount}
+		nominationMap[pubkey] = resolved
+		resolvedNominations = append(resolvedNominations, resolved)
+	}
+	var resolvedVotes map[[32]byte]int64
+	if posVotes != nil {
+		resolvedVotes = make(map[[32]byte]int64, len(posVotes))
+		for pubkey, coindays := range posVotes {
+			resolvedVotes[resolve(pubkey)] += coindays
+		}
+	}
+	return resolvedNominations, resolvedVotes
+}
+
+// Returns the current pubkey of the validator, active or in unbonding, which used to sign with the
+// consensus address before a rotation, so that the evidences signed with the old key can be handled.
+func getPubkeyByRotatedConsAddr(ctx *mevmtypes.Context, info *types.StakingInfo, consAddr [20]byte) ([32]byte, bool) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForRotatedConsAddr(consAddr))
+	if len(bz) == 0 {
+		return [32]byte{}, false
+	}
+	var oldPubkey [32]byte
+	copy(oldPubkey[:], bz)
+	origPubkey := GetDelegationPubkey(ctx, oldPubkey)
+	for _, val := range info.Validators {
+		if GetDelegationPubkey(ctx, val.Pubkey) == origPubkey {
+			return val.Pubkey, true
+		}
+	}
+	for _, u := range LoadValidatorUnbondingList(ctx).Unbondings {
+		if GetDelegationPubkey(ctx, u.Pubkey) == origPubkey {
+			return u.Pubkey, true
+		}
+	}
+	return [32]byte{}, false
+}
+
+// A pubkey is reserved if it has ever been rotated from or to, or is waiting for a rotation, or belongs
+// to a validator in unbonding. Such a pubkey cannot be used by a new validator or a new rotation.
+func isPubkeyReserved(ctx *mevmtypes.Context, pubkey [32]byte) bool {
+	if len(ctx.GetStorageAt(StakingContractSequence, getSlotForConsensusKeyAlias(pubkey))) != 0 {
+		return true
+	}
+	for _, r := range LoadConsensusKeyRotationList(ctx).Rotations {
+		if r.NewPubkey == pubkey {
+			return true
+		}
+	}
+	for _, u := range LoadValidatorUnbondingList(ctx).Unbondings {
+		if u.Pubkey == pubkey {
+			return true
+		}
+	}
+	return false
+}
+
+// a validator requests to use a new consensus pubkey since the next epoch, replacing its former request if any
+func rotateConsensusKey(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var newPubkey [32]byte
+	copy(newPubkey[:], callData)
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.IsRetiring {
+		outData = []byte(ValidatorInRetiring.Error())
+		return
+	}
+	if info.GetValidatorByPubkey(newPubkey) != nil || isPubkeyReserved(ctx, newPubkey) {
+		outData = []byte(types.ValidatorPubkeyAlreadyExists.Error())
+		return
+	}
+	list := LoadConsensusKeyRotationList(ctx)
+	found := false
+	for _, r := range list.Rotations {
+		if r.Address == val.Address {
+			r.NewPubkey = newPubkey
+			found = true
+			break
+		}
+	}
+	if !found {
+		list.Rotations = append(list.Rotations, &types.ConsensusKeyRotation{
+			Address:   val.Address,
+			OldPubkey: val.Pubkey,
+			NewPubkey: newPubkey,
+		})
+	}
+	SaveConsensusKeyRotationList(ctx, list)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildConsensusKeyRotationRequestedEvmLog(val.Address, val.Pubkey, newPubkey))
+	}
+
+	status = StatusSuccess
+	return
+}
+
+// Change the validators' consensus pubkeys as requested, when a valid epoch switches. The entries in
+// pubkey2power and the jailed list are moved to the new pubkeys, while the delegation pools stay with
+// the original pubkeys, which can be found by GetDelegationPubkey. The old consensus addresses are
+// recorded to handle the evidences signed with the old pubkeys.
+func applyConsensusKeyRotations(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey2power map[[32]byte]int64) (logs []mevmtypes.EvmLog) {
+	list := LoadConsensusKeyRotationList(ctx)
+	if len(list.Rotations) == 0 {
+		return
+	}
+	jailedList := LoadJailedValidatorList(ctx)
+	jailedMap := jailedList.GetMapByPubkey()
+	jailedListChanged := false
+	for _, r := range list.Rotations {
+		val := info.GetValidatorByAddr(r.Address)
+		if val == nil || val.IsRetiring || val.Pubkey != r.OldPubkey {
+			continue // the validator retired after the request
+		}
+		origPubkey := GetDelegationPubkey(ctx, r.OldPubkey)
+		ctx.SetStorageAt(StakingContractSequence, getSlotForConsensusKeyAlias(r.OldPubkey), origPubkey[:])
+		ctx.SetStorageAt(StakingContractSequence, getSlotForConsensusKeyAlias(r.NewPubkey), origPubkey[:])
+		var oldConsAddr [20]byte
+		copy(oldConsAddr[:], ed25519.PubKey(r.OldPubkey[:]).Address().Bytes())
+		ctx.SetStorageAt(StakingContractSequence, getSlotForRotatedConsAddr(oldConsAddr), r.OldPubkey[:])
+		val.Pubkey = r.NewPubkey
+		if power, ok := pubkey2power[r.OldPubkey]; ok {
+			delete(pubkey2power, r.OldPubkey)
+			pubkey2power[r.NewPubkey] = power
+		}
+		if j, ok := jailedMap[r.OldPubkey]; ok {
+			j.Pubkey = r.NewPubkey
+			jailedListChanged = true
+		}
+		if IsStakingEventsFork(ctx) {
+			logs = append(logs, buildConsensusKeyRotatedEvmLog(val.Address, r.OldPubkey, r.NewPubkey))
+		}
+	}
+	if jailedListChanged {
+		SaveJailedValidatorList(ctx, jailedList)
+	}
+	ctx.DeleteStorageAt(StakingContractSequence, SlotConsensusKeyRotations)
+	return
+}
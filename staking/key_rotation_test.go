@@ -0,0 +1,113 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestRotateConsensusKey(t *testing.T) {
+	oldPubkey := [32]byte{0x01}
+	newPubkey := [32]byte{0x02}
+	validatorAddr := common.Address{0xad, 0x01}
+	delegator := common.Address{0xde, 0x01}
+	ctx := setupDelegationCtx(oldPubkey, validatorAddr, delegator)
+
+	ctx.SetCurrentHeight(param.KeyRotationForkHeight - 1)
+	status, _, outData := execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackRotateConsensusKey(newPubkey))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, InvalidSelector.Error(), outData)
+
+	ctx.SetCurrentHeight(param.KeyRotationForkHeight)
+	status, _, _ = execStakingTx(ctx, nil, delegator, bch(4), PackDelegate(oldPubkey))
+	require.Equal(t, StatusSuccess, status)
+	_, _, outData = execStakingTx(ctx, nil, delegator, uint256.NewInt(0), PackRotateConsensusKey(newPubkey))
+	require.Equal(t, NoSuchValidator.Error(), outData)
+	_, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackRotateConsensusKey(oldPubkey))
+	require.Equal(t, stakingtypes.ValidatorPubkeyAlreadyExists.Error(), outData)
+	status, logs, _ := execStakingTx(ctx, &types.BlockInfo{}, validatorAddr, uint256.NewInt(0), PackRotateConsensusKey(newPubkey))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventConsensusKeyRotationRequested), logs[0].Topics[0])
+
+	// the pending new pubkey cannot be used by others
+	other := common.Address{0xad, 0x02}
+	stakedCoins := uint256.NewInt(0).AddUint64(InitialStakingAmountAfterStakingFork, 1)
+	_, _, outData = execStakingTx(ctx, nil, other, stakedCoins, PackCreateValidator(other, [32]byte{}, newPubkey))
+	require.Equal(t, stakingtypes.ValidatorPubkeyAlreadyExists.Error(), outData)
+
+	// the rotation takes effect when a valid epoch switches
+	info := LoadStakingInfo(ctx)
+	pubkey2power := map[[32]byte]int64{oldPubkey: 1}
+	logs = applyConsensusKeyRotations(ctx, &info, pubkey2power)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventConsensusKeyRotated), logs[0].Topics[0])
+	require.Equal(t, newPubkey, info.Validators[0].Pubkey)
+	require.Equal(t, map[[32]byte]int64{newPubkey: 1}, pubkey2power)
+	require.Equal(t, 0, len(LoadConsensusKeyRotationList(ctx).Rotations))
+	SaveStakingInfo(ctx, info)
+
+	// the delegations stay with the original pubkey, and can be found with both pubkeys
+	require.Equal(t, oldPubkey, GetDelegationPubkey(ctx, newPubkey))
+	require.Equal(t, bch(4).Bytes32(), LoadDelegationPool(ctx, newPubkey).TotalDelegated)
+	require.Equal(t, bch(4).Bytes32(), LoadDelegation(ctx, oldPubkey, delegator).Shares)
+	status, _, _ = execStakingTx(ctx, nil, delegator, bch(1), PackDelegate(newPubkey))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, bch(5).Bytes32(), LoadDelegation(ctx, newPubkey, delegator).Shares)
+
+	// the old pubkey cannot be reused
+	_, _, outData = execStakingTx(ctx, nil, other, stakedCoins, PackCreateValidator(other, [32]byte{}, oldPubkey))
+	require.Equal(t, stakingtypes.ValidatorPubkeyAlreadyExists.Error(), outData)
+}
+
+func TestOldPubkeyAfterRotation(t *testing.T) {
+	oldPubkey := [32]byte{0x01}
+	newPubkey := [32]byte{0x02}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(oldPubkey, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetStakingForkBlock(0)
+	ctx.SetCurrentHeight(param.KeyRotationForkHeight)
+	acc := types.ZeroAccountInfo()
+	acc.UpdateBalance(bch(100))
+	ctx.SetAccount(validatorAddr, acc)
+	status, _, _ := execStakingTx(ctx, nil, validatorAddr, bch(100), PackIncreaseStake())
+	require.Equal(t, StatusSuccess, status)
+	status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackRotateConsensusKey(newPubkey))
+	require.Equal(t, StatusSuccess, status)
+
+	// the rotation takes effect at the first epoch, and the miners keep nominating the old pubkey after it
+	for i := 0; i < 2; i++ {
+		epoch := &stakingtypes.Epoch{Nominations: []*stakingtypes.Nomination{
+			{Pubkey: oldPubkey, NominatedCount: param.StakingNumBlocksInEpoch},
+		}}
+		activeValidators, isValid, _ := SwitchEpoch(ctx, epoch, nil, param.StakingNumBlocksInEpoch, log.NewNopLogger())
+		require.True(t, isValid)
+		require.Equal(t, 1, len(activeValidators))
+		require.Equal(t, newPubkey, activeValidators[0].Pubkey)
+		require.Equal(t, oldPubkey, epoch.Nominations[0].Pubkey)
+	}
+
+	// the nominations for both pubkeys are merged
+	nominations, _ := resolveRotatedPubkeys(ctx, LoadStakingInfo(ctx), []*stakingtypes.Nomination{
+		{Pubkey: oldPubkey, NominatedCount: 1}, {Pubkey: newPubkey, NominatedCount: 2}, {Pubkey: [32]byte{0x03}, NominatedCount: 3},
+	}, nil)
+	require.Equal(t, []*stakingtypes.Nomination{
+		{Pubkey: newPubkey, NominatedCount: 3}, {Pubkey: [32]byte{0x03}, NominatedCount: 3},
+	}, nominations)
+
+	// the evidences signed with the old pubkey slash the validator
+	var oldConsAddr [20]byte
+	copy(oldConsAddr[:], ed25519.PubKey(oldPubkey[:]).Address().Bytes())
+	SlashAndReward(ctx, [][20]byte{oldConsAddr}, nil, [20]byte{}, [20]byte{}, nil, nil)
+	info := LoadStakingInfo(ctx)
+	require.True(t, uint256.NewInt(0).SetBytes32(info.Validators[0].StakedCoins[:]).Lt(bch(104)))
+	require.Equal(t, 1, len(LoadSlashHistory(ctx).Records))
+}
@@ -0,0 +1,349 @@
+package staking
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------selector------*/
+	/*interface Staking {
+		// following methods are enabled after ParamGovernanceForkHeight
+		//90132196
+		function proposeParamChange(uint256 paramId, uint256 value, uint256 activationHeight) external;
+		//525a0505
+		function voteParamChange(bool approve) external;
+		//0e1bd007
+		function executeParamChange() external;
+		//99f65122
+		function getParam(uint256 paramId) external view returns (uint256 value, uint256 newValue, uint256 activationHeight);
+	}*/
+	SelectorProposeParamChange = [4]byte{0x90, 0x13, 0x21, 0x96}
+	SelectorVoteParamChange    = [4]byte{0x52, 0x5a, 0x05, 0x05}
+	SelectorExecuteParamChange = [4]byte{0x0e, 0x1b, 0xd0, 0x07}
+	SelectorGetParam           = [4]byte{0x99, 0xf6, 0x51, 0x22}
+
+	govParamSlotHashPrefix = [4]byte{'g', 'p', 'a', 'r'}
+
+	// a change must be activated so many blocks (about 2 days) after it is proposed
+	ParamChangeMinActivationDelay int64 = 2 * 60 * 60 * 24 / 6
+
+	/*------error info------*/
+	UnknownParam             = errors.New("unknown consensus parameter")
+	ParamValueOutOfRange     = errors.New("parameter value out of range")
+	ActivationHeightTooEarly = errors.New("activation height too early")
+)
+
+// the IDs of the consensus parameters which can be changed by governance
+const (
+	GovParamMaxActiveValidatorCount        uint64 = 1
+	GovParamOnlineWindowSize               uint64 = 2
+	GovParamMinOnlineSignatures            uint64 = 3
+	GovParamNotOnlineSlashAmountDivisor    uint64 = 4
+	GovParamDuplicateSigSlashAmountDivisor uint64 = 5
+	GovParamDowntimeSlashAmountDivisor     uint64 = 6
+	GovParamMaxTxGasLimit                  uint64 = 7
+	GovParamLightClientSlashAmountDivisor  uint64 = 8 // since SlashHistoryForkHeight
+)
+
+type govParamSpec struct {
+	defaultValue uint64 // the constant in package param, used when governance has never changed it
+	minValue     uint64
+	maxValue     uint64
+}
+
+// the whitelist of the consensus parameters which can be changed by governance
+var govParamSpecs = map[uint64]govParamSpec{
+	GovParamMaxActiveValidatorCount:        {uint64(param.MaxActiveValidatorCount), 4, 200},
+	GovParamOnlineWindowSize:               {uint64(param.OnlineWindowSize), 100, 10000},
+	GovParamMinOnlineSignatures:            {uint64(param.MinOnlineSignatures), 1, 10000},
+	GovParamNotOnlineSlashAmountDivisor:    {param.NotOnlineSlashAmountDivisor, 1, 1000},
+	GovParamDuplicateSigSlashAmountDivisor: {param.DuplicateSigSlashAMountDivisor, 1, 1000},
+	GovParamDowntimeSlashAmountDivisor:     {param.DowntimeSlashAmountDivisor, 1, 1000},
+	GovParamMaxTxGasLimit:                  {param.MaxTxGasLimit, 1_000_000, uint64(param.BlockMaxGas)},
+	GovParamLightClientSlashAmountDivisor:  {param.LightClientSlashAmountDivisor, 1, 1000},
+}
+
+func IsParamGovernanceFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.ParamGovernanceForkHeight
+}
+
+func getSlotForGovParam(id uint64) string {
+	var buf [12]byte
+	copy(buf[:4], govParamSlotHashPrefix[:])
+	binary.BigEndian.PutUint64(buf[4:], id)
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+// Returns the default value in package param if the parameter has never been changed by governance
+func LoadGovParam(ctx *mevmtypes.Context, id uint64) (gp types.GovParam) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForGovParam(id))
+	if len(bz) == 0 {
+		return types.GovParam{ID: id, Value: govParamSpecs[id].defaultValue}
+	}
+	_, err := gp.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func SaveGovParam(ctx *mevmtypes.Context, gp types.GovParam) {
+	bz, err := gp.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForGovParam(gp.ID), bz)
+}
+
+// the parameter's value at 'height', which is NewValue once the scheduled change is activated
+func govParamValueAt(gp types.GovParam, height int64) uint64 {
+	if gp.ActivationHeight != 0 && height >= gp.ActivationHeight {
+		return gp.NewValue
+	}
+	return gp.Value
+}
+
+// Get the value of a whitelisted consensus parameter at current height. Before ParamGovernanceForkHeight,
+// it is always the constant in package param.
+func GetGovParam(ctx *mevmtypes.Context, id uint64) uint64 {
+	if !IsParamGovernanceFork(ctx) {
+		return govParamSpecs[id].defaultValue
+	}
+	return govParamValueAt(LoadGovParam(ctx, id), ctx.Height)
+}
+
+func LoadParamProposal(ctx *mevmtypes.Context) (p types.ParamProposal, ok bool) {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotParamProposal)
+	if len(bz) == 0 {
+		return
+	}
+	_, err := p.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	ok = true
+	return
+}
+
+func SaveParamProposal(ctx *mevmtypes.Context, p types.ParamProposal) {
+	bz, err := p.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, SlotParamProposal, bz)
+}
+
+// Check whether 'value' is a valid value of the parameter since 'activationHeight'
+func checkParamValue(ctx *mevmtypes.Context, id, value uint64, activationHeight int64) error {
+	spec, ok := govParamSpecs[id]
+	if !ok || (id == GovParamLightClientSlashAmountDivisor && !IsSlashHistoryFork(ctx)) {
+		return UnknownParam
+	}
+	if value < spec.minValue || value > spec.maxValue {
+		return ParamValueOutOfRange
+	}
+	// a validator cannot sign more blocks than the online window has
+	switch id {
+	case GovParamOnlineWindowSize:
+		if value < govParamValueAt(LoadGovParam(ctx, GovParamMinOnlineSignatures), activationHeight) {
+			return ParamValueOutOfRange
+		}
+	case GovParamMinOnlineSignatures:
+		if value > govParamValueAt(LoadGovParam(ctx, GovParamOnlineWindowSize), activationHeight) {
+			return ParamValueOutOfRange
+		}
+	}
+	return nil
+}
+
+// an active validator proposes to change a consensus parameter since activationHeight
+func proposeParamChange(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfMinGasPriceOp
+
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.VotingPower == 0 {
+		outData = []byte(ValidatorNotActive.Error())
+		return
+	}
+	if _, ok := LoadParamProposal(ctx); ok {
+		outData = []byte(StillInProposal.Error())
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32*3 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	id := uint256.NewInt(0).SetBytes32(callData[:32])
+	value := uint256.NewInt(0).SetBytes32(callData[32:64])
+	activationHeight := uint256.NewInt(0).SetBytes32(callData[64:])
+	if !id.IsUint64() || !value.IsUint64() || !activationHeight.IsUint64() || activationHeight.Uint64() > math.MaxInt64 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	if int64(activationHeight.Uint64()) < ctx.Height+ParamChangeMinActivationDelay {
+		outData = []byte(ActivationHeightTooEarly.Error())
+		return
+	}
+	p := types.ParamProposal{
+		Proposer:         tx.From,
+		ParamID:          id.Uint64(),
+		Value:            value.Uint64(),
+		ActivationHeight: int64(activationHeight.Uint64()),
+		Deadline:         now + DefaultProposalDuration,
+		Votes:            []*types.ParamVote{{Voter: tx.From, Approve: true}},
+	}
+	if err := checkParamValue(ctx, p.ParamID, p.Value, p.ActivationHeight); err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	SaveParamProposal(ctx, p)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildParamChangeProposedEvmLog(&p))
+	}
+
+	status = StatusSuccess
+	return
+}
+
+// an active validator votes for or against the ongoing proposal, overwriting its former vote
+func voteParamChange(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfMinGasPriceOp
+
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.VotingPower == 0 {
+		outData = []byte(ValidatorNotActive.Error())
+		return
+	}
+	p, ok := LoadParamProposal(ctx)
+	if !ok {
+		outData = []byte(NotInProposal.Error())
+		return
+	}
+	if now >= p.Deadline {
+		outData = []byte(ProposalHasFinished.Error())
+		return
+	}
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	approve := uint256.NewInt(0).SetBytes32(callData).Uint64() != 0
+	found := false
+	for _, v := range p.Votes {
+		if v.Voter == tx.From {
+			v.Approve = approve
+			found = true
+			break
+		}
+	}
+	if !found {
+		p.Votes = append(p.Votes, &types.ParamVote{Voter: tx.From, Approve: approve})
+	}
+	SaveParamProposal(ctx, p)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildParamChangeVotedEvmLog(tx.From, approve))
+	}
+
+	status = StatusSuccess
+	return
+}
+
+// Anyone can execute the proposal after its deadline. It passes if the active validators approving it
+// have more than 2/3 of the total voting power now, and schedules the change at its activation height.
+func executeParamChange(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfMinGasPriceOp
+
+	p, ok := LoadParamProposal(ctx)
+	if !ok {
+		outData = []byte(NotInProposal.Error())
+		return
+	}
+	if now < p.Deadline {
+		outData = []byte(ProposalNotFinished.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	activeValidators := GetActiveValidators(ctx, info.Validators)
+	var totalPower, approvingPower int64
+	powerMap := make(map[[20]byte]int64, len(activeValidators))
+	for _, val := range activeValidators {
+		totalPower += val.VotingPower
+		powerMap[val.Address] = val.VotingPower
+	}
+	for _, v := range p.Votes {
+		if v.Approve {
+			approvingPower += powerMap[v.Voter]
+		}
+	}
+	passed := approvingPower*3 > totalPower*2 && ctx.Height < p.ActivationHeight &&
+		checkParamValue(ctx, p.ParamID, p.Value, p.ActivationHeight) == nil
+	if passed {
+		gp := LoadGovParam(ctx, p.ParamID)
+		gp.Value = govParamValueAt(gp, ctx.Height)
+		gp.NewValue = p.Value
+		gp.ActivationHeight = p.ActivationHeight
+		SaveGovParam(ctx, gp)
+	}
+	ctx.DeleteStorageAt(StakingContractSequence, SlotParamProposal)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildParamChangeExecutedEvmLog(&p, passed))
+	}
+
+	status = StatusSuccess
+	return
+}
+
+// returns the current value of a parameter, and the scheduled change if it is not activated yet
+func getParam(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfStakingViewOp
+
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	id := uint256.NewInt(0).SetBytes32(callData)
+	if _, ok := govParamSpecs[id.Uint64()]; !id.IsUint64() || !ok {
+		outData = []byte(UnknownParam.Error())
+		return
+	}
+	gp := LoadGovParam(ctx, id.Uint64())
+	value := govParamValueAt(gp, ctx.Height)
+	var newValue, activationHeight uint64
+	if ctx.Height < gp.ActivationHeight {
+		newValue, activationHeight = gp.NewValue, uint64(gp.ActivationHeight)
+	}
+	outData = packReturnData("getParam", new(big.Int).SetUint64(value),
+		new(big.Int).SetUint64(newValue), new(big.Int).SetUint64(activationHeight))
+
+	status = StatusSuccess
+	return
+}
@@ -0,0 +1,128 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestParamGovernance(t *testing.T) {
+	valA := common.Address{0xad, 0x01}
+	valB := common.Address{0xad, 0x02}
+	ctx := setupDelegationCtx([32]byte{0x01}, valA, common.Address{0xde, 0x01})
+	info := stakingtypes.StakingInfo{
+		CurrEpochNum: 1,
+		Validators: []*stakingtypes.Validator{
+			{Address: valA, Pubkey: [32]byte{0x01}, RewardTo: valA, VotingPower: 6, StakedCoins: bch(100).Bytes32()},
+			{Address: valB, Pubkey: [32]byte{0x02}, RewardTo: valB, VotingPower: 1, StakedCoins: bch(100).Bytes32()},
+		},
+	}
+	for i := byte(3); i <= 5; i++ {
+		addr := common.Address{0xad, i}
+		info.Validators = append(info.Validators, &stakingtypes.Validator{
+			Address: addr, Pubkey: [32]byte{i}, RewardTo: addr, VotingPower: 1, StakedCoins: bch(100).Bytes32(),
+		})
+	}
+	SaveStakingInfo(ctx, info)
+	h := param.ParamGovernanceForkHeight
+	activationHeight := h + ParamChangeMinActivationDelay
+	id := big.NewInt(int64(GovParamMaxActiveValidatorCount))
+	propose := PackProposeParamChange(id, big.NewInt(4), big.NewInt(activationHeight))
+
+	ctx.SetCurrentHeight(h - 1)
+	status, _, outData := execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), propose)
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, InvalidSelector.Error(), outData)
+
+	ctx.SetCurrentHeight(h)
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, common.Address{0xde, 0x01}, uint256.NewInt(0), propose)
+	require.Equal(t, NoSuchValidator.Error(), outData)
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), PackProposeParamChange(id, big.NewInt(4), big.NewInt(activationHeight-1)))
+	require.Equal(t, ActivationHeightTooEarly.Error(), outData)
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), PackProposeParamChange(big.NewInt(99), big.NewInt(4), big.NewInt(activationHeight)))
+	require.Equal(t, UnknownParam.Error(), outData)
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), PackProposeParamChange(id, big.NewInt(201), big.NewInt(activationHeight)))
+	require.Equal(t, ParamValueOutOfRange.Error(), outData)
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), PackProposeParamChange(id, big.NewInt(3), big.NewInt(activationHeight)))
+	require.Equal(t, ParamValueOutOfRange.Error(), outData)
+	// MinOnlineSignatures cannot be larger than OnlineWindowSize
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), PackProposeParamChange(big.NewInt(int64(GovParamMinOnlineSignatures)),
+		big.NewInt(param.OnlineWindowSize+1), big.NewInt(activationHeight)))
+	require.Equal(t, ParamValueOutOfRange.Error(), outData)
+
+	// the proposer alone has 60% of the voting power, which is not enough
+	status, logs, _ := execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), propose)
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventParamChangeProposed), logs[0].Topics[0])
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, valB, uint256.NewInt(0), propose)
+	require.Equal(t, StillInProposal.Error(), outData)
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{}, valB, uint256.NewInt(0), PackExecuteParamChange())
+	require.Equal(t, ProposalNotFinished.Error(), outData)
+	now := int64(DefaultProposalDuration)
+	_, _, outData = execStakingTx(ctx, &types.BlockInfo{Timestamp: now}, valB, uint256.NewInt(0), PackVoteParamChange(true))
+	require.Equal(t, ProposalHasFinished.Error(), outData)
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{Timestamp: now}, valB, uint256.NewInt(0), PackExecuteParamChange())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventParamChangeExecuted), logs[0].Topics[0])
+	require.Equal(t, []interface{}{big.NewInt(4), big.NewInt(activationHeight), false},
+		unpackEventData(t, "ParamChangeExecuted", logs[0].Data))
+	_, ok := LoadParamProposal(ctx)
+	require.False(t, ok)
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, getSlotForGovParam(GovParamMaxActiveValidatorCount)))
+
+	// passes with 70% of the voting power
+	execStakingTx(ctx, &types.BlockInfo{}, valA, uint256.NewInt(0), propose)
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{}, valB, uint256.NewInt(0), PackVoteParamChange(true))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventParamChangeVoted), logs[0].Topics[0])
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{Timestamp: now}, valB, uint256.NewInt(0), PackExecuteParamChange())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, []interface{}{big.NewInt(4), big.NewInt(activationHeight), true},
+		unpackEventData(t, "ParamChangeExecuted", logs[0].Data))
+
+	// the change is scheduled at activationHeight
+	status, _, outData = execStakingTx(ctx, nil, valB, uint256.NewInt(0), PackGetParam(id))
+	require.Equal(t, StatusSuccess, status)
+	value, newValue, height := UnpackGetParamReturnData([]byte(outData))
+	require.Equal(t, big.NewInt(int64(param.MaxActiveValidatorCount)), value)
+	require.Equal(t, big.NewInt(4), newValue)
+	require.Equal(t, big.NewInt(activationHeight), height)
+	info = LoadStakingInfo(ctx)
+	require.Equal(t, 5, len(GetActiveValidators(ctx, info.Validators)))
+	ctx.SetCurrentHeight(activationHeight)
+	require.Equal(t, uint64(4), GetGovParam(ctx, GovParamMaxActiveValidatorCount))
+	require.Equal(t, 4, len(GetActiveValidators(ctx, info.Validators)))
+	_, _, outData = execStakingTx(ctx, nil, valB, uint256.NewInt(0), PackGetParam(id))
+	value, newValue, height = UnpackGetParamReturnData([]byte(outData))
+	require.Equal(t, big.NewInt(4), value)
+	require.Zero(t, newValue.Sign())
+	require.Zero(t, height.Sign())
+}
+
+func TestOnlineWindowShrunkByGovernance(t *testing.T) {
+	ctx := setupDelegationCtx([32]byte{0x01}, common.Address{0xad, 0x01}, common.Address{0xde, 0x01})
+	ctx.SetCurrentHeight(param.ParamGovernanceForkHeight)
+	SaveGovParam(ctx, stakingtypes.GovParam{
+		ID:               GovParamOnlineWindowSize,
+		Value:            uint64(param.OnlineWindowSize),
+		NewValue:         100,
+		ActivationHeight: ctx.Height,
+	})
+	require.True(t, isOnlineWindowEnd(ctx, ctx.Height-100))
+	// the window had passed its new end when the change was activated
+	require.True(t, isOnlineWindowEnd(ctx, ctx.Height-200))
+	require.False(t, isOnlineWindowEnd(ctx, ctx.Height-99))
+
+	ctx.SetCurrentHeight(param.ParamGovernanceForkHeight - 1)
+	require.True(t, isOnlineWindowEnd(ctx, ctx.Height-param.OnlineWindowSize))
+	require.False(t, isOnlineWindowEnd(ctx, ctx.Height-100))
+}
@@ -0,0 +1,160 @@
+package staking
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------selector------*/
+	/*interface Staking {
+		// following methods are enabled after RewardClaimingForkHeight
+		//316d17d5
+		function editValidator(address rewardTo, bytes32 introduction, uint8 rewardMode) external;
+		//c7b8981c
+		function withdrawRewards() external;
+		//b759ad6b
+		function getRewardAccount(address validator) external view returns (uint8 rewardMode, uint accumulated);
+	}*/
+	SelectorEditValidatorWithRewardMode = [4]byte{0x31, 0x6d, 0x17, 0xd5}
+	SelectorWithdrawRewards             = [4]byte{0xc7, 0xb8, 0x98, 0x1c}
+	SelectorGetRewardAccount            = [4]byte{0xb7, 0x59, 0xad, 0x6b}
+
+	rewardAccountSlotHashPrefix = [4]byte{'r', 'w', 'd', 'a'}
+
+	/*------error info------*/
+	InvalidRewardMode   = errors.New("invalid reward mode")
+	NoRewardsToWithdraw = errors.New("no rewards to withdraw")
+)
+
+// the ways to handle a validator's mature rewards
+const (
+	RewardModePayToRewardTo uint8 = 0 // paid to rewardTo at once, the default one
+	RewardModeAccumulate    uint8 = 1 // kept in the staking contract until withdrawRewards() is called
+	RewardModeCompound      uint8 = 2 // added to the validator's staked coins
+)
+
+func IsRewardClaimingFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.RewardClaimingForkHeight
+}
+
+func getSlotForRewardAccount(validator [20]byte) string {
+	key := sha256.Sum256(append(rewardAccountSlotHashPrefix[:], validator[:]...))
+	return string(key[:])
+}
+
+// Returns an account with RewardModePayToRewardTo if the validator has never changed its reward mode
+func LoadRewardAccount(ctx *mevmtypes.Context, validator [20]byte) (acc types.ValidatorRewardAccount) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForRewardAccount(validator))
+	if len(bz) == 0 {
+		return types.ValidatorRewardAccount{Address: validator}
+	}
+	_, err := acc.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// An account with the default reward mode and no accumulated rewards is deleted
+func SaveRewardAccount(ctx *mevmtypes.Context, acc types.ValidatorRewardAccount) {
+	if acc.RewardMode == RewardModePayToRewardTo && uint256.NewInt(0).SetBytes32(acc.Accumulated[:]).IsZero() {
+		ctx.DeleteStorageAt(StakingContractSequence, getSlotForRewardAccount(acc.Address))
+		return
+	}
+	bz, err := acc.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForRewardAccount(acc.Address), bz)
+}
+
+// Keep the validator's mature reward in the staking contract according to its reward mode.
+// Returns false if the reward should be paid to rewardTo.
+func keepRewardInStakingAcc(ctx *mevmtypes.Context, val *types.Validator, reward *uint256.Int) bool {
+	acc := LoadRewardAccount(ctx, val.Address)
+	switch acc.RewardMode {
+	case RewardModeAccumulate:
+		accumulated := uint256.NewInt(0).SetBytes32(acc.Accumulated[:])
+		acc.Accumulated = accumulated.Add(accumulated, reward).Bytes32()
+		SaveRewardAccount(ctx, acc)
+		return true
+	case RewardModeCompound:
+		stakedCoins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+		val.StakedCoins = stakedCoins.Add(stakedCoins, reward).Bytes32()
+		return true
+	default:
+		return false
+	}
+}
+
+// A validator with accumulated rewards is not useless, so remove it from uselessValMap.
+func keepValidatorsWithRewards(ctx *mevmtypes.Context, info *types.StakingInfo, uselessValMap map[[20]byte]struct{}) {
+	for _, val := range info.Validators {
+		if _, ok := uselessValMap[val.Address]; !ok {
+			continue
+		}
+		acc := LoadRewardAccount(ctx, val.Address)
+		if !uint256.NewInt(0).SetBytes32(acc.Accumulated[:]).IsZero() {
+			delete(uselessValMap, val.Address)
+		}
+	}
+}
+
+// a validator withdraws its accumulated rewards to its rewardTo
+func withdrawRewards(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	acc := LoadRewardAccount(ctx, val.Address)
+	accumulated := uint256.NewInt(0).SetBytes32(acc.Accumulated[:])
+	if accumulated.IsZero() {
+		outData = []byte(NoRewardsToWithdraw.Error())
+		return
+	}
+	if err := transferFromStakingAcc(ctx, val.RewardTo, accumulated); err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	acc.Accumulated = [32]byte{}
+	SaveRewardAccount(ctx, acc)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildRewardsWithdrawnEvmLog(val, accumulated))
+	}
+
+	status = StatusSuccess
+	return
+}
+
+func getRewardAccount(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfStakingViewOp
+
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	var addr common.Address
+	addr.SetBytes(callData[12:])
+	acc := LoadRewardAccount(ctx, addr)
+	outData = packReturnData("getRewardAccount", acc.RewardMode, new(big.Int).SetBytes(acc.Accumulated[:]))
+
+	status = StatusSuccess
+	return
+}
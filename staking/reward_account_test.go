@@ -0,0 +1,87 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestRewardModes(t *testing.T) {
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx([32]byte{0x01}, validatorAddr, common.Address{0xde, 0x01})
+	// the sender of a staking tx always has an account, which has paid the gas fee
+	validatorAcc := types.ZeroAccountInfo()
+	validatorAcc.UpdateBalance(bch(1))
+	ctx.SetAccount(validatorAddr, validatorAcc)
+
+	ctx.SetCurrentHeight(param.RewardClaimingForkHeight - 1)
+	status, _, outData := execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0),
+		PackEditValidatorWithRewardMode(common.Address{}, [32]byte{}, RewardModeAccumulate))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, InvalidSelector.Error(), outData)
+
+	ctx.SetCurrentHeight(param.RewardClaimingForkHeight)
+	status, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0),
+		PackEditValidatorWithRewardMode(common.Address{}, [32]byte{}, 3))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, InvalidRewardMode.Error(), outData)
+
+	status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0),
+		PackEditValidatorWithRewardMode(common.Address{}, [32]byte{}, RewardModeAccumulate))
+	require.Equal(t, StatusSuccess, status)
+
+	// the mature reward is accumulated
+	info := LoadStakingInfo(ctx)
+	info.CurrEpochNum = 10
+	info.PendingRewards = []*stakingtypes.PendingReward{
+		{Address: validatorAddr, EpochNum: 1, Amount: uint256.NewInt(100).Bytes32()},
+	}
+	deliverMintRewardInEpoch(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, bch(1), ctx.GetAccount(validatorAddr).Balance())
+	status, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetRewardAccount(validatorAddr))
+	require.Equal(t, StatusSuccess, status)
+	rewardMode, accumulated := UnpackGetRewardAccountReturnData([]byte(outData))
+	require.Equal(t, RewardModeAccumulate, rewardMode)
+	require.Equal(t, big.NewInt(100), accumulated)
+
+	// a validator with accumulated rewards is not useless
+	info.Validators[0].VotingPower = 0
+	clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, 1, len(info.Validators))
+	SaveStakingInfo(ctx, info)
+
+	status, logs, _ := execStakingTx(ctx, &types.BlockInfo{}, validatorAddr, uint256.NewInt(0), PackWithdrawRewards())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint256.NewInt(0).AddUint64(bch(1), 100), ctx.GetAccount(validatorAddr).Balance())
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventRewardsWithdrawn), logs[0].Topics[0])
+	status, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackWithdrawRewards())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, NoRewardsToWithdraw.Error(), outData)
+
+	// the mature reward is compounded into the staked coins
+	status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0),
+		PackEditValidatorWithRewardMode(common.Address{}, [32]byte{}, RewardModeCompound))
+	require.Equal(t, StatusSuccess, status)
+	info = LoadStakingInfo(ctx)
+	info.PendingRewards = []*stakingtypes.PendingReward{
+		{Address: validatorAddr, EpochNum: 1, Amount: uint256.NewInt(50).Bytes32()},
+	}
+	deliverMintRewardInEpoch(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, uint256.NewInt(0).AddUint64(bch(4), 50).Bytes32(), info.Validators[0].StakedCoins)
+	require.Equal(t, uint256.NewInt(0).AddUint64(bch(1), 100), ctx.GetAccount(validatorAddr).Balance())
+
+	// the default mode needs no storage
+	status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0),
+		PackEditValidatorWithRewardMode(common.Address{}, [32]byte{}, RewardModePayToRewardTo))
+	require.Equal(t, StatusSuccess, status)
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, getSlotForRewardAccount(validatorAddr)))
+}
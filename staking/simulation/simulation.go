@@ -0,0 +1,265 @@
+package simulation
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/moeingads/store"
+	"github.com/smartbch/moeingads/store/rabbit"
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+// A Scenario contains the validators at genesis and the epochs to be simulated. It is usually read from
+// a JSON file, in which the coin amounts are integers in wei.
+type Scenario struct {
+	Height     int64        `json:"height"` // the height of the first block, which decides the enabled forks. Zero means the latest rules
+	Validators []*Validator `json:"validators"`
+	Epochs     []*Epoch     `json:"epochs"`
+}
+
+type Validator struct {
+	Address     common.Address `json:"address"`
+	Pubkey      common.Hash    `json:"pubkey"`
+	RewardTo    common.Address `json:"reward_to"`
+	StakedCoins *big.Int       `json:"staked_coins"`
+	VotingPower int64          `json:"voting_power"`
+}
+
+type Nomination struct {
+	Pubkey common.Hash `json:"pubkey"`
+	Count  int64       `json:"count"` // nominated blocks for PoW nominations, or coin-days for PoS votes
+}
+
+// The blocks produced during an epoch, followed by the nominations and votes collected at its end
+type Epoch struct {
+	Blocks      []*Block      `json:"blocks"`
+	Nominations []*Nomination `json:"nominations"` // from the BCH miners
+	PosVotes    []*Nomination `json:"pos_votes"`   // from the xHedge contract
+}
+
+type Block struct {
+	Proposer             common.Hash   `json:"proposer"`
+	Voters               []common.Hash `json:"voters"` // the signers of the last block, which are all the active validators if omitted
+	DuplicateSigners     []common.Hash `json:"duplicate_signers"`
+	LightClientAttackers []common.Hash `json:"light_client_attackers"`
+	Fee                  *big.Int      `json:"fee"`    // the gas fee collected in the last block
+	Repeat               int64         `json:"repeat"` // how many times this block is repeated, at least once
+}
+
+type ValidatorState struct {
+	Address     common.Address `json:"address"`
+	Pubkey      common.Hash    `json:"pubkey"`
+	StakedCoins *big.Int       `json:"staked_coins"`
+	VotingPower int64          `json:"voting_power"`
+	IsRetiring  bool           `json:"is_retiring"`
+}
+
+type Slash struct {
+	Height int64       `json:"height"`
+	Pubkey common.Hash `json:"pubkey"`
+	Amount *big.Int    `json:"amount"`
+}
+
+type Reward struct {
+	Address common.Address `json:"address"`
+	Amount  *big.Int       `json:"amount"`
+}
+
+type EpochResult struct {
+	EpochNum         int64             `json:"epoch_num"`
+	IsValid          bool              `json:"is_valid"` // an invalid epoch keeps the voting powers unchanged
+	Slashes          []*Slash          `json:"slashes"`
+	Rewards          []*Reward         `json:"rewards"` // the pending rewards got during this epoch
+	Validators       []*ValidatorState `json:"validators"`
+	ValidatorUpdates []*ValidatorState `json:"validator_updates"` // sent to tendermint in EndBlock
+}
+
+// the height since which all the staking-related forks are enabled
+func latestForkHeight() (height int64) {
+	for _, h := range []int64{param.DelegationForkHeight, param.StakingViewForkHeight, param.StakingInfoStorageForkHeight,
+		param.ValidatorUnbondingForkHeight, param.StakingEventsForkHeight, param.JailingForkHeight,
+		param.RewardClaimingForkHeight, param.EpochSnapshotForkHeight, param.ParamGovernanceForkHeight,
+		param.KeyRotationForkHeight, param.UptimeHistoryForkHeight, param.SlashHistoryForkHeight,
+		param.StakeAdjustmentForkHeight, param.StakingForkHeight, param.XHedgeForkBlock} {
+		if h > height {
+			height = h
+		}
+	}
+	return
+}
+
+func toValidatorStates(vals []*stakingtypes.Validator) []*ValidatorState {
+	res := make([]*ValidatorState, len(vals))
+	for i, val := range vals {
+		res[i] = &ValidatorState{
+			Address:     val.Address,
+			Pubkey:      val.Pubkey,
+			StakedCoins: uint256.NewInt(0).SetBytes32(val.StakedCoins[:]).ToBig(),
+			VotingPower: val.VotingPower,
+			IsRetiring:  val.IsRetiring,
+		}
+	}
+	return res
+}
+
+func consensusAddress(pubkey common.Hash) (addr [20]byte) {
+	copy(addr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	return
+}
+
+func toNominationMap(nominations []*Nomination) map[[32]byte]int64 {
+	res := make(map[[32]byte]int64, len(nominations))
+	for _, n := range nominations {
+		res[n.Pubkey] += n.Count
+	}
+	return res
+}
+
+// Run the scenario with the real staking code on an in-memory context, the same way as app.Commit does,
+// and return the results of each epoch.
+func Run(scenario *Scenario, logger log.Logger) ([]*EpochResult, error) {
+	if len(scenario.Validators) == 0 {
+		return nil, errors.New("no validators at genesis")
+	}
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := mevmtypes.NewContext(&r, nil)
+	ctx.SetShaGateForkBlock(param.ShaGateForkBlock)
+	ctx.SetStakingForkBlock(param.StakingForkHeight)
+	ctx.SetXHedgeForkBlock(param.XHedgeForkBlock)
+	height := scenario.Height
+	if height == 0 {
+		height = latestForkHeight()
+	}
+	ctx.SetCurrentHeight(height)
+
+	info := stakingtypes.StakingInfo{}
+	totalStaked := uint256.NewInt(0)
+	for _, v := range scenario.Validators {
+		if v.StakedCoins == nil {
+			return nil, errors.New("staked_coins is missing")
+		}
+		coins, overflow := uint256.FromBig(v.StakedCoins)
+		if overflow {
+			return nil, errors.New("staked_coins is too large")
+		}
+		if err := info.AddValidator(v.Address, v.Pubkey, "", coins.Bytes32(), v.RewardTo); err != nil {
+			return nil, err
+		}
+		info.Validators[len(info.Validators)-1].VotingPower = v.VotingPower
+		totalStaked.Add(totalStaked, coins)
+	}
+	stakingAcc := mevmtypes.ZeroAccountInfo()
+	stakingAcc.UpdateBalance(totalStaked)
+	ctx.SetAccount(staking.StakingContractAddress, stakingAcc)
+	staking.SaveStakingInfo(ctx, info)
+
+	results := make([]*EpochResult, 0, len(scenario.Epochs))
+	var lastProposer [20]byte
+	for _, e := range scenario.Epochs {
+		res := &EpochResult{}
+		currValidators := staking.GetActiveValidators(ctx, staking.LoadStakingInfo(ctx).Validators)
+		for _, blk := range e.Blocks {
+			var fee *uint256.Int
+			if blk.Fee != nil {
+				var overflow bool
+				if fee, overflow = uint256.FromBig(blk.Fee); overflow {
+					return nil, errors.New("fee is too large")
+				}
+			}
+			for i := int64(0); i == 0 || i < blk.Repeat; i++ {
+				height++
+				ctx.SetCurrentHeight(height)
+				res.Slashes = append(res.Slashes, simulateBlock(ctx, blk, fee, lastProposer)...)
+				lastProposer = consensusAddress(blk.Proposer)
+			}
+		}
+
+		info = staking.LoadStakingInfo(ctx)
+		for _, rwd := range info.PendingRewards {
+			if rwd.EpochNum == info.CurrEpochNum {
+				res.Rewards = append(res.Rewards, &Reward{
+					Address: rwd.Address,
+					Amount:  uint256.NewInt(0).SetBytes32(rwd.Amount[:]).ToBig(),
+				})
+			}
+		}
+		epoch := &stakingtypes.Epoch{}
+		for _, n := range e.Nominations {
+			epoch.Nominations = append(epoch.Nominations, &stakingtypes.Nomination{Pubkey: n.Pubkey, NominatedCount: n.Count})
+		}
+		posVotes := toNominationMap(e.PosVotes)
+		if staking.IsDelegationFork(ctx) {
+			posVotes = staking.AddDelegatedVotes(ctx, posVotes, param.StakingNumBlocksInEpoch)
+		}
+		newValidators, isValid, _ := staking.SwitchEpoch(ctx, epoch, posVotes, param.StakingNumBlocksInEpoch, logger)
+		if staking.IsValidatorUnbondingFork(ctx) {
+			staking.ReleaseMatureValidatorUnbondings(ctx, epoch.Number)
+		}
+		res.EpochNum = epoch.Number
+		res.IsValid = isValid
+		res.Validators = toValidatorStates(staking.LoadStakingInfo(ctx).Validators)
+		res.ValidatorUpdates = toValidatorStates(stakingtypes.GetUpdateValidatorSet(currValidators, newValidators))
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// Run SlashAndReward for a block and return the slashes found by comparing the staked coins
+func simulateBlock(ctx *mevmtypes.Context, blk *Block, fee *uint256.Int, lastProposer [20]byte) (slashes []*Slash) {
+	info := staking.LoadStakingInfo(ctx)
+	stakedCoinsBefore := make(map[[32]byte][32]byte, len(info.Validators))
+	for _, val := range info.Validators {
+		stakedCoinsBefore[val.Pubkey] = val.StakedCoins
+	}
+	var voters [][]byte
+	if blk.Voters == nil {
+		for _, val := range staking.GetActiveValidators(ctx, info.Validators) {
+			addr := consensusAddress(val.Pubkey)
+			voters = append(voters, addr[:])
+		}
+	} else {
+		for _, pubkey := range blk.Voters {
+			addr := consensusAddress(pubkey)
+			voters = append(voters, addr[:])
+		}
+	}
+	duplicateSigners := make([][20]byte, len(blk.DuplicateSigners))
+	for i, pubkey := range blk.DuplicateSigners {
+		duplicateSigners[i] = consensusAddress(pubkey)
+	}
+	lightClientAttackers := make([][20]byte, len(blk.LightClientAttackers))
+	for i, pubkey := range blk.LightClientAttackers {
+		lightClientAttackers[i] = consensusAddress(pubkey)
+	}
+	var blockReward *uint256.Int
+	if fee != nil {
+		blockReward = fee.Clone() // DistributeFee changes it
+	}
+	staking.SlashAndReward(ctx, duplicateSigners, lightClientAttackers, consensusAddress(blk.Proposer), lastProposer, voters, blockReward)
+
+	for _, val := range staking.LoadStakingInfo(ctx).Validators {
+		before, ok := stakedCoinsBefore[val.Pubkey]
+		if !ok {
+			continue
+		}
+		coinsBefore := uint256.NewInt(0).SetBytes32(before[:])
+		coinsAfter := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+		if coinsAfter.Lt(coinsBefore) {
+			slashes = append(slashes, &Slash{
+				Height: ctx.Height,
+				Pubkey: val.Pubkey,
+				Amount: coinsBefore.Sub(coinsBefore, coinsAfter).ToBig(),
+			})
+		}
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package simulation
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func bch(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestRun(t *testing.T) {
+	pubkeyA := common.Hash{0x01}
+	pubkeyB := common.Hash{0x02}
+	scenario := &Scenario{
+		Validators: []*Validator{
+			{Address: common.Address{0xad, 0x01}, Pubkey: pubkeyA, RewardTo: common.Address{0xad, 0x01}, StakedCoins: bch(10000), VotingPower: 1},
+			{Address: common.Address{0xad, 0x02}, Pubkey: pubkeyB, RewardTo: common.Address{0xad, 0x02}, StakedCoins: bch(10000), VotingPower: 1},
+		},
+		Epochs: []*Epoch{
+			{
+				Blocks: []*Block{
+					{Proposer: pubkeyA, Fee: bch(1), Repeat: 10},
+					{Proposer: pubkeyB, DuplicateSigners: []common.Hash{pubkeyB}},
+				},
+				Nominations: []*Nomination{{Pubkey: pubkeyA, Count: 1000}, {Pubkey: pubkeyB, Count: 500}},
+			},
+			{
+				// not enough nominations
+				Nominations: []*Nomination{{Pubkey: pubkeyA, Count: 10}},
+			},
+		},
+	}
+	results, err := Run(scenario, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(results))
+
+	res := results[0]
+	require.Equal(t, int64(1), res.EpochNum)
+	require.True(t, res.IsValid)
+	require.Equal(t, 1, len(res.Slashes))
+	require.Equal(t, pubkeyB, res.Slashes[0].Pubkey)
+	require.NotEmpty(t, res.Rewards)
+	powers := make(map[common.Hash]int64)
+	for _, val := range res.Validators {
+		powers[val.Pubkey] = val.VotingPower
+	}
+	// the duplicate signer is retired and cannot get voting power, each elected one has the same power
+	require.Equal(t, map[common.Hash]int64{pubkeyA: 1, pubkeyB: 0}, powers)
+	require.Equal(t, 1, len(res.ValidatorUpdates))
+	require.Equal(t, pubkeyB, res.ValidatorUpdates[0].Pubkey)
+	require.Equal(t, int64(0), res.ValidatorUpdates[0].VotingPower)
+
+	res = results[1]
+	require.Equal(t, int64(2), res.EpochNum)
+	require.False(t, res.IsValid)
+	require.Empty(t, res.ValidatorUpdates)
+
+	_, err = Run(&Scenario{}, log.NewNopLogger())
+	require.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package staking
+
+import (
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+func IsSlashHistoryFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.SlashHistoryForkHeight
+}
+
+func LoadSlashHistory(ctx *mevmtypes.Context) (history types.SlashHistory) {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotSlashHistory)
+	if len(bz) == 0 {
+		return
+	}
+	_, err := history.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func SaveSlashHistory(ctx *mevmtypes.Context, history types.SlashHistory) {
+	bz, err := history.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, SlotSlashHistory, bz)
+}
+
+// The validator with 'pubkey' may have retired and be in unbonding
+func getValidatorAddrByPubkey(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte) (addr [20]byte, ok bool) {
+	if val := info.GetValidatorByPubkey(pubkey); val != nil {
+		return val.Address, true
+	}
+	for _, u := range LoadValidatorUnbondingList(ctx).Unbondings {
+		if u.Pubkey == pubkey {
+			return u.Address, true
+		}
+	}
+	return
+}
+
+// only the latest param.SlashHistoryMaxCount records are kept
+func recordSlash(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, reason uint8, burnt *uint256.Int) {
+	addr, ok := getValidatorAddrByPubkey(ctx, info, pubkey)
+	if !ok {
+		return
+	}
+	history := LoadSlashHistory(ctx)
+	history.Records = append(history.Records, &types.SlashRecord{
+		Height:    ctx.Height,
+		Validator: addr,
+		Pubkey:    pubkey,
+		Reason:    reason,
+		Burnt:     burnt.Bytes32(),
+	})
+	if n := len(history.Records) - int(param.SlashHistoryMaxCount); n > 0 {
+		history.Records = history.Records[n:]
+	}
+	SaveSlashHistory(ctx, history)
+}
+
+// Returns the slashes in [fromHeight, toHeight], the oldest first
+func GetSlashRecords(ctx *mevmtypes.Context, fromHeight, toHeight int64) []*types.SlashRecord {
+	records := make([]*types.SlashRecord, 0)
+	for _, r := range LoadSlashHistory(ctx).Records {
+		if r.Height >= fromHeight && r.Height <= toHeight {
+			records = append(records, r)
+		}
+	}
+	return records
+}
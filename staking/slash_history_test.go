@@ -0,0 +1,43 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+func TestLightClientAttackSlash(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+
+	// ignored before the fork
+	ctx.SetCurrentHeight(param.SlashHistoryForkHeight - 1)
+	SlashAndReward(ctx, nil, [][20]byte{consAddr}, [20]byte{}, [20]byte{}, nil, nil)
+	require.Equal(t, bch(4).Bytes32(), LoadStakingInfo(ctx).Validators[0].StakedCoins)
+	require.Equal(t, 0, len(LoadSlashHistory(ctx).Records))
+
+	ctx.SetCurrentHeight(param.SlashHistoryForkHeight)
+	SlashAndReward(ctx, nil, [][20]byte{consAddr}, [20]byte{}, [20]byte{}, nil, nil)
+	info := LoadStakingInfo(ctx)
+	require.True(t, info.Validators[0].IsRetiring)
+	require.Equal(t, int64(0), info.Validators[0].VotingPower)
+	burnt := uint256.NewInt(0).Sub(bch(4), uint256.NewInt(0).SetBytes32(info.Validators[0].StakedCoins[:]))
+	require.False(t, burnt.IsZero())
+
+	records := GetSlashRecords(ctx, 0, ctx.Height)
+	require.Equal(t, 1, len(records))
+	require.Equal(t, ctx.Height, records[0].Height)
+	require.Equal(t, [20]byte(validatorAddr), records[0].Validator)
+	require.Equal(t, pubkey, records[0].Pubkey)
+	require.Equal(t, SlashReasonLightClientAttack, records[0].Reason)
+	require.Equal(t, burnt.Bytes32(), records[0].Burnt)
+	require.Equal(t, 0, len(GetSlashRecords(ctx, 0, ctx.Height-1)))
+}
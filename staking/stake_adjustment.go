@@ -0,0 +1,122 @@
+package staking
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------selector------*/
+	/*interface Staking {
+		// following methods are enabled after StakeAdjustmentForkHeight
+		//d9e257ef
+		function increaseStake() external payable;
+		//e8b96de1
+		function decreaseStake(uint256 amount) external;
+	}*/
+	SelectorIncreaseStake = [4]byte{0xd9, 0xe2, 0x57, 0xef}
+	SelectorDecreaseStake = [4]byte{0xe8, 0xb9, 0x6d, 0xe1}
+
+	/*------error info------*/
+	ZeroStakeAmount            = errors.New("stake amount is zero")
+	StakedCoinsLtMinimumAmount = errors.New("staked coins would be less than the minimum amount")
+)
+
+func IsStakeAdjustmentFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.StakeAdjustmentForkHeight
+}
+
+// a validator stakes more coins, which take effect at once
+func increaseStake(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+
+	amount := uint256.NewInt(0).SetBytes32(tx.Value[:])
+	if amount.IsZero() {
+		outData = []byte(ZeroStakeAmount.Error())
+		return
+	}
+	stakingAcc, info := LoadStakingAccAndInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.IsRetiring {
+		outData = []byte(ValidatorInRetiring.Error())
+		return
+	}
+	stakedCoins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+	stakedCoins.Add(stakedCoins, amount)
+	val.StakedCoins = stakedCoins.Bytes32()
+
+	//Now let's update the states, the readonly states are unchanged because voting powers are unchanged.
+	SaveStakingInfo(ctx, info)
+
+	status, outData = transferStakedCoins(ctx, tx, stakingAcc)
+	if status == StatusSuccess && IsStakingEventsFork(ctx) {
+		logs = append(logs, buildStakeIncreasedEvmLog(val.Address, amount))
+	}
+	return
+}
+
+// A validator withdraws some of its staked coins, leaving at least MinimumStakingAmountAfterStakingFork.
+// The withdrawn coins are unbonding just like the ones of a retired validator, so they can still be slashed
+// until they are paid back to rewardTo after param.ValidatorUnbondingEpochCount epochs.
+func decreaseStake(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed //default status is failed
+	gasUsed = GasOfValidatorOp
+
+	callData := tx.Data[4:]
+	if len(callData) != 32 {
+		outData = []byte(InvalidCallData.Error())
+		return
+	}
+	amount := uint256.NewInt(0).SetBytes(callData)
+	if amount.IsZero() {
+		outData = []byte(ZeroStakeAmount.Error())
+		return
+	}
+	info := LoadStakingInfo(ctx)
+	val := info.GetValidatorByAddr(tx.From)
+	if val == nil {
+		outData = []byte(NoSuchValidator.Error())
+		return
+	}
+	if val.IsRetiring {
+		outData = []byte(ValidatorInRetiring.Error())
+		return
+	}
+	stakedCoins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+	if stakedCoins.Lt(amount) || stakedCoins.Sub(stakedCoins, amount).Lt(MinimumStakingAmountAfterStakingFork) {
+		outData = []byte(StakedCoinsLtMinimumAmount.Error())
+		return
+	}
+	val.StakedCoins = stakedCoins.Bytes32()
+
+	//Now let's update the states, the readonly states are unchanged because voting powers are unchanged.
+	SaveStakingInfo(ctx, info)
+	u := &types.ValidatorUnbonding{
+		Address:        val.Address,
+		Pubkey:         val.Pubkey,
+		RewardTo:       val.RewardTo,
+		Amount:         amount.Bytes32(),
+		StartEpochNum:  info.CurrEpochNum,
+		MatureEpochNum: info.CurrEpochNum + param.ValidatorUnbondingEpochCount,
+	}
+	list := LoadValidatorUnbondingList(ctx)
+	list.Unbondings = append(list.Unbondings, u)
+	SaveValidatorUnbondingList(ctx, list)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildStakeDecreasedEvmLog(val.Address, amount, u.MatureEpochNum))
+	}
+
+	status = StatusSuccess
+	return
+}
@@ -0,0 +1,107 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+func TestIncreaseAndDecreaseStake(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	acc := types.ZeroAccountInfo()
+	acc.UpdateBalance(bch(200))
+	ctx.SetAccount(validatorAddr, acc)
+
+	ctx.SetCurrentHeight(param.StakeAdjustmentForkHeight - 1)
+	status, _, outData := execStakingTx(ctx, nil, validatorAddr, bch(100), PackIncreaseStake())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, InvalidSelector.Error(), outData)
+
+	ctx.SetCurrentHeight(param.StakeAdjustmentForkHeight)
+	_, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackIncreaseStake())
+	require.Equal(t, ZeroStakeAmount.Error(), outData)
+	_, _, outData = execStakingTx(ctx, nil, common.Address{0xde, 0x01}, bch(1), PackIncreaseStake())
+	require.Equal(t, NoSuchValidator.Error(), outData)
+	status, logs, _ := execStakingTx(ctx, &types.BlockInfo{}, validatorAddr, bch(100), PackIncreaseStake())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventStakeIncreased), logs[0].Topics[0])
+	require.Equal(t, bch(104).Bytes32(), LoadStakingInfo(ctx).Validators[0].StakedCoins)
+	require.Equal(t, bch(104), ctx.GetAccount(StakingContractAddress).Balance())
+
+	// at least MinimumStakingAmountAfterStakingFork must be left
+	withdrawable := uint256.NewInt(0).Sub(bch(104), MinimumStakingAmountAfterStakingFork)
+	_, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0),
+		PackDecreaseStake(uint256.NewInt(0).AddUint64(withdrawable, 1).ToBig()))
+	require.Equal(t, StakedCoinsLtMinimumAmount.Error(), outData)
+	_, _, outData = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackDecreaseStake(bch(105).ToBig()))
+	require.Equal(t, StakedCoinsLtMinimumAmount.Error(), outData)
+	status, logs, _ = execStakingTx(ctx, &types.BlockInfo{}, validatorAddr, uint256.NewInt(0), PackDecreaseStake(withdrawable.ToBig()))
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, common.Hash(HashOfEventStakeDecreased), logs[0].Topics[0])
+	require.Equal(t, MinimumStakingAmountAfterStakingFork.Bytes32(), LoadStakingInfo(ctx).Validators[0].StakedCoins)
+
+	// the withdrawn coins are unbonding, and still slashable
+	unbondings := LoadValidatorUnbondingList(ctx).Unbondings
+	require.Equal(t, 1, len(unbondings))
+	require.Equal(t, withdrawable.Bytes32(), unbondings[0].Amount)
+	info := LoadStakingInfo(ctx)
+	totalSlashed := Slash(ctx, &info, pubkey, uint256.NewInt(0).AddUint64(MinimumStakingAmountAfterStakingFork, 1))
+	require.Equal(t, uint256.NewInt(0).AddUint64(MinimumStakingAmountAfterStakingFork, 1), totalSlashed)
+	unbondings = LoadValidatorUnbondingList(ctx).Unbondings
+	require.Equal(t, uint256.NewInt(0).SubUint64(withdrawable, 1).Bytes32(), unbondings[0].Amount)
+
+	// they are paid back to rewardTo when mature
+	ReleaseMatureValidatorUnbondings(ctx, unbondings[0].MatureEpochNum)
+	require.Equal(t, 0, len(LoadValidatorUnbondingList(ctx).Unbondings))
+	require.Equal(t, uint256.NewInt(0).Add(bch(100), uint256.NewInt(0).SubUint64(withdrawable, 1)),
+		ctx.GetAccount(validatorAddr).Balance())
+}
+
+func TestSlashDecreasedStakeByEvidence(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetStakingForkBlock(0)
+	ctx.SetCurrentHeight(param.StakeAdjustmentForkHeight)
+	acc := types.ZeroAccountInfo()
+	acc.UpdateBalance(bch(200))
+	ctx.SetAccount(validatorAddr, acc)
+	status, _, _ := execStakingTx(ctx, nil, validatorAddr, bch(120), PackIncreaseStake())
+	require.Equal(t, StatusSuccess, status)
+	for i := 0; i < 2; i++ {
+		status, _, _ = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackDecreaseStake(bch(10).ToBig()))
+		require.Equal(t, StatusSuccess, status)
+	}
+
+	// after the validator is cleared, its consensus address maps to all the three unbonding entries
+	info := LoadStakingInfo(ctx)
+	info.Validators[0].IsRetiring = true
+	info.Validators[0].VotingPower = 0
+	clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	SaveStakingInfo(ctx, info)
+	require.Equal(t, 3, len(LoadValidatorUnbondingList(ctx).Unbondings))
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	require.Equal(t, 1, len(getUnbondingPubkeyMapByConsAddr(ctx)))
+
+	// one evidence slashes the amount once, from the entries in order
+	slashAmount := uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork,
+		uint256.NewInt(GetGovParam(ctx, GovParamDuplicateSigSlashAmountDivisor)))
+	SlashAndReward(ctx, [][20]byte{consAddr}, nil, [20]byte{}, [20]byte{}, nil, nil)
+	require.Equal(t, uint256.NewInt(0).Sub(bch(124), slashAmount), ctx.GetAccount(StakingContractAddress).Balance())
+	require.Equal(t, slashAmount, loadAllBurnt(ctx))
+	remained := uint256.NewInt(0)
+	for _, u := range LoadValidatorUnbondingList(ctx).Unbondings {
+		remained.Add(remained, uint256.NewInt(0).SetBytes32(u.Amount[:]))
+	}
+	require.Equal(t, uint256.NewInt(0).Sub(bch(124), slashAmount), remained)
+}
@@ -12,6 +12,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/holiman/uint256"
@@ -84,6 +85,18 @@ var (
 	SlotMinGasPriceProposalTarget = strings.Repeat(string([]byte{0}), 31) + string([]byte{4})
 	SlotVoters                    = strings.Repeat(string([]byte{0}), 31) + string([]byte{5})
 	SlotOnlineInfo                = strings.Repeat(string([]byte{0}), 31) + string([]byte{6})
+	SlotStakingInfoIndex          = strings.Repeat(string([]byte{0}), 31) + string([]byte{7})
+	SlotValidatorUnbondings       = strings.Repeat(string([]byte{0}), 31) + string([]byte{8})
+	SlotJailedValidators          = strings.Repeat(string([]byte{0}), 31) + string([]byte{9})
+	SlotParamProposal             = strings.Repeat(string([]byte{0}), 31) + string([]byte{10})
+	SlotConsensusKeyRotations     = strings.Repeat(string([]byte{0}), 31) + string([]byte{11})
+	SlotUptimeWindow              = strings.Repeat(string([]byte{0}), 31) + string([]byte{12})
+	SlotSlashHistory              = strings.Repeat(string([]byte{0}), 31) + string([]byte{13})
+	SlotEpochStartHeight          = strings.Repeat(string([]byte{0}), 31) + string([]byte{14})
+	SlotTotalDelegated            = strings.Repeat(string([]byte{0}), 31) + string([]byte{15})
+	SlotDelegatorUnbondings       = strings.Repeat(string([]byte{0}), 31) + string([]byte{16})
+	SlotUnsettledRewards          = strings.Repeat(string([]byte{0}), 31) + string([]byte{17})
+	SlotPendingSystemLogs         = strings.Repeat(string([]byte{0}), 31) + string([]byte{18})
 
 	// slot in hex
 	SlotMinGasPriceHex = hex.EncodeToString([]byte(SlotMinGasPrice))
@@ -134,7 +147,34 @@ var (
 	ProposalNotFinished               = errors.New("proposal not finished")
 )
 
-var readonlyStakingInfo *types.StakingInfo // for sumVotingPower
+// The states read by Run when other contracts call the staking contract. They are a snapshot taken in Commit,
+// before the block's txs are executed, so the txs, eth_call and CheckTx read the same states on all the nodes.
+type readonlyStates struct {
+	height           int64 // the height of the block whose txs are executed with these states
+	stakingInfo      *types.StakingInfo
+	activeValidators []*types.Validator
+	minGasPrice      uint64
+}
+
+var readonlyStatesValue atomic.Value // *readonlyStates, swapped atomically as Run is called by many goroutines
+
+// UpdateReadonlyStates takes the snapshot read by Run. It is called when the app starts and in Commit, after
+// the staking states of the block are updated.
+func UpdateReadonlyStates(ctx *mevmtypes.Context) {
+	info := LoadStakingInfo(ctx)
+	readonlyStatesValue.Store(&readonlyStates{
+		height:           ctx.Height,
+		stakingInfo:      &info,
+		activeValidators: GetActiveValidators(ctx, info.Validators),
+		minGasPrice:      LoadMinGasPrice(ctx, false),
+	})
+}
+
+// returns nil before the first snapshot is taken
+func loadReadonlyStates() *readonlyStates {
+	states, _ := readonlyStatesValue.Load().(*readonlyStates)
+	return states
+}
 
 type StakingContractExecutor struct {
 	logger log.Logger
@@ -155,8 +195,7 @@ func (_ *StakingContractExecutor) Init(ctx *mevmtypes.Context) {
 		stakingAcc.UpdateSequence(StakingContractSequence)
 		ctx.SetAccount(StakingContractAddress, stakingAcc)
 	}
-	info := LoadStakingInfo(ctx)
-	readonlyStakingInfo = &info
+	UpdateReadonlyStates(ctx)
 }
 
 func (_ *StakingContractExecutor) IsSystemContract(addr common.Address) bool {
@@ -213,18 +252,159 @@ func (s *StakingContractExecutor) Execute(ctx *mevmtypes.Context, currBlock *mev
 		} else {
 			return handleInvalidSelector()
 		}
+	case SelectorGetValidator:
+		if IsStakingViewFork(ctx) {
+			return getValidator(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorGetActiveValidators:
+		if IsStakingViewFork(ctx) {
+			return getActiveValidators(ctx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorGetPendingRewards:
+		if IsStakingViewFork(ctx) {
+			return getPendingRewards(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorGetCurrEpochNum:
+		if IsStakingViewFork(ctx) {
+			return getCurrEpochNum(ctx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorGetMinGasPrice:
+		if IsStakingViewFork(ctx) {
+			return getMinGasPrice(ctx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorDelegate:
+		if IsDelegationFork(ctx) {
+			//function delegate(bytes32 pubkey) external payable;
+			return delegate(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorUndelegate:
+		if IsDelegationFork(ctx) {
+			//function undelegate(bytes32 pubkey, uint256 amount) external;
+			return undelegate(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorWithdrawUnbonded:
+		if IsDelegationFork(ctx) {
+			//function withdrawUnbonded() external;
+			return withdrawUnbonded(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorSetCommissionRate:
+		if IsDelegationFork(ctx) {
+			//function setCommissionRate(uint256 rate) external;
+			return setCommissionRate(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorEditValidatorWithRewardMode:
+		if IsRewardClaimingFork(ctx) {
+			//editValidator(address rewardTo, bytes32 introduction, uint8 rewardMode)
+			return editValidator(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorWithdrawRewards:
+		if IsRewardClaimingFork(ctx) {
+			//function withdrawRewards() external;
+			return withdrawRewards(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorGetRewardAccount:
+		if IsRewardClaimingFork(ctx) {
+			return getRewardAccount(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorUnjail:
+		if IsJailingFork(ctx) {
+			//function unjail() external;
+			return unjail(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorProposeParamChange:
+		if IsParamGovernanceFork(ctx) {
+			//function proposeParamChange(uint256 paramId, uint256 value, uint256 activationHeight) external;
+			return proposeParamChange(ctx, uint64(currBlock.Timestamp), tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorVoteParamChange:
+		if IsParamGovernanceFork(ctx) {
+			//function voteParamChange(bool approve) external;
+			return voteParamChange(ctx, uint64(currBlock.Timestamp), tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorExecuteParamChange:
+		if IsParamGovernanceFork(ctx) {
+			//function executeParamChange() external;
+			return executeParamChange(ctx, uint64(currBlock.Timestamp), tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorGetParam:
+		if IsParamGovernanceFork(ctx) {
+			return getParam(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorRotateConsensusKey:
+		if IsKeyRotationFork(ctx) {
+			//function rotateConsensusKey(bytes32 newPubkey) external;
+			return rotateConsensusKey(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorIncreaseStake:
+		if IsStakeAdjustmentFork(ctx) {
+			//function increaseStake() external payable;
+			return increaseStake(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
+	case SelectorDecreaseStake:
+		// the withdrawn coins are put into the validator unbonding queue, which is only released after its fork
+		if IsStakeAdjustmentFork(ctx) && IsValidatorUnbondingFork(ctx) {
+			//function decreaseStake(uint256 amount) external;
+			return decreaseStake(ctx, tx)
+		} else {
+			return handleInvalidSelector()
+		}
 	default:
 		return handleInvalidSelector()
 	}
 }
 
-// this functions is called when other contract calls sumVotingPower
+// this functions is called when other contract calls sumVotingPower or the view methods
 func (_ *StakingContractExecutor) RequiredGas(input []byte) uint64 {
+	if isReadonlyViewMethod(loadReadonlyStates(), input) {
+		return GasOfStakingViewOp
+	}
 	return uint64(len(input))*SumVotingPowerGasPerByte + SumVotingPowerBaseGas
 }
 
 //   function sumVotingPower(address[] calldata addrList) external override returns (uint summedPower, uint totalPower)
 func (_ *StakingContractExecutor) Run(input []byte) ([]byte, error) {
+	states := loadReadonlyStates()
+	if isReadonlyViewMethod(states, input) {
+		return runViewMethod(states, input)
+	}
 	if len(input) < 4+32*2 || !bytes.Equal(input[:4], SelectorSumVotingPower[:]) {
 		return nil, InvalidArgument
 	}
@@ -238,8 +418,8 @@ func (_ *StakingContractExecutor) Run(input []byte) ([]byte, error) {
 	summedPower := int64(0)
 	totalPower := int64(0)
 	validators := []*types.Validator{}
-	if readonlyStakingInfo != nil {
-		validators = readonlyStakingInfo.Validators
+	if states != nil {
+		validators = states.stakingInfo.Validators
 	}
 	countedAddrs := make(map[[20]byte]struct{}, len(input)/32)
 	for _, val := range validators {
@@ -286,6 +466,10 @@ func createValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int,
 		return
 	}
 
+	if IsKeyRotationFork(ctx) && isPubkeyReserved(ctx, pubkey) {
+		outData = []byte(types.ValidatorPubkeyAlreadyExists.Error())
+		return
+	}
 	stakingAcc, info := LoadStakingAccAndInfo(ctx)
 	err := info.AddValidator(tx.From, pubkey, intro, tx.Value, rewardTo)
 	if err != nil {
@@ -293,14 +477,18 @@ func createValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int,
 		return
 	}
 
-	//Now let's update the states, readonlyStakingInfo is unchanged because voting powers are unchanged.
+	//Now let's update the states, the readonly states are unchanged because voting powers are unchanged.
 	SaveStakingInfo(ctx, info)
 
 	status, outData = transferStakedCoins(ctx, tx, stakingAcc)
+	if status == StatusSuccess && IsStakingEventsFork(ctx) {
+		logs = append(logs, buildValidatorCreatedEvmLog(info.GetValidatorByAddr(tx.From)))
+	}
 	return
 }
 
-// edit a new validator's rewardTo and intro fields (pubkey cannot change), and stake it with some more coins
+// edit a new validator's rewardTo and intro fields (pubkey cannot change), and stake it with some more coins.
+// After RewardClaimingForkHeight, the reward mode can also be changed with an extra argument.
 func editValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
 	status = StatusFailed //default status is failed
 	gasUsed = GasOfValidatorOp
@@ -317,6 +505,21 @@ func editValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, l
 	if ctx.IsXHedgeFork() {
 		intro = strings.TrimRight(string(callData[32:64]), string([]byte{0}))
 	}
+	// Third argument: rewardMode (only the new editValidator has it)
+	hasRewardMode := bytes.Equal(tx.Data[:4], SelectorEditValidatorWithRewardMode[:])
+	var rewardMode uint8
+	if hasRewardMode {
+		if len(callData) < 96 {
+			outData = []byte(InvalidCallData.Error())
+			return
+		}
+		mode := uint256.NewInt(0).SetBytes(callData[64:96])
+		if !mode.IsUint64() || mode.Uint64() > uint64(RewardModeCompound) {
+			outData = []byte(InvalidRewardMode.Error())
+			return
+		}
+		rewardMode = uint8(mode.Uint64())
+	}
 
 	stakingAcc, info := LoadStakingAccAndInfo(ctx)
 
@@ -343,10 +546,18 @@ func editValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, l
 		val.StakedCoins = stakedCoins.Bytes32()
 	}
 
-	//Now let's update the states, readonlyStakingInfo is unchanged because voting powers are unchanged.
+	//Now let's update the states, the readonly states are unchanged because voting powers are unchanged.
 	SaveStakingInfo(ctx, info)
 
 	status, outData = transferStakedCoins(ctx, tx, stakingAcc)
+	if status == StatusSuccess && hasRewardMode {
+		acc := LoadRewardAccount(ctx, val.Address)
+		acc.RewardMode = rewardMode
+		SaveRewardAccount(ctx, acc)
+	}
+	if status == StatusSuccess && IsStakingEventsFork(ctx) {
+		logs = append(logs, buildValidatorEditedEvmLog(val, tx.Value))
+	}
 	return
 }
 
@@ -397,8 +608,11 @@ func retire(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []m
 	}
 	val.IsRetiring = true
 
-	//Now let's update the states, readonlyStakingInfo is unchanged because voting powers are unchanged.
+	//Now let's update the states, the readonly states are unchanged because voting powers are unchanged.
 	SaveStakingInfo(ctx, info)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildRetiredEvmLog(val.Address))
+	}
 
 	status = StatusSuccess
 	return
@@ -438,6 +652,9 @@ func createProposal(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (
 	SaveProposal(ctx, target, now+DefaultProposalDuration)
 	SaveVote(ctx, tx.From, target, uint64(val.VotingPower))
 	AddVoters(ctx, tx.From)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildProposalCreatedEvmLog(tx.From, target, now+DefaultProposalDuration))
+	}
 	status = StatusSuccess
 	return
 }
@@ -481,6 +698,9 @@ func vote(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun) (status int
 	}
 	SaveVote(ctx, tx.From, target, uint64(val.VotingPower))
 	AddVoters(ctx, tx.From)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildVotedEvmLog(tx.From, target, uint64(val.VotingPower)))
+	}
 
 	status = StatusSuccess
 	return
@@ -515,8 +735,15 @@ func executeProposal(ctx *mevmtypes.Context, now uint64, tx *mevmtypes.TxToRun)
 	}
 	voters := GetVoters(ctx)
 	target = CalculateTarget(ctx, voters)
+	oldMinGasPrice := LoadMinGasPrice(ctx, false)
 	SaveMinGasPrice(ctx, target, false)
 	DeleteProposalInfos(ctx, voters)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildProposalExecutedEvmLog(tx.From, target))
+		if oldMinGasPrice != target {
+			logs = append(logs, buildMinGasPriceChangedEvmLog(oldMinGasPrice, target))
+		}
+	}
 
 	status = StatusSuccess
 	return
@@ -576,6 +803,7 @@ func handleMinGasPrice(ctx *mevmtypes.Context, sender common.Address, isIncrease
 		status = StatusFailed //default status is failed
 		gasUsed = GasOfMinGasPriceOp
 		mGP := LoadMinGasPrice(ctx, false)
+		oldMGP := mGP
 		lastMGP := LoadMinGasPrice(ctx, true) // this variable only updates at endblock
 		info := LoadStakingInfo(ctx)
 		isValidatorOrRewardTo := false
@@ -612,6 +840,9 @@ func handleMinGasPrice(ctx *mevmtypes.Context, sender common.Address, isIncrease
 			return
 		}
 		SaveMinGasPrice(ctx, mGP, false)
+		if IsStakingEventsFork(ctx) {
+			logs = append(logs, buildMinGasPriceChangedEvmLog(oldMGP, mGP))
+		}
 		status = StatusSuccess
 	}
 	return
@@ -641,6 +872,9 @@ func LoadStakingInfo(ctx *mevmtypes.Context) (info types.StakingInfo) {
 	if err != nil {
 		panic(err)
 	}
+	if index, ok := loadStakingInfoIndex(ctx); ok {
+		loadValidatorsAndPendingRewards(ctx, &info, index)
+	}
 	return
 }
 
@@ -657,12 +891,15 @@ func AddGenesisValidatorsIntoStakingInfo(ctx *mevmtypes.Context, genesisValidato
 }
 
 func SaveStakingInfo(ctx *mevmtypes.Context, info types.StakingInfo) {
+	if _, migrated := loadStakingInfoIndex(ctx); migrated || IsStakingInfoStorageFork(ctx) {
+		saveStakingInfoIncrementally(ctx, info)
+		return
+	}
 	bz, err := info.MarshalMsg(nil)
 	if err != nil {
 		panic(err)
 	}
 	ctx.SetStorageAt(StakingContractSequence, SlotStakingInfo, bz)
-	readonlyStakingInfo = &info
 }
 
 func SaveEpoch(ctx *mevmtypes.Context, epoch *types.Epoch) {
@@ -832,6 +1069,16 @@ func NewOnlineInfos(activeValidators []*types.Validator, startHeight int64) *typ
 	return &infos
 }
 
+// Whether the online window which started at startHeight ends at current height
+func isOnlineWindowEnd(ctx *mevmtypes.Context, startHeight int64) bool {
+	windowSize := int64(GetGovParam(ctx, GovParamOnlineWindowSize))
+	if IsParamGovernanceFork(ctx) {
+		// the window may be shrunk by governance after it started
+		return ctx.Height >= startHeight+windowSize
+	}
+	return ctx.Height == startHeight+windowSize
+}
+
 func UpdateOnlineInfos(ctx *mevmtypes.Context, infos types.ValidatorOnlineInfos, voters [][]byte) (startHeight int64) {
 	voterMap := make(map[[20]byte]bool, len(voters))
 	for _, voter := range voters {
@@ -839,7 +1086,7 @@ func UpdateOnlineInfos(ctx *mevmtypes.Context, infos types.ValidatorOnlineInfos,
 		copy(v[:], voter)
 		voterMap[v] = true
 	}
-	if ctx.Height == infos.StartHeight+param.OnlineWindowSize {
+	if isOnlineWindowEnd(ctx, infos.StartHeight) {
 		for _, info := range infos.OnlineInfos {
 			info.SignatureCount = 0
 		}
@@ -864,13 +1111,14 @@ func HandleOnlineInfos(ctx *mevmtypes.Context, stakingInfo *types.StakingInfo, v
 		UpdateOnlineInfos(ctx, infos, voters)
 		return
 	}
-	if ctx.Height != infos.StartHeight+param.OnlineWindowSize {
+	if !isOnlineWindowEnd(ctx, infos.StartHeight) {
 		UpdateOnlineInfos(ctx, infos, voters)
 		return
 	}
+	minOnlineSignatures := int32(GetGovParam(ctx, GovParamMinOnlineSignatures))
 	var newInfos []*types.OnlineInfo
 	for _, info := range infos.OnlineInfos {
-		if info.SignatureCount < param.MinOnlineSignatures {
+		if info.SignatureCount < minOnlineSignatures {
 			retireValidators[info.ValidatorConsensusAddress] = true
 		} else {
 			newInfos = append(newInfos, info)
@@ -881,23 +1129,32 @@ func HandleOnlineInfos(ctx *mevmtypes.Context, stakingInfo *types.StakingInfo, v
 		return
 	}
 	infos.OnlineInfos = newInfos
+	var jailedValidators []*types.Validator
 	for _, val := range stakingInfo.Validators {
 		var address [20]byte
 		copy(address[:], ed25519.PubKey(val.Pubkey[:]).Address().Bytes())
 		if retireValidators[address] {
-			val.IsRetiring = true
+			if IsJailingFork(ctx) && !val.IsRetiring {
+				// the validator can come back by calling unjail() after JailEpochCount epochs
+				jailedValidators = append(jailedValidators, val)
+			} else {
+				val.IsRetiring = true
+			}
 			val.VotingPower = 0
 			slashValidators = append(slashValidators, address)
 		}
 	}
+	if len(jailedValidators) != 0 {
+		jailValidators(ctx, stakingInfo.CurrEpochNum, jailedValidators)
+	}
 	UpdateOnlineInfos(ctx, infos, voters)
 	return
 }
 
 // slashValidators and lastVoters are consensus addresses generated from validator consensus pubkey
-func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]byte,
+func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators, lightClientSlashValidators [][20]byte,
 	currProposer, lastProposer [20]byte, lastVoters [][]byte, /*include proposer*/
-	blockReward *uint256.Int) (currValidators, newValidators []*types.Validator, currEpochNum int64) {
+	blockReward *uint256.Int) (currValidators, newValidators []*types.Validator, currEpochNum int64, logs []mevmtypes.EvmLog) {
 
 	stakingAcc, info := LoadStakingAccAndInfo(ctx)
 	currEpochNum = info.CurrEpochNum
@@ -910,24 +1167,72 @@ func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]by
 		pubkeyMapByConsAddr[consAddr] = v.Pubkey
 	}
 	//slash first
+	if !IsSlashHistoryFork(ctx) {
+		lightClientSlashValidators = nil // the light client attacks were ignored
+	}
+	var unbondingPubkeyMapByConsAddr map[[20]byte][32]byte
+	if len(duplicateSigSlashValidators)+len(lightClientSlashValidators) != 0 && IsValidatorUnbondingFork(ctx) {
+		unbondingPubkeyMapByConsAddr = getUnbondingPubkeyMapByConsAddr(ctx)
+	}
 	for _, v := range duplicateSigSlashValidators {
-		if pubkey, ok := pubkeyMapByConsAddr[v]; ok {
+		pubkey, ok := pubkeyMapByConsAddr[v]
+		if !ok {
+			pubkey, ok = unbondingPubkeyMapByConsAddr[v]
+		}
+		if !ok && IsKeyRotationFork(ctx) {
+			pubkey, ok = getPubkeyByRotatedConsAddr(ctx, &info, v)
+		}
+		if ok {
 			slashAmount := uint256.NewInt(0)
 			if ctx.IsStakingFork() {
-				slashAmount = uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork, uint256.NewInt(param.DuplicateSigSlashAMountDivisor))
+				slashAmount = uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork, uint256.NewInt(GetGovParam(ctx, GovParamDuplicateSigSlashAmountDivisor)))
 			}
-			Slash(ctx, &info, pubkey, slashAmount)
+			logs = append(logs, slashByzantineValidator(ctx, &info, pubkey, slashAmount, SlashReasonDuplicateSig)...)
+		}
+	}
+	for _, v := range lightClientSlashValidators {
+		pubkey, ok := pubkeyMapByConsAddr[v]
+		if !ok {
+			pubkey, ok = unbondingPubkeyMapByConsAddr[v]
+		}
+		if !ok && IsKeyRotationFork(ctx) {
+			pubkey, ok = getPubkeyByRotatedConsAddr(ctx, &info, v)
+		}
+		if ok {
+			slashAmount := uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork, uint256.NewInt(GetGovParam(ctx, GovParamLightClientSlashAmountDivisor)))
+			logs = append(logs, slashByzantineValidator(ctx, &info, pubkey, slashAmount, SlashReasonLightClientAttack)...)
 		}
 	}
 	if ctx.IsStakingFork() {
 		notOnlineSlashValidators := HandleOnlineInfos(ctx, &info, lastVoters)
+		slashAmountDivisor := GetGovParam(ctx, GovParamNotOnlineSlashAmountDivisor)
+		var jailedMapByPubkey map[[32]byte]*types.JailedValidator
+		if IsJailingFork(ctx) {
+			slashAmountDivisor = GetGovParam(ctx, GovParamDowntimeSlashAmountDivisor)
+			if len(notOnlineSlashValidators) != 0 && IsStakingEventsFork(ctx) {
+				jailedList := LoadJailedValidatorList(ctx)
+				jailedMapByPubkey = jailedList.GetMapByPubkey()
+			}
+		}
 		for _, v := range notOnlineSlashValidators {
 			if pubkey, ok := pubkeyMapByConsAddr[v]; ok {
-				slashAmount := uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork, uint256.NewInt(param.NotOnlineSlashAmountDivisor))
-				Slash(ctx, &info, pubkey, slashAmount)
+				slashAmount := uint256.NewInt(0).Div(MinimumStakingAmountAfterStakingFork, uint256.NewInt(slashAmountDivisor))
+				totalSlashed := Slash(ctx, &info, pubkey, slashAmount)
+				if totalSlashed != nil && IsStakingEventsFork(ctx) {
+					logs = append(logs, buildSlashedEvmLog(pubkey, totalSlashed, SlashReasonNotOnline))
+				}
+				if totalSlashed != nil && IsSlashHistoryFork(ctx) {
+					recordSlash(ctx, &info, pubkey, SlashReasonNotOnline, totalSlashed)
+				}
+				if j, ok := jailedMapByPubkey[pubkey]; ok {
+					logs = append(logs, buildJailedEvmLog(j))
+				}
 			}
 		}
 	}
+	if IsUptimeHistoryFork(ctx) {
+		updateUptimeWindow(ctx, currValidators, currProposer, lastVoters)
+	}
 	voters := make([][32]byte, 0, len(lastVoters))
 	var tmpAddr [20]byte
 	for _, c := range lastVoters {
@@ -936,6 +1241,10 @@ func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]by
 			voters = append(voters, voter)
 		}
 	}
+	if IsSlashBurningFixFork(ctx) {
+		// the slashed coins have been burnt from stakingAcc in ctx, which must not be overwritten by the stale one
+		stakingAcc = ctx.GetAccount(StakingContractAddress)
+	}
 	DistributeFee(ctx, stakingAcc, &info, blockReward, pubkeyMapByConsAddr[currProposer],
 		pubkeyMapByConsAddr[lastProposer], voters)
 	newValidators = GetActiveValidators(ctx, info.Validators)
@@ -943,21 +1252,56 @@ func SlashAndReward(ctx *mevmtypes.Context, duplicateSigSlashValidators [][20]by
 	return
 }
 
+func IsSlashBurningFixFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.SlashBurningFixForkHeight
+}
+
+// Slash a validator which signed duplicate votes or took part in a light client attack, and retire it
+func slashByzantineValidator(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, slashAmount *uint256.Int,
+	reason uint8) (logs []mevmtypes.EvmLog) {
+	totalSlashed := Slash(ctx, info, pubkey, slashAmount)
+	if totalSlashed != nil && IsStakingEventsFork(ctx) {
+		logs = append(logs, buildSlashedEvmLog(pubkey, totalSlashed, reason))
+	}
+	if totalSlashed != nil && IsUptimeHistoryFork(ctx) && reason == SlashReasonDuplicateSig {
+		recordDuplicateSig(ctx, info, pubkey, totalSlashed)
+	}
+	if totalSlashed != nil && IsSlashHistoryFork(ctx) {
+		recordSlash(ctx, info, pubkey, reason, totalSlashed)
+	}
+	if val := info.GetValidatorByPubkey(pubkey); val != nil && IsJailingFork(ctx) {
+		// unlike the jailed ones, a byzantine validator can never come back
+		val.IsRetiring = true
+		val.VotingPower = 0
+	}
+	return
+}
+
 // Slash 'amount' of coins from the validator with 'pubkey'. These coins are burnt and booked on BlackHole acc.
 func Slash(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amount *uint256.Int) (totalSlashed *uint256.Int) {
 	val := info.GetValidatorByPubkey(pubkey)
 	if val == nil {
+		if IsValidatorUnbondingFork(ctx) {
+			// a retired validator can still be slashed before its staked coins are paid back
+			return slashValidatorUnbondings(ctx, info, pubkey, amount)
+		}
 		return // If tendermint works fine, we'll never reach here
 	}
 	coins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+	stakedCoins := coins.Clone()
+	remained := uint256.NewInt(0) // the amount which cannot be slashed from the staked coins
 	if coins.Lt(amount) { // not enough coins to be slashed
 		totalSlashed = coins.Clone()
+		remained.Sub(amount, coins)
 		coins.SetUint64(0)
 	} else {
 		totalSlashed = amount.Clone()
 		coins.Sub(coins, amount)
 	}
 	val.StakedCoins = coins.Bytes32()
+	if IsDelegationFork(ctx) && !stakedCoins.IsZero() {
+		totalSlashed.Add(totalSlashed, slashDelegatedCoins(ctx, val.Pubkey, info.CurrEpochNum, totalSlashed, stakedCoins))
+	}
 
 	totalCleared := info.ClearRewardsOf(val.Address)
 	totalSlashed.Add(totalSlashed, totalCleared)
@@ -965,16 +1309,16 @@ func Slash(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amo
 	// deduct the totalSlashed from stakingAcc and burn them, must no error, not check
 	_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, StakingContractAddress, totalSlashed)
 	incrAllBurnt(ctx, totalSlashed)
+	if !remained.IsZero() && IsStakeAdjustmentFork(ctx) {
+		// the coins withdrawn by decreaseStake are still slashable before they are paid back
+		totalSlashed.Add(totalSlashed, slashValidatorUnbondings(ctx, info, pubkey, remained))
+	}
 	return
 }
 
 // Increase the slot of 'all burnt' inside stakingAcc
 func incrAllBurnt(ctx *mevmtypes.Context, amount *uint256.Int) {
-	allBurnt := uint256.NewInt(0)
-	bz := ctx.GetStorageAt(StakingContractSequence, SlotAllBurnt)
-	if len(bz) != 0 {
-		allBurnt.SetBytes32(bz)
-	}
+	allBurnt := loadAllBurnt(ctx)
 	allBurnt.Add(allBurnt, amount)
 	bz32 := allBurnt.Bytes32()
 	ctx.SetStorageAt(StakingContractSequence, SlotAllBurnt, bz32[:])
@@ -1033,28 +1377,33 @@ func DistributeFee(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, in
 		rwdCoins := uint256.NewInt(0).Mul(collectedFee, uint256.NewInt(uint64(val.VotingPower)))
 		rwdCoins.Div(rwdCoins, uint256.NewInt(uint64(votedPower)))
 		remainedFee.Sub(remainedFee, rwdCoins)
-		distributeToValidator(info, rwdMapByAddr, rwdCoins, val)
+		distributeToValidator(ctx, info, rwdMapByAddr, rwdCoins, val)
 	}
 
 	if proposer != [32]byte{} {
 		//distribute to the proposer
 		proposerVal := valMapByPubkey[proposer]
 		coins := uint256.NewInt(0).Add(proposerBaseFee, remainedFee)
-		distributeToValidator(info, rwdMapByAddr, coins, proposerVal)
+		distributeToValidator(ctx, info, rwdMapByAddr, coins, proposerVal)
 	} else if !remainedFee.IsZero() {
 		_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, StakingContractAddress, remainedFee)
 	}
 
 	if collector != [32]byte{} {
-		distributeToValidator(info, rwdMapByAddr, collectorFee, valMapByPubkey[collector])
+		distributeToValidator(ctx, info, rwdMapByAddr, collectorFee, valMapByPubkey[collector])
 	} else if !collectorFee.IsZero() {
 		_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, StakingContractAddress, collectorFee)
 	}
 }
 
-func distributeToValidator(info *types.StakingInfo, rwdMapByAddr map[[20]byte]*types.PendingReward,
+func distributeToValidator(ctx *mevmtypes.Context, info *types.StakingInfo, rwdMapByAddr map[[20]byte]*types.PendingReward,
 	rwdCoins *uint256.Int, val *types.Validator) {
 
+	if IsDelegationFork(ctx) {
+		// the delegators' share is left in stakingAcc, and is not cleared when the validator is slashed,
+		// because the delegated coins are slashed instead
+		rwdCoins = splitRewardWithDelegators(ctx, val, rwdCoins)
+	}
 	rwd := rwdMapByAddr[val.Address]
 	if rwd == nil {
 		rwd = &types.PendingReward{
@@ -1070,22 +1419,35 @@ func distributeToValidator(info *types.StakingInfo, rwdMapByAddr map[[20]byte]*t
 }
 
 // switch to a new epoch
-func SwitchEpoch(ctx *mevmtypes.Context, epoch *types.Epoch, posVotes map[[32]byte]int64, logger log.Logger) []*types.Validator {
+func SwitchEpoch(ctx *mevmtypes.Context, epoch *types.Epoch, posVotes map[[32]byte]int64, numBlocksInEpoch int64,
+	logger log.Logger) (activeValidators []*types.Validator, isValid bool, logs []mevmtypes.EvmLog) {
 	stakingAcc, info := LoadStakingAccAndInfo(ctx)
 	//increase currEpochNum no matter if epoch is valid
 	info.CurrEpochNum++
 	epoch.Number = info.CurrEpochNum
 	SaveEpoch(ctx, epoch)
+	if IsDelegationFork(ctx) {
+		saveEpochStartHeight(ctx)
+	}
 	logger.Debug(fmt.Sprintf("Epoch info in switchEpoch [newPpochNumber:%d,startHeight:%d,EndTime:%d]", epoch.Number, epoch.StartHeight, epoch.EndTime))
 
 	// distribute mature pending reward to rewardTo
-	deliverMintRewardInEpoch(ctx, stakingAcc, &info)
+	logs, rewards := deliverMintRewardInEpoch(ctx, stakingAcc, &info)
 
-	isValid, pubkey2power, oldActiveValidators := checkEpoch(ctx, info, epoch, posVotes, logger)
+	isValid, pubkey2power, oldActiveValidators := checkEpoch(ctx, info, epoch, posVotes, numBlocksInEpoch, logger)
+	if IsStakingEventsFork(ctx) {
+		logs = append(logs, buildEpochSwitchedEvmLog(epoch, isValid))
+	}
 	if !isValid {
 		updatePendingRewardsInNewEpoch(oldActiveValidators, &info, logger)
 		SaveStakingInfo(ctx, info)
-		return nil
+		if IsEpochSnapshotFork(ctx) {
+			SaveEpochSnapshot(ctx, &types.EpochSnapshot{EpochNum: epoch.Number, Validators: info.Validators, Rewards: rewards})
+		}
+		return
+	}
+	if IsKeyRotationFork(ctx) {
+		logs = append(logs, applyConsensusKeyRotations(ctx, &info, pubkey2power)...)
 	}
 	// someone who call createValidator before switchEpoch can enjoy the voting power update
 	// someone who call retire() before switchEpoch cannot get elected in this update
@@ -1093,17 +1455,21 @@ func SwitchEpoch(ctx *mevmtypes.Context, epoch *types.Epoch, posVotes map[[32]by
 	// payback staking coins to rewardTo of useless validators and delete these validators
 	clearUselessValidators(ctx, stakingAcc, &info)
 	// allocate new entries in info.PendingRewards
-	activeValidators := GetActiveValidators(ctx, info.Validators)
+	activeValidators = GetActiveValidators(ctx, info.Validators)
 	updatePendingRewardsInNewEpoch(activeValidators, &info, logger)
 	SaveStakingInfo(ctx, info)
+	if IsEpochSnapshotFork(ctx) {
+		SaveEpochSnapshot(ctx, &types.EpochSnapshot{EpochNum: epoch.Number, Validators: info.Validators, Rewards: rewards})
+	}
 	if ctx.IsStakingFork() {
 		SaveOnlineInfo(ctx, *NewOnlineInfos(activeValidators, ctx.Height))
 	}
-	return activeValidators
+	return
 }
 
-// deliver pending rewards which are mature now to rewardTo
-func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo) {
+// deliver pending rewards which are mature now to rewardTo. The delegators' share has been split out.
+// The delivered rewards are returned for the epoch snapshot.
+func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo) (logs []mevmtypes.EvmLog, rewards []*types.EpochReward) {
 	stakingAccBalance := stakingAcc.Balance()
 	newPRList := make([]*types.PendingReward, 0, len(info.PendingRewards))
 	valMapByAddr := info.GetValMapByAddr()
@@ -1115,10 +1481,24 @@ func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.Acco
 			continue
 		}
 		val := valMapByAddr[pr.Address]
-		if _, ok := rewardMap[val.RewardTo]; !ok {
-			rewardMap[val.RewardTo] = uint256.NewInt(0)
+		amount := uint256.NewInt(0).SetBytes32(pr.Amount[:])
+		if !IsRewardClaimingFork(ctx) || !keepRewardInStakingAcc(ctx, val, amount) {
+			if _, ok := rewardMap[val.RewardTo]; !ok {
+				rewardMap[val.RewardTo] = uint256.NewInt(0)
+			}
+			rewardMap[val.RewardTo].Add(rewardMap[val.RewardTo], amount)
+		}
+		if IsStakingEventsFork(ctx) {
+			logs = append(logs, buildRewardDistributedEvmLog(val, pr.EpochNum, amount))
+		}
+		if IsEpochSnapshotFork(ctx) {
+			rewards = append(rewards, &types.EpochReward{
+				Address:  val.Address,
+				RewardTo: val.RewardTo,
+				EpochNum: pr.EpochNum,
+				Amount:   amount.Bytes32(),
+			})
 		}
-		rewardMap[val.RewardTo].Add(rewardMap[val.RewardTo], uint256.NewInt(0).SetBytes32(pr.Amount[:]))
 	}
 	info.PendingRewards = newPRList
 
@@ -1135,13 +1515,26 @@ func deliverMintRewardInEpoch(ctx *mevmtypes.Context, stakingAcc *mevmtypes.Acco
 		ctx.SetAccount(addr, acc)
 	}
 	stakingAcc.UpdateBalance(stakingAccBalance)
+	return
 }
 
-func checkEpoch(ctx *mevmtypes.Context, info types.StakingInfo, epoch *types.Epoch, posVotes map[[32]byte]int64, logger log.Logger) (bool, map[[32]byte]int64, []*types.Validator) {
-	powTotalNomination, pubkey2power := getPubkey2Power(info, epoch, posVotes, logger)
+func checkEpoch(ctx *mevmtypes.Context, info types.StakingInfo, epoch *types.Epoch, posVotes map[[32]byte]int64, numBlocksInEpoch int64,
+	logger log.Logger) (bool, map[[32]byte]int64, []*types.Validator) {
+	var jailedMapByPubkey map[[32]byte]*types.JailedValidator
+	if IsJailingFork(ctx) {
+		jailedList := LoadJailedValidatorList(ctx)
+		jailedMapByPubkey = jailedList.GetMapByPubkey()
+	}
+	if IsKeyRotationFork(ctx) {
+		resolved := *epoch
+		resolved.Nominations, posVotes = resolveRotatedPubkeys(ctx, info, epoch.Nominations, posVotes)
+		epoch = &resolved
+	}
+	maxActiveValidatorCount := int(GetGovParam(ctx, GovParamMaxActiveValidatorCount))
+	powTotalNomination, pubkey2power := getPubkey2Power(info, epoch, posVotes, jailedMapByPubkey, maxActiveValidatorCount, logger)
 	activeValidators := GetActiveValidators(ctx, info.Validators)
 	if !(param.IsAmber && ctx.IsXHedgeFork()) {
-		if powTotalNomination < param.StakingNumBlocksInEpoch*int64(param.StakingMinVotingPercentPerEpoch)/100 {
+		if powTotalNomination < numBlocksInEpoch*int64(param.StakingMinVotingPercentPerEpoch)/100 {
 			logger.Debug("PoWTotalNomination not big enough", "PoWTotalNomination", powTotalNomination)
 			return false, pubkey2power, activeValidators
 		}
@@ -1153,10 +1546,12 @@ func checkEpoch(ctx *mevmtypes.Context, info types.StakingInfo, epoch *types.Epo
 	return true, pubkey2power, activeValidators
 }
 
-func getPubkey2Power(info types.StakingInfo, epoch *types.Epoch, posVotes map[[32]byte]int64, logger log.Logger) (powTotalNomination int64, pubkey2power map[[32]byte]int64) {
+// the jailed validators cannot get voting power, just like the retiring ones
+func getPubkey2Power(info types.StakingInfo, epoch *types.Epoch, posVotes map[[32]byte]int64,
+	jailedMapByPubkey map[[32]byte]*types.JailedValidator, maxActiveValidatorCount int, logger log.Logger) (powTotalNomination int64, pubkey2power map[[32]byte]int64) {
 	validatorSet := make(map[[32]byte]bool, len(info.Validators))
 	for _, val := range info.Validators {
-		if !val.IsRetiring {
+		if _, jailed := jailedMapByPubkey[val.Pubkey]; !val.IsRetiring && !jailed {
 			validatorSet[val.Pubkey] = true
 		}
 	}
@@ -1201,11 +1596,11 @@ func getPubkey2Power(info types.StakingInfo, epoch *types.Epoch, posVotes map[[3
 		validNominations = append(validNominations, n)
 	}
 
-	// select at most MaxActiveValidatorCount validators
+	// select at most maxActiveValidatorCount validators
 	nominationHeap := types.NominationHeap(validNominations)
 	heap.Init(&nominationHeap)
 	pubkey2power = make(map[[32]byte]int64, len(validNominations))
-	for i := 0; i < param.MaxActiveValidatorCount && len(nominationHeap) > 0; i++ {
+	for i := 0; i < maxActiveValidatorCount && len(nominationHeap) > 0; i++ {
 		n := heap.Pop(&nominationHeap).(*types.Nomination)
 		pubkey2power[n.Pubkey] = 1
 	}
@@ -1244,23 +1639,35 @@ func updateVotingPower(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey2p
 	}
 }
 
-// Remove the useless validators from info and return StakedCoins to them
+// Remove the useless validators from info and return StakedCoins to them,
+// or put StakedCoins into the unbonding queue after ValidatorUnbondingForkHeight
 func clearUselessValidators(ctx *mevmtypes.Context, stakingAcc *mevmtypes.AccountInfo, info *types.StakingInfo) {
 	uselessValMap := info.GetUselessValidators()
+	if IsJailingFork(ctx) {
+		keepJailedValidators(ctx, info, uselessValMap)
+	}
+	if IsRewardClaimingFork(ctx) {
+		keepValidatorsWithRewards(ctx, info, uselessValMap)
+	}
 	valMapByAddr := info.GetValMapByAddr()
 	stakingAccBalance := stakingAcc.Balance()
-	for addr := range uselessValMap {
-		val := valMapByAddr[addr]
-		acc := ctx.GetAccount(val.RewardTo)
-		if acc == nil {
-			acc = mevmtypes.ZeroAccountInfo()
+	if IsValidatorUnbondingFork(ctx) {
+		// the coins are kept in stakingAcc until the unbonding entries are mature
+		startValidatorUnbondings(ctx, info, uselessValMap)
+	} else {
+		for addr := range uselessValMap {
+			val := valMapByAddr[addr]
+			acc := ctx.GetAccount(val.RewardTo)
+			if acc == nil {
+				acc = mevmtypes.ZeroAccountInfo()
+			}
+			coins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
+			stakingAccBalance.Sub(stakingAccBalance, coins)
+			balance := acc.Balance()
+			balance.Add(balance, coins)
+			acc.UpdateBalance(balance)
+			ctx.SetAccount(val.RewardTo, acc)
 		}
-		coins := uint256.NewInt(0).SetBytes32(val.StakedCoins[:])
-		stakingAccBalance.Sub(stakingAccBalance, coins)
-		balance := acc.Balance()
-		balance.Add(balance, coins)
-		acc.UpdateBalance(balance)
-		ctx.SetAccount(val.RewardTo, acc)
 	}
 	stakingAcc.UpdateBalance(stakingAccBalance)
 	ctx.SetAccount(StakingContractAddress, stakingAcc)
@@ -1297,8 +1704,9 @@ func GetActiveValidators(ctx *mevmtypes.Context, vals []*types.Validator) []*typ
 	sort.SliceStable(res, func(i, j int) bool {
 		return res[i].VotingPower > res[j].VotingPower
 	})
-	if len(res) > param.MaxActiveValidatorCount {
-		res = res[:param.MaxActiveValidatorCount]
+	maxActiveValidatorCount := int(GetGovParam(ctx, GovParamMaxActiveValidatorCount))
+	if len(res) > maxActiveValidatorCount {
+		res = res[:maxActiveValidatorCount]
 	}
 	return res
 }
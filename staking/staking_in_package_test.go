@@ -82,6 +82,7 @@ func TestGetActiveValidators(t *testing.T) {
 	ctx := types.NewContext(nil, nil)
 	ctx.SetCurrentHeight(1)
 	ctx.SetStakingForkBlock(100)
+	defer func(amount *uint256.Int) { MinimumStakingAmount = amount }(MinimumStakingAmount)
 	MinimumStakingAmount = uint256.NewInt(0).SetBytes32(min[:])
 	vals := GetActiveValidators(ctx, si.Validators)
 	fmt.Println(len(vals))
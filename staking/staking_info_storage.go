@@ -0,0 +1,147 @@
+package staking
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+// Before StakingInfoStorageForkHeight, the whole StakingInfo is serialized into SlotStakingInfo. After it,
+// SlotStakingInfo only keeps the other fields, each validator is stored in a slot keyed by its address, and
+// each pending reward is stored in a slot keyed by its address and epoch number, together with how many
+// entries before it have the same ones, so removing an entry does not move the others. SlotStakingInfoIndex
+// tells how to find them. Only the changed slots are written when saving, and the first saving after the
+// fork migrates the old format.
+
+var (
+	validatorSlotHashPrefix     = [4]byte{'s', 'v', 'a', 'l'}
+	pendingRewardSlotHashPrefix = [4]byte{'s', 'r', 'w', 'd'}
+)
+
+func IsStakingInfoStorageFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.StakingInfoStorageForkHeight
+}
+
+func getSlotForValidator(addr [20]byte) string {
+	key := sha256.Sum256(append(validatorSlotHashPrefix[:], addr[:]...))
+	return string(key[:])
+}
+
+func getSlotForPendingReward(addr [20]byte, epochNum int64, seq int64) string {
+	var buf [4 + 20 + 8 + 8]byte
+	copy(buf[:4], pendingRewardSlotHashPrefix[:])
+	copy(buf[4:24], addr[:])
+	binary.BigEndian.PutUint64(buf[24:32], uint64(epochNum))
+	binary.BigEndian.PutUint64(buf[32:], uint64(seq))
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+// the slots of the pending rewards with 'keys', the entries with the same key are told apart by their order
+func getSlotsForPendingRewards(keys []*types.PendingRewardKey) []string {
+	slots := make([]string, len(keys))
+	seqMap := make(map[types.PendingRewardKey]int64, len(keys))
+	for i, k := range keys {
+		slots[i] = getSlotForPendingReward(k.Address, k.EpochNum, seqMap[*k])
+		seqMap[*k]++
+	}
+	return slots
+}
+
+// ok is false if StakingInfo has not been migrated
+func loadStakingInfoIndex(ctx *mevmtypes.Context) (index types.StakingInfoIndex, ok bool) {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotStakingInfoIndex)
+	if len(bz) == 0 {
+		return
+	}
+	_, err := index.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	ok = true
+	return
+}
+
+func loadValidatorsAndPendingRewards(ctx *mevmtypes.Context, info *types.StakingInfo, index types.StakingInfoIndex) {
+	info.Validators = make([]*types.Validator, len(index.ValidatorAddrs))
+	for i, addr := range index.ValidatorAddrs {
+		info.Validators[i] = &types.Validator{}
+		bz := ctx.GetStorageAt(StakingContractSequence, getSlotForValidator(addr))
+		if _, err := info.Validators[i].UnmarshalMsg(bz); err != nil {
+			panic(err)
+		}
+	}
+	info.PendingRewards = make([]*types.PendingReward, len(index.PendingRewardKeys))
+	for i, slot := range getSlotsForPendingRewards(index.PendingRewardKeys) {
+		info.PendingRewards[i] = &types.PendingReward{}
+		bz := ctx.GetStorageAt(StakingContractSequence, slot)
+		if _, err := info.PendingRewards[i].UnmarshalMsg(bz); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func saveStakingInfoIncrementally(ctx *mevmtypes.Context, info types.StakingInfo) {
+	oldIndex, _ := loadStakingInfoIndex(ctx)
+	index := types.StakingInfoIndex{
+		ValidatorAddrs:    make([][20]byte, len(info.Validators)),
+		PendingRewardKeys: make([]*types.PendingRewardKey, len(info.PendingRewards)),
+	}
+	currAddrs := make(map[[20]byte]struct{}, len(info.Validators))
+	for i, val := range info.Validators {
+		index.ValidatorAddrs[i] = val.Address
+		currAddrs[val.Address] = struct{}{}
+		bz, err := val.MarshalMsg(nil)
+		if err != nil {
+			panic(err)
+		}
+		setStorageIfChanged(ctx, getSlotForValidator(val.Address), bz)
+	}
+	for _, addr := range oldIndex.ValidatorAddrs {
+		if _, ok := currAddrs[addr]; !ok {
+			ctx.DeleteStorageAt(StakingContractSequence, getSlotForValidator(addr))
+		}
+	}
+	for i, pr := range info.PendingRewards {
+		index.PendingRewardKeys[i] = &types.PendingRewardKey{Address: pr.Address, EpochNum: pr.EpochNum}
+	}
+	currSlots := make(map[string]struct{}, len(info.PendingRewards))
+	for i, slot := range getSlotsForPendingRewards(index.PendingRewardKeys) {
+		currSlots[slot] = struct{}{}
+		bz, err := info.PendingRewards[i].MarshalMsg(nil)
+		if err != nil {
+			panic(err)
+		}
+		setStorageIfChanged(ctx, slot, bz)
+	}
+	for _, slot := range getSlotsForPendingRewards(oldIndex.PendingRewardKeys) {
+		if _, ok := currSlots[slot]; !ok {
+			ctx.DeleteStorageAt(StakingContractSequence, slot)
+		}
+	}
+
+	bz, err := index.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	setStorageIfChanged(ctx, SlotStakingInfoIndex, bz)
+	info.Validators = nil
+	info.PendingRewards = nil
+	bz, err = info.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	setStorageIfChanged(ctx, SlotStakingInfo, bz)
+}
+
+// writing a slot is much more expensive than reading it
+func setStorageIfChanged(ctx *mevmtypes.Context, slot string, bz []byte) {
+	if !bytes.Equal(ctx.GetStorageAt(StakingContractSequence, slot), bz) {
+		ctx.SetStorageAt(StakingContractSequence, slot, bz)
+	}
+}
@@ -0,0 +1,115 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/moeingads/store"
+	"github.com/smartbch/moeingads/store/rabbit"
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func buildStakingInfoForStorage() stakingtypes.StakingInfo {
+	return stakingtypes.StakingInfo{
+		GenesisMainnetBlockHeight: 100,
+		CurrEpochNum:              3,
+		Validators: []*stakingtypes.Validator{
+			{Address: [20]byte{0xad, 0x02}, Pubkey: [32]byte{0x02}, VotingPower: 2, Introduction: "val2"},
+			{Address: [20]byte{0xad, 0x01}, Pubkey: [32]byte{0x01}, VotingPower: 1, Introduction: "val1"},
+			{Address: [20]byte{0xad, 0x03}, Pubkey: [32]byte{0x03}, IsRetiring: true},
+		},
+		PendingRewards: []*stakingtypes.PendingReward{
+			{Address: [20]byte{0xad, 0x01}, EpochNum: 2, Amount: [32]byte{31: 10}},
+			{Address: [20]byte{0xad, 0x02}, EpochNum: 3, Amount: [32]byte{31: 20}},
+			{Address: [20]byte{0xad, 0x02}, EpochNum: 3, Amount: [32]byte{31: 30}},
+		},
+	}
+}
+
+func requireSameStakingInfo(t *testing.T, expected, actual stakingtypes.StakingInfo) {
+	require.Equal(t, expected.GenesisMainnetBlockHeight, actual.GenesisMainnetBlockHeight)
+	require.Equal(t, expected.CurrEpochNum, actual.CurrEpochNum)
+	require.Equal(t, len(expected.Validators), len(actual.Validators))
+	for i, val := range expected.Validators {
+		require.Equal(t, *val, *actual.Validators[i])
+	}
+	require.Equal(t, len(expected.PendingRewards), len(actual.PendingRewards))
+	for i, pr := range expected.PendingRewards {
+		require.Equal(t, *pr, *actual.PendingRewards[i])
+	}
+}
+
+func TestStakingInfoStorageMigration(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	info := buildStakingInfoForStorage()
+
+	// the whole StakingInfo is in one slot before the fork
+	ctx.SetCurrentHeight(param.StakingInfoStorageForkHeight - 1)
+	SaveStakingInfo(ctx, info)
+	_, migrated := loadStakingInfoIndex(ctx)
+	require.False(t, migrated)
+	requireSameStakingInfo(t, info, LoadStakingInfo(ctx))
+
+	// migrated at the first saving after the fork
+	ctx.SetCurrentHeight(param.StakingInfoStorageForkHeight)
+	SaveStakingInfo(ctx, LoadStakingInfo(ctx))
+	index, migrated := loadStakingInfoIndex(ctx)
+	require.True(t, migrated)
+	require.Equal(t, [][20]byte{{0xad, 0x02}, {0xad, 0x01}, {0xad, 0x03}}, index.ValidatorAddrs)
+	require.Equal(t, []*stakingtypes.PendingRewardKey{
+		{Address: [20]byte{0xad, 0x01}, EpochNum: 2},
+		{Address: [20]byte{0xad, 0x02}, EpochNum: 3},
+		{Address: [20]byte{0xad, 0x02}, EpochNum: 3},
+	}, index.PendingRewardKeys)
+	var header stakingtypes.StakingInfo
+	_, err := header.UnmarshalMsg(ctx.GetStorageAt(StakingContractSequence, SlotStakingInfo))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(header.Validators))
+	require.Equal(t, 0, len(header.PendingRewards))
+	requireSameStakingInfo(t, info, LoadStakingInfo(ctx))
+	UpdateReadonlyStates(ctx)
+	require.Equal(t, int64(2), loadReadonlyStates().stakingInfo.Validators[0].VotingPower)
+
+	// the new format is kept even if a context with a lower height saves it
+	ctx.SetCurrentHeight(param.StakingInfoStorageForkHeight - 1)
+	info = LoadStakingInfo(ctx)
+	info.CurrEpochNum++
+	SaveStakingInfo(ctx, info)
+	requireSameStakingInfo(t, info, LoadStakingInfo(ctx))
+}
+
+func TestSaveStakingInfoIncrementally(t *testing.T) {
+	r := rabbit.NewRabbitStore(store.NewMockRootStore())
+	ctx := types.NewContext(&r, nil)
+	ctx.SetCurrentHeight(param.StakingInfoStorageForkHeight)
+	SaveStakingInfo(ctx, buildStakingInfoForStorage())
+
+	info := LoadStakingInfo(ctx)
+	info.Validators[1].VotingPower = 10
+	info.Validators = info.Validators[:2] // remove val3
+	lastRewardBz := ctx.GetStorageAt(StakingContractSequence, getSlotForPendingReward([20]byte{0xad, 0x02}, 3, 1))
+	info.PendingRewards = info.PendingRewards[1:]
+	info.PendingRewards[0].Amount[31] = 21
+	SaveStakingInfo(ctx, info)
+
+	loaded := LoadStakingInfo(ctx)
+	requireSameStakingInfo(t, info, loaded)
+	require.Equal(t, int64(10), loaded.Validators[1].VotingPower)
+	require.Equal(t, byte(21), loaded.PendingRewards[0].Amount[31])
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, getSlotForValidator([20]byte{0xad, 0x03})))
+	// removing an entry does not move the others
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, getSlotForPendingReward([20]byte{0xad, 0x01}, 2, 0)))
+	require.Equal(t, lastRewardBz, ctx.GetStorageAt(StakingContractSequence, getSlotForPendingReward([20]byte{0xad, 0x02}, 3, 1)))
+
+	// the entries with the same address and epoch number are told apart by their order
+	info.PendingRewards = info.PendingRewards[1:]
+	SaveStakingInfo(ctx, info)
+	requireSameStakingInfo(t, info, LoadStakingInfo(ctx))
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, getSlotForPendingReward([20]byte{0xad, 0x02}, 3, 1)))
+	require.Equal(t, byte(30), LoadStakingInfo(ctx).PendingRewards[0].Amount[31])
+}
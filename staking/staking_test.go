@@ -19,6 +19,7 @@ import (
 	"github.com/smartbch/moeingevm/types"
 
 	"github.com/smartbch/smartbch/internal/testutils"
+	"github.com/smartbch/smartbch/param"
 	"github.com/smartbch/smartbch/staking"
 	types2 "github.com/smartbch/smartbch/staking/types"
 )
@@ -286,7 +287,7 @@ func TestSwitchEpoch(t *testing.T) {
 	info.PendingRewards = []*types2.PendingReward{proposerReward}
 	staking.SaveStakingInfo(ctx, info)
 	rewardTo := info.Validators[0].RewardTo
-	staking.SwitchEpoch(ctx, e, nil, log.NewNopLogger())
+	staking.SwitchEpoch(ctx, e, nil, param.StakingNumBlocksInEpoch, log.NewNopLogger())
 	stakingAcc, info = staking.LoadStakingAccAndInfo(ctx)
 	require.Equal(t, uint64(10000/2 /*pending reward not transfer to validator as of EpochCountBeforeRewardMature*/), stakingAcc.Balance().Uint64())
 	acc = ctx.GetAccount(sender)
@@ -297,7 +298,7 @@ func TestSwitchEpoch(t *testing.T) {
 	rewardAcc := ctx.GetAccount(rewardTo)
 	require.Equal(t, uint64(100), rewardAcc.Balance().Uint64())
 
-	staking.SwitchEpoch(ctx, e, nil, log.NewNopLogger())
+	staking.SwitchEpoch(ctx, e, nil, param.StakingNumBlocksInEpoch, log.NewNopLogger())
 	stakingAcc, info = staking.LoadStakingAccAndInfo(ctx)
 	require.Equal(t, uint64((10000-1500-8500*15/100)/2/2), stakingAcc.Balance().Uint64())
 }
@@ -342,7 +343,7 @@ func TestSlashAndReward(t *testing.T) {
 	copy(valAddress1[:], ed25519.PubKey(validator1[:]).Address().Bytes())
 	copy(valAddress2[:], ed25519.PubKey(validator2[:]).Address().Bytes())
 	staking.BuildAndSaveStakingInfo(ctx, [][32]byte{validator1, validator2})
-	currValidators, newValidators, _ := staking.SlashAndReward(ctx, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil)
+	currValidators, newValidators, _, _ := staking.SlashAndReward(ctx, nil, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil)
 	require.Equal(t, 2, len(currValidators))
 	require.Equal(t, 2, len(newValidators))
 	onlineInfos := staking.LoadOnlineInfo(ctx)
@@ -351,7 +352,7 @@ func TestSlashAndReward(t *testing.T) {
 	require.Equal(t, valAddress1, onlineInfos.OnlineInfos[0].ValidatorConsensusAddress)
 
 	ctx.SetCurrentHeight(600)
-	currValidators, newValidators, _ = staking.SlashAndReward(ctx, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil)
+	currValidators, newValidators, _, _ = staking.SlashAndReward(ctx, nil, nil, valAddress1, valAddress2, [][]byte{valAddress1[:], valAddress2[:]}, nil)
 	require.Equal(t, 2, len(currValidators))
 	require.Equal(t, 0, len(newValidators))
 	onlineInfos = staking.LoadOnlineInfo(ctx)
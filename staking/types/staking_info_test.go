@@ -81,3 +81,25 @@ func TestClearRewardsOf(t *testing.T) {
 	require.Equal(t, [20]byte{0xad, 0x03}, si.PendingRewards[2].Address)
 	require.Equal(t, [20]byte{0xad, 0x04}, si.PendingRewards[3].Address)
 }
+
+func TestGetUpdateValidatorSet(t *testing.T) {
+	addr1 := [20]byte{0xad, 0x01}
+	addr2 := [20]byte{0xad, 0x02}
+	addr3 := [20]byte{0xad, 0x03}
+	currValidators := []*Validator{
+		{Address: addr1, Pubkey: [32]byte{0xbe, 0x01}, VotingPower: 1},
+		{Address: addr2, Pubkey: [32]byte{0xbe, 0x02}, VotingPower: 1},
+		{Address: addr3, Pubkey: [32]byte{0xbe, 0x03}, VotingPower: 1},
+	}
+	newValidators := []*Validator{
+		{Address: addr1, Pubkey: [32]byte{0xbe, 0x01}, VotingPower: 1},
+		{Address: addr2, Pubkey: [32]byte{0xbe, 0x02}, VotingPower: 2},
+		{Address: addr3, Pubkey: [32]byte{0xbe, 0x33}, VotingPower: 1}, // rotated
+	}
+	require.Nil(t, GetUpdateValidatorSet(currValidators, nil))
+	updated := GetUpdateValidatorSet(currValidators, newValidators)
+	require.Equal(t, 3, len(updated))
+	require.Equal(t, Validator{Address: addr2, Pubkey: [32]byte{0xbe, 0x02}, VotingPower: 2}, *updated[0])
+	require.Equal(t, Validator{Address: addr3, Pubkey: [32]byte{0xbe, 0x03}, VotingPower: 0}, *updated[1])
+	require.Equal(t, Validator{Address: addr3, Pubkey: [32]byte{0xbe, 0x33}, VotingPower: 1}, *updated[2])
+}
@@ -16,8 +16,9 @@ var (
 	ValidatorPubkeyAlreadyExists  = errors.New("Validator's pubkey already exists")
 )
 
-// Currently the first Vout in a coinbase transaction can nominate one validator with one vote
-// In the future it maybe extend to nominate multiple validators with different weights
+// Before param.WeightedNominationMainnetHeight, a coinbase transaction can nominate one validator
+// with one vote. After it, a coinbase transaction can split its vote among multiple validators
+// with different weights. In an epoch, NominatedCount is counted in blocks.
 type Nomination struct {
 	Pubkey         [32]byte // The validator's ED25519 pubkey used in tendermint
 	NominatedCount int64
@@ -105,6 +106,146 @@ type PendingReward struct {
 	Amount   [32]byte `msgp:"amount"`    // amount of rewards
 }
 
+// The delegation-related states of a validator, stored in its own slot after param.DelegationForkHeight
+type DelegationPool struct {
+	Pubkey             [32]byte `msgp:"pubkey"`               // the validator's pubkey
+	CommissionRate     uint64   `msgp:"commission_rate"`      // in basis points, taken from the delegators' share of rewards
+	CommissionEpochNum int64    `msgp:"commission_epoch_num"` // in which epoch was CommissionRate changed last time?
+	TotalDelegated     [32]byte `msgp:"total_delegated"`      // the delegated coins, which are slashed with the validator
+	TotalShares        [32]byte `msgp:"total_shares"`         // the shares of all the delegations in TotalDelegated
+	RewardPerShare     [32]byte `msgp:"reward_per_share"`     // accumulated rewards per share, scaled up by RewardPerSharePrecision
+	NumDelegators      int64    `msgp:"num_delegators"`
+	CoinBlocks         [32]byte `msgp:"coin_blocks"`        // TotalDelegated accumulated over the blocks of current epoch
+	CoinBlocksHeight   int64    `msgp:"coin_blocks_height"` // until which height CoinBlocks was accumulated
+}
+
+// A delegator's shares of the coins delegated to a validator
+type Delegation struct {
+	Delegator  [20]byte `msgp:"delegator"`
+	Pubkey     [32]byte `msgp:"pubkey"` // the validator's pubkey
+	Shares     [32]byte `msgp:"shares"`
+	RewardDebt [32]byte `msgp:"reward_debt"` // Shares*RewardPerShare which has already been settled
+}
+
+// Undelegated coins which cannot be withdrawn before MatureEpochNum
+type Unbonding struct {
+	Pubkey         [32]byte `msgp:"pubkey"`
+	Amount         [32]byte `msgp:"amount"` // the undelegated coins, which are worth less if the validator is slashed
+	MatureEpochNum int64    `msgp:"mature_epoch_num"`
+	Shares         [32]byte `msgp:"shares"` // the shares of the UnbondingPool with the same Pubkey and MatureEpochNum
+}
+
+// The coins undelegated from a validator which mature at the same epoch. They are slashed with the validator
+// before MatureEpochNum, which lowers the value of every Unbonding's shares.
+type UnbondingPool struct {
+	Pubkey         [32]byte `msgp:"pubkey"`
+	MatureEpochNum int64    `msgp:"mature_epoch_num"`
+	TotalAmount    [32]byte `msgp:"total_amount"`
+	TotalShares    [32]byte `msgp:"total_shares"`
+}
+
+// All the unbonding entries of a delegator
+type UnbondingList struct {
+	Delegator  [20]byte     `msgp:"delegator"`
+	Unbondings []*Unbonding `msgp:"unbondings"`
+}
+
+// Staked coins of a retired validator, which are paid back to RewardTo at MatureEpochNum.
+// They can still be slashed before then.
+type ValidatorUnbonding struct {
+	Address        [20]byte `msgp:"address"`
+	Pubkey         [32]byte `msgp:"pubkey"`
+	RewardTo       [20]byte `msgp:"reward_to"`
+	Amount         [32]byte `msgp:"amount"`
+	StartEpochNum  int64    `msgp:"start_epoch_num"`
+	MatureEpochNum int64    `msgp:"mature_epoch_num"`
+}
+
+// All the validator unbonding entries, ordered by StartEpochNum
+type ValidatorUnbondingList struct {
+	Unbondings []*ValidatorUnbonding `msgp:"unbondings"`
+}
+
+// How a validator's mature rewards are handled, stored in its own slot after param.RewardClaimingForkHeight
+type ValidatorRewardAccount struct {
+	Address     [20]byte `msgp:"address"`     // the validator's address
+	RewardMode  uint8    `msgp:"reward_mode"` // pay to RewardTo, accumulate or compound
+	Accumulated [32]byte `msgp:"accumulated"` // the rewards which can be withdrawn by withdrawRewards()
+}
+
+// A mature reward delivered to a validator when an epoch starts, after sharing it with the delegators
+type EpochReward struct {
+	Address  [20]byte `msgp:"address"`
+	RewardTo [20]byte `msgp:"reward_to"`
+	EpochNum int64    `msgp:"epoch_num"` // During which epoch was the reward got?
+	Amount   [32]byte `msgp:"amount"`
+}
+
+// The validators and the delivered rewards when an epoch starts, saved after param.EpochSnapshotForkHeight
+type EpochSnapshot struct {
+	EpochNum   int64          `msgp:"epoch_num"`
+	Validators []*Validator   `msgp:"validators"`
+	Rewards    []*EpochReward `msgp:"rewards"`
+}
+
+// A validator which missed too many signatures in an online window. It gets no voting power
+// until it calls unjail() in or after ReleaseEpochNum.
+type JailedValidator struct {
+	Address         [20]byte `msgp:"address"`
+	Pubkey          [32]byte `msgp:"pubkey"`
+	JailedEpochNum  int64    `msgp:"jailed_epoch_num"`
+	ReleaseEpochNum int64    `msgp:"release_epoch_num"`
+}
+
+// All the jailed validators, ordered by JailedEpochNum
+type JailedValidatorList struct {
+	Jailed []*JailedValidator `msgp:"jailed"`
+}
+
+// Change the list into a map with pubkeys as keys
+func (l *JailedValidatorList) GetMapByPubkey() map[[32]byte]*JailedValidator {
+	res := make(map[[32]byte]*JailedValidator, len(l.Jailed))
+	for _, j := range l.Jailed {
+		res[j.Pubkey] = j
+	}
+	return res
+}
+
+// A validator's request to change its consensus pubkey, which takes effect at the next valid epoch switch
+type ConsensusKeyRotation struct {
+	Address   [20]byte `msgp:"address"`
+	OldPubkey [32]byte `msgp:"old_pubkey"`
+	NewPubkey [32]byte `msgp:"new_pubkey"`
+}
+
+// All the pending consensus key rotations, at most one for each validator
+type ConsensusKeyRotationList struct {
+	Rotations []*ConsensusKeyRotation `msgp:"rotations"`
+}
+
+// A consensus parameter changed by governance, stored in its own slot after param.ParamGovernanceForkHeight
+type GovParam struct {
+	ID               uint64 `msgp:"id"`
+	Value            uint64 `msgp:"value"`             // the value used before ActivationHeight
+	NewValue         uint64 `msgp:"new_value"`         // the value used since ActivationHeight
+	ActivationHeight int64  `msgp:"activation_height"` // zero if no change is scheduled
+}
+
+type ParamVote struct {
+	Voter   [20]byte `msgp:"voter"`
+	Approve bool     `msgp:"approve"`
+}
+
+// The ongoing proposal to change a consensus parameter. There is at most one such proposal.
+type ParamProposal struct {
+	Proposer         [20]byte     `msgp:"proposer"`
+	ParamID          uint64       `msgp:"param_id"`
+	Value            uint64       `msgp:"value"`
+	ActivationHeight int64        `msgp:"activation_height"`
+	Deadline         uint64       `msgp:"deadline"` // votes are accepted before this timestamp
+	Votes            []*ParamVote `msgp:"votes"`
+}
+
 // This struct is stored in the world state.
 // All the staking-related operations manipulate it.
 type StakingInfo struct {
@@ -115,6 +256,19 @@ type StakingInfo struct {
 	PendingRewards            []*PendingReward `msgp:"pending_rewards"`
 }
 
+// After param.StakingInfoStorageForkHeight, StakingInfo's validators and pending rewards are stored in
+// their own slots, and this index tells how to find them
+type StakingInfoIndex struct {
+	ValidatorAddrs    [][20]byte          `msgp:"validator_addrs"`     // in the same order as StakingInfo.Validators
+	PendingRewardKeys []*PendingRewardKey `msgp:"pending_reward_keys"` // in the same order as StakingInfo.PendingRewards
+}
+
+// The validator and the epoch of a pending reward, which locate its slot
+type PendingRewardKey struct {
+	Address  [20]byte `msgp:"address"`
+	EpochNum int64    `msgp:"epoch_num"`
+}
+
 // Change si.Validators into a map with pubkeys as keys
 func (si *StakingInfo) GetValMapByPubkey() map[[32]byte]*Validator {
 	res := make(map[[32]byte]*Validator, len(si.Validators))
@@ -240,6 +394,13 @@ func GetUpdateValidatorSet(currentValidators, newValidators []*Validator) []*Val
 			removedV := *v
 			removedV.VotingPower = 0
 			updatedList = append(updatedList, &removedV)
+		} else if v.Pubkey != newValMap[v.Address].Pubkey {
+			// the consensus pubkey was rotated, so the old one is removed and the new one is added
+			removedV := *v
+			removedV.VotingPower = 0
+			updatedV := *newValMap[v.Address]
+			updatedList = append(updatedList, &removedV, &updatedV)
+			delete(newValMap, v.Address)
 		} else if v.VotingPower != newValMap[v.Address].VotingPower {
 			updatedV := *newValMap[v.Address]
 			updatedList = append(updatedList, &updatedV)
@@ -253,6 +414,9 @@ func GetUpdateValidatorSet(currentValidators, newValidators []*Validator) []*Val
 		updatedList = append(updatedList, &addedV)
 	}
 	sort.Slice(updatedList, func(i, j int) bool {
+		if updatedList[i].Address == updatedList[j].Address { // only when the pubkey was rotated
+			return bytes.Compare(updatedList[i].Pubkey[:], updatedList[j].Pubkey[:]) < 0
+		}
 		return bytes.Compare(updatedList[i].Address[:], updatedList[j].Address[:]) < 0
 	})
 	return updatedList
@@ -268,3 +432,62 @@ type OnlineInfo struct {
 	SignatureCount            int32    `msgp:"signature_count"`
 	HeightOfLastSignature     int64    `msgp:"height_of_last_signature"`
 }
+
+// A validator's signing record in an uptime window of GovParamOnlineWindowSize blocks. In the bitmaps, the
+// i-th bit stands for the block at StartHeight+i. A validator signs a block if its signature is included
+// in this block's last commit, just like in OnlineInfo. A block is in none of SignedBitmap and MissedBitmap
+// if the validator was not active then.
+type UptimeRecord struct {
+	Address        [20]byte `msgp:"address"`
+	StartHeight    int64    `msgp:"start_height"`
+	SignedBitmap   []byte   `msgp:"signed_bitmap"`
+	MissedBitmap   []byte   `msgp:"missed_bitmap"`
+	ProposedBitmap []byte   `msgp:"proposed_bitmap"`
+}
+
+// The current uptime window and the validators having records in it, stored after param.UptimeHistoryForkHeight.
+// The bitmaps of the records are stored in their own slots, so a block only writes the bits it sets.
+type UptimeWindow struct {
+	StartHeight int64      `msgp:"start_height"`
+	Size        int64      `msgp:"size"` // GovParamOnlineWindowSize when the window started
+	Addresses   [][20]byte `msgp:"addresses"`
+}
+
+// A duplicate vote evidence against a validator, and the coins slashed for it
+type DuplicateSigRecord struct {
+	Height  int64    `msgp:"height"`
+	Pubkey  [32]byte `msgp:"pubkey"`
+	Slashed [32]byte `msgp:"slashed"`
+}
+
+// A validator's records of the past uptime windows, the oldest first and at most param.UptimeHistoryWindowCount
+type UptimeHistory struct {
+	Records       []*UptimeRecord       `msgp:"records"`
+	DuplicateSigs []*DuplicateSigRecord `msgp:"duplicate_sigs"`
+}
+
+// A slash of a validator, with the coins burnt for it. Reason is the same as the Slashed event's.
+type SlashRecord struct {
+	Height    int64    `msgp:"height"`
+	Validator [20]byte `msgp:"validator"`
+	Pubkey    [32]byte `msgp:"pubkey"`
+	Reason    uint8    `msgp:"reason"`
+	Burnt     [32]byte `msgp:"burnt"`
+}
+
+// The latest slashes, the oldest first and at most param.SlashHistoryMaxCount, stored after param.SlashHistoryForkHeight
+type SlashHistory struct {
+	Records []*SlashRecord `msgp:"records"`
+}
+
+// A log emitted by the staking contract outside of any tx
+type SystemLog struct {
+	Address [20]byte   `msgp:"address"`
+	Topics  [][32]byte `msgp:"topics"`
+	Data    []byte     `msgp:"data"`
+}
+
+// The logs emitted in a block's Commit, which are kept until they are written into the history store
+type SystemLogList struct {
+	Logs []*SystemLog `msgp:"logs"`
+}
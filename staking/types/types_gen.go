@@ -7,7 +7,7 @@ import (
 )
 
 // DecodeMsg implements msgp.Decodable
-func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *ConsensusKeyRotation) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -24,84 +24,24 @@ func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Number":
-			z.Number, err = dc.ReadInt64()
-			if err != nil {
-				err = msgp.WrapError(err, "Number")
-				return
-			}
-		case "StartHeight":
-			z.StartHeight, err = dc.ReadInt64()
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "StartHeight")
+				err = msgp.WrapError(err, "Address")
 				return
 			}
-		case "EndTime":
-			z.EndTime, err = dc.ReadInt64()
+		case "OldPubkey":
+			err = dc.ReadExactBytes((z.OldPubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "EndTime")
+				err = msgp.WrapError(err, "OldPubkey")
 				return
 			}
-		case "Nominations":
-			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+		case "NewPubkey":
+			err = dc.ReadExactBytes((z.NewPubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Nominations")
+				err = msgp.WrapError(err, "NewPubkey")
 				return
 			}
-			if cap(z.Nominations) >= int(zb0002) {
-				z.Nominations = (z.Nominations)[:zb0002]
-			} else {
-				z.Nominations = make([]*Nomination, zb0002)
-			}
-			for za0001 := range z.Nominations {
-				if dc.IsNil() {
-					err = dc.ReadNil()
-					if err != nil {
-						err = msgp.WrapError(err, "Nominations", za0001)
-						return
-					}
-					z.Nominations[za0001] = nil
-				} else {
-					if z.Nominations[za0001] == nil {
-						z.Nominations[za0001] = new(Nomination)
-					}
-					var zb0003 uint32
-					zb0003, err = dc.ReadMapHeader()
-					if err != nil {
-						err = msgp.WrapError(err, "Nominations", za0001)
-						return
-					}
-					for zb0003 > 0 {
-						zb0003--
-						field, err = dc.ReadMapKeyPtr()
-						if err != nil {
-							err = msgp.WrapError(err, "Nominations", za0001)
-							return
-						}
-						switch msgp.UnsafeString(field) {
-						case "Pubkey":
-							err = dc.ReadExactBytes((z.Nominations[za0001].Pubkey)[:])
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
-								return
-							}
-						case "NominatedCount":
-							z.Nominations[za0001].NominatedCount, err = dc.ReadInt64()
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
-								return
-							}
-						default:
-							err = dc.Skip()
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001)
-								return
-							}
-						}
-					}
-				}
-			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -114,115 +54,59 @@ func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *Epoch) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
-	// write "Number"
-	err = en.Append(0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
-	if err != nil {
-		return
-	}
-	err = en.WriteInt64(z.Number)
-	if err != nil {
-		err = msgp.WrapError(err, "Number")
-		return
-	}
-	// write "StartHeight"
-	err = en.Append(0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+func (z *ConsensusKeyRotation) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Address"
+	err = en.Append(0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.StartHeight)
+	err = en.WriteBytes((z.Address)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "StartHeight")
+		err = msgp.WrapError(err, "Address")
 		return
 	}
-	// write "EndTime"
-	err = en.Append(0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
+	// write "OldPubkey"
+	err = en.Append(0xa9, 0x4f, 0x6c, 0x64, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.EndTime)
+	err = en.WriteBytes((z.OldPubkey)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "EndTime")
+		err = msgp.WrapError(err, "OldPubkey")
 		return
 	}
-	// write "Nominations"
-	err = en.Append(0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	// write "NewPubkey"
+	err = en.Append(0xa9, 0x4e, 0x65, 0x77, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Nominations)))
+	err = en.WriteBytes((z.NewPubkey)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "Nominations")
+		err = msgp.WrapError(err, "NewPubkey")
 		return
 	}
-	for za0001 := range z.Nominations {
-		if z.Nominations[za0001] == nil {
-			err = en.WriteNil()
-			if err != nil {
-				return
-			}
-		} else {
-			// map header, size 2
-			// write "Pubkey"
-			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-			if err != nil {
-				return
-			}
-			err = en.WriteBytes((z.Nominations[za0001].Pubkey)[:])
-			if err != nil {
-				err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
-				return
-			}
-			// write "NominatedCount"
-			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-			if err != nil {
-				return
-			}
-			err = en.WriteInt64(z.Nominations[za0001].NominatedCount)
-			if err != nil {
-				err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
-				return
-			}
-		}
-	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *Epoch) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *ConsensusKeyRotation) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
-	// string "Number"
-	o = append(o, 0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
-	o = msgp.AppendInt64(o, z.Number)
-	// string "StartHeight"
-	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendInt64(o, z.StartHeight)
-	// string "EndTime"
-	o = append(o, 0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
-	o = msgp.AppendInt64(o, z.EndTime)
-	// string "Nominations"
-	o = append(o, 0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Nominations)))
-	for za0001 := range z.Nominations {
-		if z.Nominations[za0001] == nil {
-			o = msgp.AppendNil(o)
-		} else {
-			// map header, size 2
-			// string "Pubkey"
-			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-			o = msgp.AppendBytes(o, (z.Nominations[za0001].Pubkey)[:])
-			// string "NominatedCount"
-			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-			o = msgp.AppendInt64(o, z.Nominations[za0001].NominatedCount)
-		}
-	}
+	// map header, size 3
+	// string "Address"
+	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "OldPubkey"
+	o = append(o, 0xa9, 0x4f, 0x6c, 0x64, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.OldPubkey)[:])
+	// string "NewPubkey"
+	o = append(o, 0xa9, 0x4e, 0x65, 0x77, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.NewPubkey)[:])
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *ConsensusKeyRotation) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -239,83 +123,24 @@ func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Number":
-			z.Number, bts, err = msgp.ReadInt64Bytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Number")
-				return
-			}
-		case "StartHeight":
-			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "StartHeight")
+				err = msgp.WrapError(err, "Address")
 				return
 			}
-		case "EndTime":
-			z.EndTime, bts, err = msgp.ReadInt64Bytes(bts)
+		case "OldPubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.OldPubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "EndTime")
+				err = msgp.WrapError(err, "OldPubkey")
 				return
 			}
-		case "Nominations":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "NewPubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.NewPubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Nominations")
+				err = msgp.WrapError(err, "NewPubkey")
 				return
 			}
-			if cap(z.Nominations) >= int(zb0002) {
-				z.Nominations = (z.Nominations)[:zb0002]
-			} else {
-				z.Nominations = make([]*Nomination, zb0002)
-			}
-			for za0001 := range z.Nominations {
-				if msgp.IsNil(bts) {
-					bts, err = msgp.ReadNilBytes(bts)
-					if err != nil {
-						return
-					}
-					z.Nominations[za0001] = nil
-				} else {
-					if z.Nominations[za0001] == nil {
-						z.Nominations[za0001] = new(Nomination)
-					}
-					var zb0003 uint32
-					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Nominations", za0001)
-						return
-					}
-					for zb0003 > 0 {
-						zb0003--
-						field, bts, err = msgp.ReadMapKeyZC(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Nominations", za0001)
-							return
-						}
-						switch msgp.UnsafeString(field) {
-						case "Pubkey":
-							bts, err = msgp.ReadExactBytes(bts, (z.Nominations[za0001].Pubkey)[:])
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
-								return
-							}
-						case "NominatedCount":
-							z.Nominations[za0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
-								return
-							}
-						default:
-							bts, err = msgp.Skip(bts)
-							if err != nil {
-								err = msgp.WrapError(err, "Nominations", za0001)
-								return
-							}
-						}
-					}
-				}
-			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -329,20 +154,13 @@ func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *Epoch) Msgsize() (s int) {
-	s = 1 + 7 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize
-	for za0001 := range z.Nominations {
-		if z.Nominations[za0001] == nil {
-			s += msgp.NilSize
-		} else {
-			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
-		}
-	}
+func (z *ConsensusKeyRotation) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 10 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 10 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *ConsensusKeyRotationList) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -359,17 +177,36 @@ func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Pubkey":
-			err = dc.ReadExactBytes((z.Pubkey)[:])
+		case "Rotations":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "Pubkey")
+				err = msgp.WrapError(err, "Rotations")
 				return
 			}
-		case "NominatedCount":
-			z.NominatedCount, err = dc.ReadInt64()
-			if err != nil {
-				err = msgp.WrapError(err, "NominatedCount")
-				return
+			if cap(z.Rotations) >= int(zb0002) {
+				z.Rotations = (z.Rotations)[:zb0002]
+			} else {
+				z.Rotations = make([]*ConsensusKeyRotation, zb0002)
+			}
+			for za0001 := range z.Rotations {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Rotations", za0001)
+						return
+					}
+					z.Rotations[za0001] = nil
+				} else {
+					if z.Rotations[za0001] == nil {
+						z.Rotations[za0001] = new(ConsensusKeyRotation)
+					}
+					err = z.Rotations[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Rotations", za0001)
+						return
+					}
+				}
 			}
 		default:
 			err = dc.Skip()
@@ -383,46 +220,58 @@ func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *Nomination) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
-	// write "Pubkey"
-	err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+func (z *ConsensusKeyRotationList) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Rotations"
+	err = en.Append(0x81, 0xa9, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.Pubkey)[:])
-	if err != nil {
-		err = msgp.WrapError(err, "Pubkey")
-		return
-	}
-	// write "NominatedCount"
-	err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	err = en.WriteArrayHeader(uint32(len(z.Rotations)))
 	if err != nil {
+		err = msgp.WrapError(err, "Rotations")
 		return
 	}
-	err = en.WriteInt64(z.NominatedCount)
-	if err != nil {
-		err = msgp.WrapError(err, "NominatedCount")
-		return
+	for za0001 := range z.Rotations {
+		if z.Rotations[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Rotations[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Rotations", za0001)
+				return
+			}
+		}
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *Nomination) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *ConsensusKeyRotationList) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "Pubkey"
-	o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-	o = msgp.AppendBytes(o, (z.Pubkey)[:])
-	// string "NominatedCount"
-	o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-	o = msgp.AppendInt64(o, z.NominatedCount)
+	// map header, size 1
+	// string "Rotations"
+	o = append(o, 0x81, 0xa9, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Rotations)))
+	for za0001 := range z.Rotations {
+		if z.Rotations[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Rotations[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Rotations", za0001)
+				return
+			}
+		}
+	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *ConsensusKeyRotationList) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -439,17 +288,35 @@ func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Pubkey":
-			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+		case "Rotations":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Pubkey")
+				err = msgp.WrapError(err, "Rotations")
 				return
 			}
-		case "NominatedCount":
-			z.NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "NominatedCount")
-				return
+			if cap(z.Rotations) >= int(zb0002) {
+				z.Rotations = (z.Rotations)[:zb0002]
+			} else {
+				z.Rotations = make([]*ConsensusKeyRotation, zb0002)
+			}
+			for za0001 := range z.Rotations {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Rotations[za0001] = nil
+				} else {
+					if z.Rotations[za0001] == nil {
+						z.Rotations[za0001] = new(ConsensusKeyRotation)
+					}
+					bts, err = z.Rotations[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Rotations", za0001)
+						return
+					}
+				}
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -464,71 +331,65 @@ func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *Nomination) Msgsize() (s int) {
-	s = 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+func (z *ConsensusKeyRotationList) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize
+	for za0001 := range z.Rotations {
+		if z.Rotations[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Rotations[za0001].Msgsize()
+		}
+	}
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *NominationHeap) DecodeMsg(dc *msgp.Reader) (err error) {
-	var zb0003 uint32
-	zb0003, err = dc.ReadArrayHeader()
+func (z *Delegation) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
 	if err != nil {
 		err = msgp.WrapError(err)
 		return
 	}
-	if cap((*z)) >= int(zb0003) {
-		(*z) = (*z)[:zb0003]
-	} else {
-		(*z) = make(NominationHeap, zb0003)
-	}
-	for zb0001 := range *z {
-		if dc.IsNil() {
-			err = dc.ReadNil()
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Delegator":
+			err = dc.ReadExactBytes((z.Delegator)[:])
 			if err != nil {
-				err = msgp.WrapError(err, zb0001)
+				err = msgp.WrapError(err, "Delegator")
 				return
 			}
-			(*z)[zb0001] = nil
-		} else {
-			if (*z)[zb0001] == nil {
-				(*z)[zb0001] = new(Nomination)
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
 			}
-			var field []byte
-			_ = field
-			var zb0004 uint32
-			zb0004, err = dc.ReadMapHeader()
+		case "Shares":
+			err = dc.ReadExactBytes((z.Shares)[:])
 			if err != nil {
-				err = msgp.WrapError(err, zb0001)
+				err = msgp.WrapError(err, "Shares")
 				return
 			}
-			for zb0004 > 0 {
-				zb0004--
-				field, err = dc.ReadMapKeyPtr()
-				if err != nil {
-					err = msgp.WrapError(err, zb0001)
-					return
-				}
-				switch msgp.UnsafeString(field) {
-				case "Pubkey":
-					err = dc.ReadExactBytes(((*z)[zb0001].Pubkey)[:])
-					if err != nil {
-						err = msgp.WrapError(err, zb0001, "Pubkey")
-						return
-					}
-				case "NominatedCount":
-					(*z)[zb0001].NominatedCount, err = dc.ReadInt64()
-					if err != nil {
-						err = msgp.WrapError(err, zb0001, "NominatedCount")
-						return
-					}
-				default:
-					err = dc.Skip()
-					if err != nil {
-						err = msgp.WrapError(err, zb0001)
-						return
-					}
-				}
+		case "RewardDebt":
+			err = dc.ReadExactBytes((z.RewardDebt)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "RewardDebt")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
 			}
 		}
 	}
@@ -536,146 +397,132 @@ func (z *NominationHeap) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z NominationHeap) EncodeMsg(en *msgp.Writer) (err error) {
-	err = en.WriteArrayHeader(uint32(len(z)))
+func (z *Delegation) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Delegator"
+	err = en.Append(0x84, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Delegator)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Delegator")
+		return
+	}
+	// write "Pubkey"
+	err = en.Append(0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "Shares"
+	err = en.Append(0xa6, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Shares)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Shares")
+		return
+	}
+	// write "RewardDebt"
+	err = en.Append(0xaa, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x44, 0x65, 0x62, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.RewardDebt)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "RewardDebt")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Delegation) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Delegator"
+	o = append(o, 0x84, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Delegator)[:])
+	// string "Pubkey"
+	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "Shares"
+	o = append(o, 0xa6, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
+	o = msgp.AppendBytes(o, (z.Shares)[:])
+	// string "RewardDebt"
+	o = append(o, 0xaa, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x44, 0x65, 0x62, 0x74)
+	o = msgp.AppendBytes(o, (z.RewardDebt)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Delegation) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
 	if err != nil {
 		err = msgp.WrapError(err)
 		return
 	}
-	for zb0005 := range z {
-		if z[zb0005] == nil {
-			err = en.WriteNil()
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Delegator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Delegator)[:])
 			if err != nil {
+				err = msgp.WrapError(err, "Delegator")
 				return
 			}
-		} else {
-			// map header, size 2
-			// write "Pubkey"
-			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
 			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
 				return
 			}
-			err = en.WriteBytes((z[zb0005].Pubkey)[:])
+		case "Shares":
+			bts, err = msgp.ReadExactBytes(bts, (z.Shares)[:])
 			if err != nil {
-				err = msgp.WrapError(err, zb0005, "Pubkey")
+				err = msgp.WrapError(err, "Shares")
 				return
 			}
-			// write "NominatedCount"
-			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+		case "RewardDebt":
+			bts, err = msgp.ReadExactBytes(bts, (z.RewardDebt)[:])
 			if err != nil {
+				err = msgp.WrapError(err, "RewardDebt")
 				return
 			}
-			err = en.WriteInt64(z[zb0005].NominatedCount)
+		default:
+			bts, err = msgp.Skip(bts)
 			if err != nil {
-				err = msgp.WrapError(err, zb0005, "NominatedCount")
+				err = msgp.WrapError(err)
 				return
 			}
 		}
 	}
+	o = bts
 	return
 }
 
-// MarshalMsg implements msgp.Marshaler
-func (z NominationHeap) MarshalMsg(b []byte) (o []byte, err error) {
-	o = msgp.Require(b, z.Msgsize())
-	o = msgp.AppendArrayHeader(o, uint32(len(z)))
-	for zb0005 := range z {
-		if z[zb0005] == nil {
-			o = msgp.AppendNil(o)
-		} else {
-			// map header, size 2
-			// string "Pubkey"
-			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
-			o = msgp.AppendBytes(o, (z[zb0005].Pubkey)[:])
-			// string "NominatedCount"
-			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-			o = msgp.AppendInt64(o, z[zb0005].NominatedCount)
-		}
-	}
-	return
-}
-
-// UnmarshalMsg implements msgp.Unmarshaler
-func (z *NominationHeap) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	var zb0003 uint32
-	zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
-	if err != nil {
-		err = msgp.WrapError(err)
-		return
-	}
-	if cap((*z)) >= int(zb0003) {
-		(*z) = (*z)[:zb0003]
-	} else {
-		(*z) = make(NominationHeap, zb0003)
-	}
-	for zb0001 := range *z {
-		if msgp.IsNil(bts) {
-			bts, err = msgp.ReadNilBytes(bts)
-			if err != nil {
-				return
-			}
-			(*z)[zb0001] = nil
-		} else {
-			if (*z)[zb0001] == nil {
-				(*z)[zb0001] = new(Nomination)
-			}
-			var field []byte
-			_ = field
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, zb0001)
-				return
-			}
-			for zb0004 > 0 {
-				zb0004--
-				field, bts, err = msgp.ReadMapKeyZC(bts)
-				if err != nil {
-					err = msgp.WrapError(err, zb0001)
-					return
-				}
-				switch msgp.UnsafeString(field) {
-				case "Pubkey":
-					bts, err = msgp.ReadExactBytes(bts, ((*z)[zb0001].Pubkey)[:])
-					if err != nil {
-						err = msgp.WrapError(err, zb0001, "Pubkey")
-						return
-					}
-				case "NominatedCount":
-					(*z)[zb0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
-					if err != nil {
-						err = msgp.WrapError(err, zb0001, "NominatedCount")
-						return
-					}
-				default:
-					bts, err = msgp.Skip(bts)
-					if err != nil {
-						err = msgp.WrapError(err, zb0001)
-						return
-					}
-				}
-			}
-		}
-	}
-	o = bts
-	return
-}
-
-// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z NominationHeap) Msgsize() (s int) {
-	s = msgp.ArrayHeaderSize
-	for zb0005 := range z {
-		if z[zb0005] == nil {
-			s += msgp.NilSize
-		} else {
-			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
-		}
-	}
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Delegation) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 11 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *DelegationPool) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -692,22 +539,58 @@ func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "ValidatorConsensusAddress":
-			err = dc.ReadExactBytes((z.ValidatorConsensusAddress)[:])
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorConsensusAddress")
+				err = msgp.WrapError(err, "Pubkey")
 				return
 			}
-		case "SignatureCount":
-			z.SignatureCount, err = dc.ReadInt32()
+		case "CommissionRate":
+			z.CommissionRate, err = dc.ReadUint64()
 			if err != nil {
-				err = msgp.WrapError(err, "SignatureCount")
+				err = msgp.WrapError(err, "CommissionRate")
 				return
 			}
-		case "HeightOfLastSignature":
-			z.HeightOfLastSignature, err = dc.ReadInt64()
+		case "CommissionEpochNum":
+			z.CommissionEpochNum, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "HeightOfLastSignature")
+				err = msgp.WrapError(err, "CommissionEpochNum")
+				return
+			}
+		case "TotalDelegated":
+			err = dc.ReadExactBytes((z.TotalDelegated)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalDelegated")
+				return
+			}
+		case "TotalShares":
+			err = dc.ReadExactBytes((z.TotalShares)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalShares")
+				return
+			}
+		case "RewardPerShare":
+			err = dc.ReadExactBytes((z.RewardPerShare)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "RewardPerShare")
+				return
+			}
+		case "NumDelegators":
+			z.NumDelegators, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "NumDelegators")
+				return
+			}
+		case "CoinBlocks":
+			err = dc.ReadExactBytes((z.CoinBlocks)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "CoinBlocks")
+				return
+			}
+		case "CoinBlocksHeight":
+			z.CoinBlocksHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "CoinBlocksHeight")
 				return
 			}
 		default:
@@ -722,59 +605,137 @@ func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *OnlineInfo) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "ValidatorConsensusAddress"
-	err = en.Append(0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+func (z *DelegationPool) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 9
+	// write "Pubkey"
+	err = en.Append(0x89, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.ValidatorConsensusAddress)[:])
+	err = en.WriteBytes((z.Pubkey)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "ValidatorConsensusAddress")
+		err = msgp.WrapError(err, "Pubkey")
 		return
 	}
-	// write "SignatureCount"
-	err = en.Append(0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	// write "CommissionRate"
+	err = en.Append(0xae, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt32(z.SignatureCount)
+	err = en.WriteUint64(z.CommissionRate)
 	if err != nil {
-		err = msgp.WrapError(err, "SignatureCount")
+		err = msgp.WrapError(err, "CommissionRate")
 		return
 	}
-	// write "HeightOfLastSignature"
-	err = en.Append(0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	// write "CommissionEpochNum"
+	err = en.Append(0xb2, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.HeightOfLastSignature)
+	err = en.WriteInt64(z.CommissionEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "CommissionEpochNum")
+		return
+	}
+	// write "TotalDelegated"
+	err = en.Append(0xae, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.TotalDelegated)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "TotalDelegated")
+		return
+	}
+	// write "TotalShares"
+	err = en.Append(0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
 	if err != nil {
-		err = msgp.WrapError(err, "HeightOfLastSignature")
+		return
+	}
+	err = en.WriteBytes((z.TotalShares)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "TotalShares")
+		return
+	}
+	// write "RewardPerShare"
+	err = en.Append(0xae, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x50, 0x65, 0x72, 0x53, 0x68, 0x61, 0x72, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.RewardPerShare)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "RewardPerShare")
+		return
+	}
+	// write "NumDelegators"
+	err = en.Append(0xad, 0x4e, 0x75, 0x6d, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.NumDelegators)
+	if err != nil {
+		err = msgp.WrapError(err, "NumDelegators")
+		return
+	}
+	// write "CoinBlocks"
+	err = en.Append(0xaa, 0x43, 0x6f, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.CoinBlocks)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "CoinBlocks")
+		return
+	}
+	// write "CoinBlocksHeight"
+	err = en.Append(0xb0, 0x43, 0x6f, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.CoinBlocksHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "CoinBlocksHeight")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *OnlineInfo) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *DelegationPool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "ValidatorConsensusAddress"
-	o = append(o, 0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
-	o = msgp.AppendBytes(o, (z.ValidatorConsensusAddress)[:])
-	// string "SignatureCount"
-	o = append(o, 0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
-	o = msgp.AppendInt32(o, z.SignatureCount)
-	// string "HeightOfLastSignature"
-	o = append(o, 0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
-	o = msgp.AppendInt64(o, z.HeightOfLastSignature)
+	// map header, size 9
+	// string "Pubkey"
+	o = append(o, 0x89, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "CommissionRate"
+	o = append(o, 0xae, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65)
+	o = msgp.AppendUint64(o, z.CommissionRate)
+	// string "CommissionEpochNum"
+	o = append(o, 0xb2, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.CommissionEpochNum)
+	// string "TotalDelegated"
+	o = append(o, 0xae, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64)
+	o = msgp.AppendBytes(o, (z.TotalDelegated)[:])
+	// string "TotalShares"
+	o = append(o, 0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
+	o = msgp.AppendBytes(o, (z.TotalShares)[:])
+	// string "RewardPerShare"
+	o = append(o, 0xae, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x50, 0x65, 0x72, 0x53, 0x68, 0x61, 0x72, 0x65)
+	o = msgp.AppendBytes(o, (z.RewardPerShare)[:])
+	// string "NumDelegators"
+	o = append(o, 0xad, 0x4e, 0x75, 0x6d, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendInt64(o, z.NumDelegators)
+	// string "CoinBlocks"
+	o = append(o, 0xaa, 0x43, 0x6f, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73)
+	o = msgp.AppendBytes(o, (z.CoinBlocks)[:])
+	// string "CoinBlocksHeight"
+	o = append(o, 0xb0, 0x43, 0x6f, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.CoinBlocksHeight)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *DelegationPool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -791,22 +752,58 @@ func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "ValidatorConsensusAddress":
-			bts, err = msgp.ReadExactBytes(bts, (z.ValidatorConsensusAddress)[:])
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorConsensusAddress")
+				err = msgp.WrapError(err, "Pubkey")
 				return
 			}
-		case "SignatureCount":
-			z.SignatureCount, bts, err = msgp.ReadInt32Bytes(bts)
+		case "CommissionRate":
+			z.CommissionRate, bts, err = msgp.ReadUint64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "SignatureCount")
+				err = msgp.WrapError(err, "CommissionRate")
 				return
 			}
-		case "HeightOfLastSignature":
-			z.HeightOfLastSignature, bts, err = msgp.ReadInt64Bytes(bts)
+		case "CommissionEpochNum":
+			z.CommissionEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "HeightOfLastSignature")
+				err = msgp.WrapError(err, "CommissionEpochNum")
+				return
+			}
+		case "TotalDelegated":
+			bts, err = msgp.ReadExactBytes(bts, (z.TotalDelegated)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalDelegated")
+				return
+			}
+		case "TotalShares":
+			bts, err = msgp.ReadExactBytes(bts, (z.TotalShares)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalShares")
+				return
+			}
+		case "RewardPerShare":
+			bts, err = msgp.ReadExactBytes(bts, (z.RewardPerShare)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "RewardPerShare")
+				return
+			}
+		case "NumDelegators":
+			z.NumDelegators, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NumDelegators")
+				return
+			}
+		case "CoinBlocks":
+			bts, err = msgp.ReadExactBytes(bts, (z.CoinBlocks)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "CoinBlocks")
+				return
+			}
+		case "CoinBlocksHeight":
+			z.CoinBlocksHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CoinBlocksHeight")
 				return
 			}
 		default:
@@ -822,13 +819,13 @@ func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *OnlineInfo) Msgsize() (s int) {
-	s = 1 + 26 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 15 + msgp.Int32Size + 22 + msgp.Int64Size
+func (z *DelegationPool) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Uint64Size + 19 + msgp.Int64Size + 15 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 14 + msgp.Int64Size + 11 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 17 + msgp.Int64Size
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *DuplicateSigRecord) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -845,22 +842,22 @@ func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Address":
-			err = dc.ReadExactBytes((z.Address)[:])
+		case "Height":
+			z.Height, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "Address")
+				err = msgp.WrapError(err, "Height")
 				return
 			}
-		case "EpochNum":
-			z.EpochNum, err = dc.ReadInt64()
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "EpochNum")
+				err = msgp.WrapError(err, "Pubkey")
 				return
 			}
-		case "Amount":
-			err = dc.ReadExactBytes((z.Amount)[:])
+		case "Slashed":
+			err = dc.ReadExactBytes((z.Slashed)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Amount")
+				err = msgp.WrapError(err, "Slashed")
 				return
 			}
 		default:
@@ -875,59 +872,59 @@ func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *PendingReward) EncodeMsg(en *msgp.Writer) (err error) {
+func (z *DuplicateSigRecord) EncodeMsg(en *msgp.Writer) (err error) {
 	// map header, size 3
-	// write "Address"
-	err = en.Append(0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	// write "Height"
+	err = en.Append(0x83, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.Address)[:])
+	err = en.WriteInt64(z.Height)
 	if err != nil {
-		err = msgp.WrapError(err, "Address")
+		err = msgp.WrapError(err, "Height")
 		return
 	}
-	// write "EpochNum"
-	err = en.Append(0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	// write "Pubkey"
+	err = en.Append(0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.EpochNum)
+	err = en.WriteBytes((z.Pubkey)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "EpochNum")
+		err = msgp.WrapError(err, "Pubkey")
 		return
 	}
-	// write "Amount"
-	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	// write "Slashed"
+	err = en.Append(0xa7, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.Amount)[:])
+	err = en.WriteBytes((z.Slashed)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "Amount")
+		err = msgp.WrapError(err, "Slashed")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *PendingReward) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *DuplicateSigRecord) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 3
-	// string "Address"
-	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
-	o = msgp.AppendBytes(o, (z.Address)[:])
-	// string "EpochNum"
-	o = append(o, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
-	o = msgp.AppendInt64(o, z.EpochNum)
-	// string "Amount"
-	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
-	o = msgp.AppendBytes(o, (z.Amount)[:])
+	// string "Height"
+	o = append(o, 0x83, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.Height)
+	// string "Pubkey"
+	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "Slashed"
+	o = append(o, 0xa7, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64)
+	o = msgp.AppendBytes(o, (z.Slashed)[:])
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *PendingReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *DuplicateSigRecord) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -944,22 +941,22 @@ func (z *PendingReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "Address":
-			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+		case "Height":
+			z.Height, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Address")
+				err = msgp.WrapError(err, "Height")
 				return
 			}
-		case "EpochNum":
-			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "EpochNum")
+				err = msgp.WrapError(err, "Pubkey")
 				return
 			}
-		case "Amount":
-			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+		case "Slashed":
+			bts, err = msgp.ReadExactBytes(bts, (z.Slashed)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Amount")
+				err = msgp.WrapError(err, "Slashed")
 				return
 			}
 		default:
@@ -975,13 +972,13 @@ func (z *PendingReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *PendingReward) Msgsize() (s int) {
-	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+func (z *DuplicateSigRecord) Msgsize() (s int) {
+	s = 1 + 7 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 8 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *Epoch) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -998,108 +995,81 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "GenesisMainnetBlockHeight":
-			z.GenesisMainnetBlockHeight, err = dc.ReadInt64()
+		case "Number":
+			z.Number, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+				err = msgp.WrapError(err, "Number")
 				return
 			}
-		case "CurrEpochNum":
-			z.CurrEpochNum, err = dc.ReadInt64()
+		case "StartHeight":
+			z.StartHeight, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "CurrEpochNum")
+				err = msgp.WrapError(err, "StartHeight")
 				return
 			}
-		case "Validators":
-			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+		case "EndTime":
+			z.EndTime, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "Validators")
+				err = msgp.WrapError(err, "EndTime")
 				return
 			}
-			if cap(z.Validators) >= int(zb0002) {
-				z.Validators = (z.Validators)[:zb0002]
-			} else {
-				z.Validators = make([]*Validator, zb0002)
-			}
-			for za0001 := range z.Validators {
-				if dc.IsNil() {
-					err = dc.ReadNil()
-					if err != nil {
-						err = msgp.WrapError(err, "Validators", za0001)
-						return
-					}
-					z.Validators[za0001] = nil
-				} else {
-					if z.Validators[za0001] == nil {
-						z.Validators[za0001] = new(Validator)
-					}
-					err = z.Validators[za0001].DecodeMsg(dc)
-					if err != nil {
-						err = msgp.WrapError(err, "Validators", za0001)
-						return
-					}
-				}
-			}
-		case "ValidatorsUpdate":
-			var zb0003 uint32
-			zb0003, err = dc.ReadArrayHeader()
+		case "Nominations":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorsUpdate")
+				err = msgp.WrapError(err, "Nominations")
 				return
 			}
-			if cap(z.ValidatorsUpdate) >= int(zb0003) {
-				z.ValidatorsUpdate = (z.ValidatorsUpdate)[:zb0003]
+			if cap(z.Nominations) >= int(zb0002) {
+				z.Nominations = (z.Nominations)[:zb0002]
 			} else {
-				z.ValidatorsUpdate = make([]*Validator, zb0003)
+				z.Nominations = make([]*Nomination, zb0002)
 			}
-			for za0002 := range z.ValidatorsUpdate {
+			for za0001 := range z.Nominations {
 				if dc.IsNil() {
 					err = dc.ReadNil()
 					if err != nil {
-						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+						err = msgp.WrapError(err, "Nominations", za0001)
 						return
 					}
-					z.ValidatorsUpdate[za0002] = nil
+					z.Nominations[za0001] = nil
 				} else {
-					if z.ValidatorsUpdate[za0002] == nil {
-						z.ValidatorsUpdate[za0002] = new(Validator)
-					}
-					err = z.ValidatorsUpdate[za0002].DecodeMsg(dc)
-					if err != nil {
-						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
-						return
+					if z.Nominations[za0001] == nil {
+						z.Nominations[za0001] = new(Nomination)
 					}
-				}
-			}
-		case "PendingRewards":
-			var zb0004 uint32
-			zb0004, err = dc.ReadArrayHeader()
-			if err != nil {
-				err = msgp.WrapError(err, "PendingRewards")
-				return
-			}
-			if cap(z.PendingRewards) >= int(zb0004) {
-				z.PendingRewards = (z.PendingRewards)[:zb0004]
-			} else {
-				z.PendingRewards = make([]*PendingReward, zb0004)
-			}
-			for za0003 := range z.PendingRewards {
-				if dc.IsNil() {
-					err = dc.ReadNil()
+					var zb0003 uint32
+					zb0003, err = dc.ReadMapHeader()
 					if err != nil {
-						err = msgp.WrapError(err, "PendingRewards", za0003)
+						err = msgp.WrapError(err, "Nominations", za0001)
 						return
 					}
-					z.PendingRewards[za0003] = nil
-				} else {
-					if z.PendingRewards[za0003] == nil {
-						z.PendingRewards[za0003] = new(PendingReward)
-					}
-					err = z.PendingRewards[za0003].DecodeMsg(dc)
-					if err != nil {
-						err = msgp.WrapError(err, "PendingRewards", za0003)
-						return
+					for zb0003 > 0 {
+						zb0003--
+						field, err = dc.ReadMapKeyPtr()
+						if err != nil {
+							err = msgp.WrapError(err, "Nominations", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Pubkey":
+							err = dc.ReadExactBytes((z.Nominations[za0001].Pubkey)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
+								return
+							}
+						case "NominatedCount":
+							z.Nominations[za0001].NominatedCount, err = dc.ReadInt64()
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
+								return
+							}
+						default:
+							err = dc.Skip()
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001)
+								return
+							}
+						}
 					}
 				}
 			}
@@ -1115,96 +1085,74 @@ func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *StakingInfo) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 5
-	// write "GenesisMainnetBlockHeight"
-	err = en.Append(0x85, 0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+func (z *Epoch) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Number"
+	err = en.Append(0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.GenesisMainnetBlockHeight)
+	err = en.WriteInt64(z.Number)
 	if err != nil {
-		err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+		err = msgp.WrapError(err, "Number")
 		return
 	}
-	// write "CurrEpochNum"
-	err = en.Append(0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	// write "StartHeight"
+	err = en.Append(0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt64(z.CurrEpochNum)
+	err = en.WriteInt64(z.StartHeight)
 	if err != nil {
-		err = msgp.WrapError(err, "CurrEpochNum")
+		err = msgp.WrapError(err, "StartHeight")
 		return
 	}
-	// write "Validators"
-	err = en.Append(0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	// write "EndTime"
+	err = en.Append(0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Validators)))
+	err = en.WriteInt64(z.EndTime)
 	if err != nil {
-		err = msgp.WrapError(err, "Validators")
+		err = msgp.WrapError(err, "EndTime")
 		return
 	}
-	for za0001 := range z.Validators {
-		if z.Validators[za0001] == nil {
-			err = en.WriteNil()
-			if err != nil {
-				return
-			}
-		} else {
-			err = z.Validators[za0001].EncodeMsg(en)
-			if err != nil {
-				err = msgp.WrapError(err, "Validators", za0001)
-				return
-			}
-		}
-	}
-	// write "ValidatorsUpdate"
-	err = en.Append(0xb0, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
+	// write "Nominations"
+	err = en.Append(0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.ValidatorsUpdate)))
+	err = en.WriteArrayHeader(uint32(len(z.Nominations)))
 	if err != nil {
-		err = msgp.WrapError(err, "ValidatorsUpdate")
+		err = msgp.WrapError(err, "Nominations")
 		return
 	}
-	for za0002 := range z.ValidatorsUpdate {
-		if z.ValidatorsUpdate[za0002] == nil {
+	for za0001 := range z.Nominations {
+		if z.Nominations[za0001] == nil {
 			err = en.WriteNil()
 			if err != nil {
 				return
 			}
 		} else {
-			err = z.ValidatorsUpdate[za0002].EncodeMsg(en)
+			// map header, size 2
+			// write "Pubkey"
+			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
 				return
 			}
-		}
-	}
-	// write "PendingRewards"
-	err = en.Append(0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
-	if err != nil {
-		return
-	}
-	err = en.WriteArrayHeader(uint32(len(z.PendingRewards)))
-	if err != nil {
-		err = msgp.WrapError(err, "PendingRewards")
-		return
-	}
-	for za0003 := range z.PendingRewards {
-		if z.PendingRewards[za0003] == nil {
-			err = en.WriteNil()
+			err = en.WriteBytes((z.Nominations[za0001].Pubkey)[:])
 			if err != nil {
+				err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
 				return
 			}
-		} else {
-			err = z.PendingRewards[za0003].EncodeMsg(en)
+			// write "NominatedCount"
+			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
 			if err != nil {
-				err = msgp.WrapError(err, "PendingRewards", za0003)
+				return
+			}
+			err = en.WriteInt64(z.Nominations[za0001].NominatedCount)
+			if err != nil {
+				err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
 				return
 			}
 		}
@@ -1213,29 +1161,3114 @@ func (z *StakingInfo) EncodeMsg(en *msgp.Writer) (err error) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *StakingInfo) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *Epoch) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 5
-	// string "GenesisMainnetBlockHeight"
-	o = append(o, 0x85, 0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendInt64(o, z.GenesisMainnetBlockHeight)
-	// string "CurrEpochNum"
-	o = append(o, 0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
-	o = msgp.AppendInt64(o, z.CurrEpochNum)
-	// string "Validators"
-	o = append(o, 0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Validators)))
-	for za0001 := range z.Validators {
-		if z.Validators[za0001] == nil {
+	// map header, size 4
+	// string "Number"
+	o = append(o, 0x84, 0xa6, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72)
+	o = msgp.AppendInt64(o, z.Number)
+	// string "StartHeight"
+	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.StartHeight)
+	// string "EndTime"
+	o = append(o, 0xa7, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65)
+	o = msgp.AppendInt64(o, z.EndTime)
+	// string "Nominations"
+	o = append(o, 0xab, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Nominations)))
+	for za0001 := range z.Nominations {
+		if z.Nominations[za0001] == nil {
 			o = msgp.AppendNil(o)
 		} else {
-			o, err = z.Validators[za0001].MarshalMsg(o)
-			if err != nil {
-				err = msgp.WrapError(err, "Validators", za0001)
-				return
-			}
-		}
-	}
+			// map header, size 2
+			// string "Pubkey"
+			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+			o = msgp.AppendBytes(o, (z.Nominations[za0001].Pubkey)[:])
+			// string "NominatedCount"
+			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+			o = msgp.AppendInt64(o, z.Nominations[za0001].NominatedCount)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Epoch) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Number":
+			z.Number, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Number")
+				return
+			}
+		case "StartHeight":
+			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StartHeight")
+				return
+			}
+		case "EndTime":
+			z.EndTime, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EndTime")
+				return
+			}
+		case "Nominations":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nominations")
+				return
+			}
+			if cap(z.Nominations) >= int(zb0002) {
+				z.Nominations = (z.Nominations)[:zb0002]
+			} else {
+				z.Nominations = make([]*Nomination, zb0002)
+			}
+			for za0001 := range z.Nominations {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Nominations[za0001] = nil
+				} else {
+					if z.Nominations[za0001] == nil {
+						z.Nominations[za0001] = new(Nomination)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Nominations", za0001)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Nominations", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Pubkey":
+							bts, err = msgp.ReadExactBytes(bts, (z.Nominations[za0001].Pubkey)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "Pubkey")
+								return
+							}
+						case "NominatedCount":
+							z.Nominations[za0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001, "NominatedCount")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Nominations", za0001)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Epoch) Msgsize() (s int) {
+	s = 1 + 7 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize
+	for za0001 := range z.Nominations {
+		if z.Nominations[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *EpochReward) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "RewardTo":
+			err = dc.ReadExactBytes((z.RewardTo)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "RewardTo")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *EpochReward) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Address"
+	err = en.Append(0x84, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "RewardTo"
+	err = en.Append(0xa8, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x6f)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.RewardTo)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "RewardTo")
+		return
+	}
+	// write "EpochNum"
+	err = en.Append(0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.EpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "EpochNum")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Amount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *EpochReward) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Address"
+	o = append(o, 0x84, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "RewardTo"
+	o = append(o, 0xa8, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x6f)
+	o = msgp.AppendBytes(o, (z.RewardTo)[:])
+	// string "EpochNum"
+	o = append(o, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.EpochNum)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *EpochReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "RewardTo":
+			bts, err = msgp.ReadExactBytes(bts, (z.RewardTo)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "RewardTo")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *EpochReward) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *EpochSnapshot) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "EpochNum":
+			z.EpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Validators":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Validators")
+				return
+			}
+			if cap(z.Validators) >= int(zb0002) {
+				z.Validators = (z.Validators)[:zb0002]
+			} else {
+				z.Validators = make([]*Validator, zb0002)
+			}
+			for za0001 := range z.Validators {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+					z.Validators[za0001] = nil
+				} else {
+					if z.Validators[za0001] == nil {
+						z.Validators[za0001] = new(Validator)
+					}
+					err = z.Validators[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+				}
+			}
+		case "Rewards":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Rewards")
+				return
+			}
+			if cap(z.Rewards) >= int(zb0003) {
+				z.Rewards = (z.Rewards)[:zb0003]
+			} else {
+				z.Rewards = make([]*EpochReward, zb0003)
+			}
+			for za0002 := range z.Rewards {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Rewards", za0002)
+						return
+					}
+					z.Rewards[za0002] = nil
+				} else {
+					if z.Rewards[za0002] == nil {
+						z.Rewards[za0002] = new(EpochReward)
+					}
+					err = z.Rewards[za0002].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Rewards", za0002)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *EpochSnapshot) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "EpochNum"
+	err = en.Append(0x83, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.EpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "EpochNum")
+		return
+	}
+	// write "Validators"
+	err = en.Append(0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Validators)))
+	if err != nil {
+		err = msgp.WrapError(err, "Validators")
+		return
+	}
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Validators[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators", za0001)
+				return
+			}
+		}
+	}
+	// write "Rewards"
+	err = en.Append(0xa7, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Rewards)))
+	if err != nil {
+		err = msgp.WrapError(err, "Rewards")
+		return
+	}
+	for za0002 := range z.Rewards {
+		if z.Rewards[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Rewards[za0002].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Rewards", za0002)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *EpochSnapshot) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "EpochNum"
+	o = append(o, 0x83, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.EpochNum)
+	// string "Validators"
+	o = append(o, 0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Validators)))
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Validators[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators", za0001)
+				return
+			}
+		}
+	}
+	// string "Rewards"
+	o = append(o, 0xa7, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Rewards)))
+	for za0002 := range z.Rewards {
+		if z.Rewards[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Rewards[za0002].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Rewards", za0002)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *EpochSnapshot) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "EpochNum":
+			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Validators":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators")
+				return
+			}
+			if cap(z.Validators) >= int(zb0002) {
+				z.Validators = (z.Validators)[:zb0002]
+			} else {
+				z.Validators = make([]*Validator, zb0002)
+			}
+			for za0001 := range z.Validators {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Validators[za0001] = nil
+				} else {
+					if z.Validators[za0001] == nil {
+						z.Validators[za0001] = new(Validator)
+					}
+					bts, err = z.Validators[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+				}
+			}
+		case "Rewards":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Rewards")
+				return
+			}
+			if cap(z.Rewards) >= int(zb0003) {
+				z.Rewards = (z.Rewards)[:zb0003]
+			} else {
+				z.Rewards = make([]*EpochReward, zb0003)
+			}
+			for za0002 := range z.Rewards {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Rewards[za0002] = nil
+				} else {
+					if z.Rewards[za0002] == nil {
+						z.Rewards[za0002] = new(EpochReward)
+					}
+					bts, err = z.Rewards[za0002].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Rewards", za0002)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *EpochSnapshot) Msgsize() (s int) {
+	s = 1 + 9 + msgp.Int64Size + 11 + msgp.ArrayHeaderSize
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Validators[za0001].Msgsize()
+		}
+	}
+	s += 8 + msgp.ArrayHeaderSize
+	for za0002 := range z.Rewards {
+		if z.Rewards[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Rewards[za0002].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *GovParam) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "Value":
+			z.Value, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "NewValue":
+			z.NewValue, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "NewValue")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *GovParam) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "ID"
+	err = en.Append(0x84, 0xa2, 0x49, 0x44)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ID)
+	if err != nil {
+		err = msgp.WrapError(err, "ID")
+		return
+	}
+	// write "Value"
+	err = en.Append(0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Value)
+	if err != nil {
+		err = msgp.WrapError(err, "Value")
+		return
+	}
+	// write "NewValue"
+	err = en.Append(0xa8, 0x4e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.NewValue)
+	if err != nil {
+		err = msgp.WrapError(err, "NewValue")
+		return
+	}
+	// write "ActivationHeight"
+	err = en.Append(0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ActivationHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "ActivationHeight")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *GovParam) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "ID"
+	o = append(o, 0x84, 0xa2, 0x49, 0x44)
+	o = msgp.AppendUint64(o, z.ID)
+	// string "Value"
+	o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o = msgp.AppendUint64(o, z.Value)
+	// string "NewValue"
+	o = append(o, 0xa8, 0x4e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o = msgp.AppendUint64(o, z.NewValue)
+	// string "ActivationHeight"
+	o = append(o, 0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.ActivationHeight)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *GovParam) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "Value":
+			z.Value, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "NewValue":
+			z.NewValue, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NewValue")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *GovParam) Msgsize() (s int) {
+	s = 1 + 3 + msgp.Uint64Size + 6 + msgp.Uint64Size + 9 + msgp.Uint64Size + 17 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *JailedValidator) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "JailedEpochNum":
+			z.JailedEpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "JailedEpochNum")
+				return
+			}
+		case "ReleaseEpochNum":
+			z.ReleaseEpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReleaseEpochNum")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *JailedValidator) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Address"
+	err = en.Append(0x84, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "Pubkey"
+	err = en.Append(0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "JailedEpochNum"
+	err = en.Append(0xae, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.JailedEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "JailedEpochNum")
+		return
+	}
+	// write "ReleaseEpochNum"
+	err = en.Append(0xaf, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ReleaseEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "ReleaseEpochNum")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *JailedValidator) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Address"
+	o = append(o, 0x84, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "Pubkey"
+	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "JailedEpochNum"
+	o = append(o, 0xae, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.JailedEpochNum)
+	// string "ReleaseEpochNum"
+	o = append(o, 0xaf, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.ReleaseEpochNum)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *JailedValidator) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "JailedEpochNum":
+			z.JailedEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "JailedEpochNum")
+				return
+			}
+		case "ReleaseEpochNum":
+			z.ReleaseEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReleaseEpochNum")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *JailedValidator) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size + 16 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *JailedValidatorList) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Jailed":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Jailed")
+				return
+			}
+			if cap(z.Jailed) >= int(zb0002) {
+				z.Jailed = (z.Jailed)[:zb0002]
+			} else {
+				z.Jailed = make([]*JailedValidator, zb0002)
+			}
+			for za0001 := range z.Jailed {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Jailed", za0001)
+						return
+					}
+					z.Jailed[za0001] = nil
+				} else {
+					if z.Jailed[za0001] == nil {
+						z.Jailed[za0001] = new(JailedValidator)
+					}
+					err = z.Jailed[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Jailed", za0001)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *JailedValidatorList) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Jailed"
+	err = en.Append(0x81, 0xa6, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Jailed)))
+	if err != nil {
+		err = msgp.WrapError(err, "Jailed")
+		return
+	}
+	for za0001 := range z.Jailed {
+		if z.Jailed[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Jailed[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Jailed", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *JailedValidatorList) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Jailed"
+	o = append(o, 0x81, 0xa6, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x64)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Jailed)))
+	for za0001 := range z.Jailed {
+		if z.Jailed[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Jailed[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Jailed", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *JailedValidatorList) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Jailed":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Jailed")
+				return
+			}
+			if cap(z.Jailed) >= int(zb0002) {
+				z.Jailed = (z.Jailed)[:zb0002]
+			} else {
+				z.Jailed = make([]*JailedValidator, zb0002)
+			}
+			for za0001 := range z.Jailed {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Jailed[za0001] = nil
+				} else {
+					if z.Jailed[za0001] == nil {
+						z.Jailed[za0001] = new(JailedValidator)
+					}
+					bts, err = z.Jailed[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Jailed", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *JailedValidatorList) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize
+	for za0001 := range z.Jailed {
+		if z.Jailed[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Jailed[za0001].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *Nomination) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "NominatedCount":
+			z.NominatedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "NominatedCount")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *Nomination) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Pubkey"
+	err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "NominatedCount"
+	err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.NominatedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "NominatedCount")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Nomination) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Pubkey"
+	o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "NominatedCount"
+	o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.NominatedCount)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Nomination) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "NominatedCount":
+			z.NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NominatedCount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Nomination) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *NominationHeap) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0003 uint32
+	zb0003, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if cap((*z)) >= int(zb0003) {
+		(*z) = (*z)[:zb0003]
+	} else {
+		(*z) = make(NominationHeap, zb0003)
+	}
+	for zb0001 := range *z {
+		if dc.IsNil() {
+			err = dc.ReadNil()
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			(*z)[zb0001] = nil
+		} else {
+			if (*z)[zb0001] == nil {
+				(*z)[zb0001] = new(Nomination)
+			}
+			var field []byte
+			_ = field
+			var zb0004 uint32
+			zb0004, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			for zb0004 > 0 {
+				zb0004--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Pubkey":
+					err = dc.ReadExactBytes(((*z)[zb0001].Pubkey)[:])
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "Pubkey")
+						return
+					}
+				case "NominatedCount":
+					(*z)[zb0001].NominatedCount, err = dc.ReadInt64()
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "NominatedCount")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, zb0001)
+						return
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z NominationHeap) EncodeMsg(en *msgp.Writer) (err error) {
+	err = en.WriteArrayHeader(uint32(len(z)))
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0005 := range z {
+		if z[zb0005] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Pubkey"
+			err = en.Append(0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z[zb0005].Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, zb0005, "Pubkey")
+				return
+			}
+			// write "NominatedCount"
+			err = en.Append(0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+			if err != nil {
+				return
+			}
+			err = en.WriteInt64(z[zb0005].NominatedCount)
+			if err != nil {
+				err = msgp.WrapError(err, zb0005, "NominatedCount")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z NominationHeap) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendArrayHeader(o, uint32(len(z)))
+	for zb0005 := range z {
+		if z[zb0005] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Pubkey"
+			o = append(o, 0x82, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+			o = msgp.AppendBytes(o, (z[zb0005].Pubkey)[:])
+			// string "NominatedCount"
+			o = append(o, 0xae, 0x4e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+			o = msgp.AppendInt64(o, z[zb0005].NominatedCount)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *NominationHeap) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0003 uint32
+	zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if cap((*z)) >= int(zb0003) {
+		(*z) = (*z)[:zb0003]
+	} else {
+		(*z) = make(NominationHeap, zb0003)
+	}
+	for zb0001 := range *z {
+		if msgp.IsNil(bts) {
+			bts, err = msgp.ReadNilBytes(bts)
+			if err != nil {
+				return
+			}
+			(*z)[zb0001] = nil
+		} else {
+			if (*z)[zb0001] == nil {
+				(*z)[zb0001] = new(Nomination)
+			}
+			var field []byte
+			_ = field
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, zb0001)
+				return
+			}
+			for zb0004 > 0 {
+				zb0004--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, zb0001)
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Pubkey":
+					bts, err = msgp.ReadExactBytes(bts, ((*z)[zb0001].Pubkey)[:])
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "Pubkey")
+						return
+					}
+				case "NominatedCount":
+					(*z)[zb0001].NominatedCount, bts, err = msgp.ReadInt64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, zb0001, "NominatedCount")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, zb0001)
+						return
+					}
+				}
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z NominationHeap) Msgsize() (s int) {
+	s = msgp.ArrayHeaderSize
+	for zb0005 := range z {
+		if z[zb0005] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OnlineInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ValidatorConsensusAddress":
+			err = dc.ReadExactBytes((z.ValidatorConsensusAddress)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorConsensusAddress")
+				return
+			}
+		case "SignatureCount":
+			z.SignatureCount, err = dc.ReadInt32()
+			if err != nil {
+				err = msgp.WrapError(err, "SignatureCount")
+				return
+			}
+		case "HeightOfLastSignature":
+			z.HeightOfLastSignature, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "HeightOfLastSignature")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *OnlineInfo) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "ValidatorConsensusAddress"
+	err = en.Append(0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.ValidatorConsensusAddress)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "ValidatorConsensusAddress")
+		return
+	}
+	// write "SignatureCount"
+	err = en.Append(0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt32(z.SignatureCount)
+	if err != nil {
+		err = msgp.WrapError(err, "SignatureCount")
+		return
+	}
+	// write "HeightOfLastSignature"
+	err = en.Append(0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.HeightOfLastSignature)
+	if err != nil {
+		err = msgp.WrapError(err, "HeightOfLastSignature")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OnlineInfo) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "ValidatorConsensusAddress"
+	o = append(o, 0x83, 0xb9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.ValidatorConsensusAddress)[:])
+	// string "SignatureCount"
+	o = append(o, 0xae, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt32(o, z.SignatureCount)
+	// string "HeightOfLastSignature"
+	o = append(o, 0xb5, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x66, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	o = msgp.AppendInt64(o, z.HeightOfLastSignature)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OnlineInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ValidatorConsensusAddress":
+			bts, err = msgp.ReadExactBytes(bts, (z.ValidatorConsensusAddress)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorConsensusAddress")
+				return
+			}
+		case "SignatureCount":
+			z.SignatureCount, bts, err = msgp.ReadInt32Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignatureCount")
+				return
+			}
+		case "HeightOfLastSignature":
+			z.HeightOfLastSignature, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "HeightOfLastSignature")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OnlineInfo) Msgsize() (s int) {
+	s = 1 + 26 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 15 + msgp.Int32Size + 22 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ParamProposal) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Proposer":
+			err = dc.ReadExactBytes((z.Proposer)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Proposer")
+				return
+			}
+		case "ParamID":
+			z.ParamID, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "ParamID")
+				return
+			}
+		case "Value":
+			z.Value, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		case "Deadline":
+			z.Deadline, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Deadline")
+				return
+			}
+		case "Votes":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Votes")
+				return
+			}
+			if cap(z.Votes) >= int(zb0002) {
+				z.Votes = (z.Votes)[:zb0002]
+			} else {
+				z.Votes = make([]*ParamVote, zb0002)
+			}
+			for za0002 := range z.Votes {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Votes", za0002)
+						return
+					}
+					z.Votes[za0002] = nil
+				} else {
+					if z.Votes[za0002] == nil {
+						z.Votes[za0002] = new(ParamVote)
+					}
+					var zb0003 uint32
+					zb0003, err = dc.ReadMapHeader()
+					if err != nil {
+						err = msgp.WrapError(err, "Votes", za0002)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, err = dc.ReadMapKeyPtr()
+						if err != nil {
+							err = msgp.WrapError(err, "Votes", za0002)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Voter":
+							err = dc.ReadExactBytes((z.Votes[za0002].Voter)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Voter")
+								return
+							}
+						case "Approve":
+							z.Votes[za0002].Approve, err = dc.ReadBool()
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Approve")
+								return
+							}
+						default:
+							err = dc.Skip()
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ParamProposal) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "Proposer"
+	err = en.Append(0x86, 0xa8, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Proposer)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Proposer")
+		return
+	}
+	// write "ParamID"
+	err = en.Append(0xa7, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x49, 0x44)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.ParamID)
+	if err != nil {
+		err = msgp.WrapError(err, "ParamID")
+		return
+	}
+	// write "Value"
+	err = en.Append(0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Value)
+	if err != nil {
+		err = msgp.WrapError(err, "Value")
+		return
+	}
+	// write "ActivationHeight"
+	err = en.Append(0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ActivationHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "ActivationHeight")
+		return
+	}
+	// write "Deadline"
+	err = en.Append(0xa8, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Deadline)
+	if err != nil {
+		err = msgp.WrapError(err, "Deadline")
+		return
+	}
+	// write "Votes"
+	err = en.Append(0xa5, 0x56, 0x6f, 0x74, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Votes)))
+	if err != nil {
+		err = msgp.WrapError(err, "Votes")
+		return
+	}
+	for za0002 := range z.Votes {
+		if z.Votes[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Voter"
+			err = en.Append(0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z.Votes[za0002].Voter)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Votes", za0002, "Voter")
+				return
+			}
+			// write "Approve"
+			err = en.Append(0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+			if err != nil {
+				return
+			}
+			err = en.WriteBool(z.Votes[za0002].Approve)
+			if err != nil {
+				err = msgp.WrapError(err, "Votes", za0002, "Approve")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ParamProposal) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "Proposer"
+	o = append(o, 0x86, 0xa8, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72)
+	o = msgp.AppendBytes(o, (z.Proposer)[:])
+	// string "ParamID"
+	o = append(o, 0xa7, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x49, 0x44)
+	o = msgp.AppendUint64(o, z.ParamID)
+	// string "Value"
+	o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o = msgp.AppendUint64(o, z.Value)
+	// string "ActivationHeight"
+	o = append(o, 0xb0, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.ActivationHeight)
+	// string "Deadline"
+	o = append(o, 0xa8, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65)
+	o = msgp.AppendUint64(o, z.Deadline)
+	// string "Votes"
+	o = append(o, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Votes)))
+	for za0002 := range z.Votes {
+		if z.Votes[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Voter"
+			o = append(o, 0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+			o = msgp.AppendBytes(o, (z.Votes[za0002].Voter)[:])
+			// string "Approve"
+			o = append(o, 0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+			o = msgp.AppendBool(o, z.Votes[za0002].Approve)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ParamProposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Proposer":
+			bts, err = msgp.ReadExactBytes(bts, (z.Proposer)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Proposer")
+				return
+			}
+		case "ParamID":
+			z.ParamID, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ParamID")
+				return
+			}
+		case "Value":
+			z.Value, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "ActivationHeight":
+			z.ActivationHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ActivationHeight")
+				return
+			}
+		case "Deadline":
+			z.Deadline, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Deadline")
+				return
+			}
+		case "Votes":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Votes")
+				return
+			}
+			if cap(z.Votes) >= int(zb0002) {
+				z.Votes = (z.Votes)[:zb0002]
+			} else {
+				z.Votes = make([]*ParamVote, zb0002)
+			}
+			for za0002 := range z.Votes {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Votes[za0002] = nil
+				} else {
+					if z.Votes[za0002] == nil {
+						z.Votes[za0002] = new(ParamVote)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Votes", za0002)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Votes", za0002)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Voter":
+							bts, err = msgp.ReadExactBytes(bts, (z.Votes[za0002].Voter)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Voter")
+								return
+							}
+						case "Approve":
+							z.Votes[za0002].Approve, bts, err = msgp.ReadBoolBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002, "Approve")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Votes", za0002)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ParamProposal) Msgsize() (s int) {
+	s = 1 + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 8 + msgp.Uint64Size + 6 + msgp.Uint64Size + 17 + msgp.Int64Size + 9 + msgp.Uint64Size + 6 + msgp.ArrayHeaderSize
+	for za0002 := range z.Votes {
+		if z.Votes[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 6 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 8 + msgp.BoolSize
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ParamVote) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Voter":
+			err = dc.ReadExactBytes((z.Voter)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Voter")
+				return
+			}
+		case "Approve":
+			z.Approve, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Approve")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ParamVote) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Voter"
+	err = en.Append(0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Voter)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Voter")
+		return
+	}
+	// write "Approve"
+	err = en.Append(0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Approve)
+	if err != nil {
+		err = msgp.WrapError(err, "Approve")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ParamVote) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Voter"
+	o = append(o, 0x82, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+	o = msgp.AppendBytes(o, (z.Voter)[:])
+	// string "Approve"
+	o = append(o, 0xa7, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65)
+	o = msgp.AppendBool(o, z.Approve)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ParamVote) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Voter":
+			bts, err = msgp.ReadExactBytes(bts, (z.Voter)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Voter")
+				return
+			}
+		case "Approve":
+			z.Approve, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Approve")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ParamVote) Msgsize() (s int) {
+	s = 1 + 6 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 8 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PendingReward) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PendingReward) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Address"
+	err = en.Append(0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "EpochNum"
+	err = en.Append(0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.EpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "EpochNum")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Amount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PendingReward) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Address"
+	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "EpochNum"
+	o = append(o, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.EpochNum)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PendingReward) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PendingReward) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PendingRewardKey) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PendingRewardKey) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Address"
+	err = en.Append(0x82, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "EpochNum"
+	err = en.Append(0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.EpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "EpochNum")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PendingRewardKey) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Address"
+	o = append(o, 0x82, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "EpochNum"
+	o = append(o, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.EpochNum)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PendingRewardKey) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "EpochNum":
+			z.EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EpochNum")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PendingRewardKey) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SlashHistory) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Records":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Records")
+				return
+			}
+			if cap(z.Records) >= int(zb0002) {
+				z.Records = (z.Records)[:zb0002]
+			} else {
+				z.Records = make([]*SlashRecord, zb0002)
+			}
+			for za0001 := range z.Records {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Records", za0001)
+						return
+					}
+					z.Records[za0001] = nil
+				} else {
+					if z.Records[za0001] == nil {
+						z.Records[za0001] = new(SlashRecord)
+					}
+					err = z.Records[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Records", za0001)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SlashHistory) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Records"
+	err = en.Append(0x81, 0xa7, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Records)))
+	if err != nil {
+		err = msgp.WrapError(err, "Records")
+		return
+	}
+	for za0001 := range z.Records {
+		if z.Records[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Records[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Records", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SlashHistory) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Records"
+	o = append(o, 0x81, 0xa7, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Records)))
+	for za0001 := range z.Records {
+		if z.Records[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Records[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Records", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SlashHistory) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Records":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Records")
+				return
+			}
+			if cap(z.Records) >= int(zb0002) {
+				z.Records = (z.Records)[:zb0002]
+			} else {
+				z.Records = make([]*SlashRecord, zb0002)
+			}
+			for za0001 := range z.Records {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Records[za0001] = nil
+				} else {
+					if z.Records[za0001] == nil {
+						z.Records[za0001] = new(SlashRecord)
+					}
+					bts, err = z.Records[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Records", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SlashHistory) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Records {
+		if z.Records[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Records[za0001].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SlashRecord) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Height":
+			z.Height, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Validator":
+			err = dc.ReadExactBytes((z.Validator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Validator")
+				return
+			}
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "Reason":
+			z.Reason, err = dc.ReadUint8()
+			if err != nil {
+				err = msgp.WrapError(err, "Reason")
+				return
+			}
+		case "Burnt":
+			err = dc.ReadExactBytes((z.Burnt)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Burnt")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SlashRecord) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "Height"
+	err = en.Append(0x85, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Height)
+	if err != nil {
+		err = msgp.WrapError(err, "Height")
+		return
+	}
+	// write "Validator"
+	err = en.Append(0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Validator)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Validator")
+		return
+	}
+	// write "Pubkey"
+	err = en.Append(0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "Reason"
+	err = en.Append(0xa6, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint8(z.Reason)
+	if err != nil {
+		err = msgp.WrapError(err, "Reason")
+		return
+	}
+	// write "Burnt"
+	err = en.Append(0xa5, 0x42, 0x75, 0x72, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Burnt)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Burnt")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SlashRecord) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "Height"
+	o = append(o, 0x85, 0xa6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.Height)
+	// string "Validator"
+	o = append(o, 0xa9, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Validator)[:])
+	// string "Pubkey"
+	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "Reason"
+	o = append(o, 0xa6, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e)
+	o = msgp.AppendUint8(o, z.Reason)
+	// string "Burnt"
+	o = append(o, 0xa5, 0x42, 0x75, 0x72, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Burnt)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SlashRecord) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Height":
+			z.Height, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Height")
+				return
+			}
+		case "Validator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Validator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Validator")
+				return
+			}
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "Reason":
+			z.Reason, bts, err = msgp.ReadUint8Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reason")
+				return
+			}
+		case "Burnt":
+			bts, err = msgp.ReadExactBytes(bts, (z.Burnt)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Burnt")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SlashRecord) Msgsize() (s int) {
+	s = 1 + 7 + msgp.Int64Size + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 7 + msgp.Uint8Size + 6 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *StakingInfo) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "GenesisMainnetBlockHeight":
+			z.GenesisMainnetBlockHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+				return
+			}
+		case "CurrEpochNum":
+			z.CurrEpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "CurrEpochNum")
+				return
+			}
+		case "Validators":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Validators")
+				return
+			}
+			if cap(z.Validators) >= int(zb0002) {
+				z.Validators = (z.Validators)[:zb0002]
+			} else {
+				z.Validators = make([]*Validator, zb0002)
+			}
+			for za0001 := range z.Validators {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+					z.Validators[za0001] = nil
+				} else {
+					if z.Validators[za0001] == nil {
+						z.Validators[za0001] = new(Validator)
+					}
+					err = z.Validators[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+				}
+			}
+		case "ValidatorsUpdate":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorsUpdate")
+				return
+			}
+			if cap(z.ValidatorsUpdate) >= int(zb0003) {
+				z.ValidatorsUpdate = (z.ValidatorsUpdate)[:zb0003]
+			} else {
+				z.ValidatorsUpdate = make([]*Validator, zb0003)
+			}
+			for za0002 := range z.ValidatorsUpdate {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+						return
+					}
+					z.ValidatorsUpdate[za0002] = nil
+				} else {
+					if z.ValidatorsUpdate[za0002] == nil {
+						z.ValidatorsUpdate[za0002] = new(Validator)
+					}
+					err = z.ValidatorsUpdate[za0002].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+						return
+					}
+				}
+			}
+		case "PendingRewards":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewards")
+				return
+			}
+			if cap(z.PendingRewards) >= int(zb0004) {
+				z.PendingRewards = (z.PendingRewards)[:zb0004]
+			} else {
+				z.PendingRewards = make([]*PendingReward, zb0004)
+			}
+			for za0003 := range z.PendingRewards {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewards", za0003)
+						return
+					}
+					z.PendingRewards[za0003] = nil
+				} else {
+					if z.PendingRewards[za0003] == nil {
+						z.PendingRewards[za0003] = new(PendingReward)
+					}
+					err = z.PendingRewards[za0003].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewards", za0003)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *StakingInfo) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "GenesisMainnetBlockHeight"
+	err = en.Append(0x85, 0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.GenesisMainnetBlockHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+		return
+	}
+	// write "CurrEpochNum"
+	err = en.Append(0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.CurrEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "CurrEpochNum")
+		return
+	}
+	// write "Validators"
+	err = en.Append(0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Validators)))
+	if err != nil {
+		err = msgp.WrapError(err, "Validators")
+		return
+	}
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Validators[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators", za0001)
+				return
+			}
+		}
+	}
+	// write "ValidatorsUpdate"
+	err = en.Append(0xb0, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.ValidatorsUpdate)))
+	if err != nil {
+		err = msgp.WrapError(err, "ValidatorsUpdate")
+		return
+	}
+	for za0002 := range z.ValidatorsUpdate {
+		if z.ValidatorsUpdate[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.ValidatorsUpdate[za0002].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+				return
+			}
+		}
+	}
+	// write "PendingRewards"
+	err = en.Append(0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.PendingRewards)))
+	if err != nil {
+		err = msgp.WrapError(err, "PendingRewards")
+		return
+	}
+	for za0003 := range z.PendingRewards {
+		if z.PendingRewards[za0003] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.PendingRewards[za0003].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewards", za0003)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *StakingInfo) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "GenesisMainnetBlockHeight"
+	o = append(o, 0x85, 0xb9, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x6e, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.GenesisMainnetBlockHeight)
+	// string "CurrEpochNum"
+	o = append(o, 0xac, 0x43, 0x75, 0x72, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.CurrEpochNum)
+	// string "Validators"
+	o = append(o, 0xaa, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Validators)))
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Validators[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators", za0001)
+				return
+			}
+		}
+	}
 	// string "ValidatorsUpdate"
 	o = append(o, 0xb0, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidatorsUpdate)))
@@ -1243,23 +4276,2509 @@ func (z *StakingInfo) MarshalMsg(b []byte) (o []byte, err error) {
 		if z.ValidatorsUpdate[za0002] == nil {
 			o = msgp.AppendNil(o)
 		} else {
-			o, err = z.ValidatorsUpdate[za0002].MarshalMsg(o)
+			o, err = z.ValidatorsUpdate[za0002].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+				return
+			}
+		}
+	}
+	// string "PendingRewards"
+	o = append(o, 0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.PendingRewards)))
+	for za0003 := range z.PendingRewards {
+		if z.PendingRewards[za0003] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.PendingRewards[za0003].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewards", za0003)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "GenesisMainnetBlockHeight":
+			z.GenesisMainnetBlockHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
+				return
+			}
+		case "CurrEpochNum":
+			z.CurrEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CurrEpochNum")
+				return
+			}
+		case "Validators":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Validators")
+				return
+			}
+			if cap(z.Validators) >= int(zb0002) {
+				z.Validators = (z.Validators)[:zb0002]
+			} else {
+				z.Validators = make([]*Validator, zb0002)
+			}
+			for za0001 := range z.Validators {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Validators[za0001] = nil
+				} else {
+					if z.Validators[za0001] == nil {
+						z.Validators[za0001] = new(Validator)
+					}
+					bts, err = z.Validators[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Validators", za0001)
+						return
+					}
+				}
+			}
+		case "ValidatorsUpdate":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorsUpdate")
+				return
+			}
+			if cap(z.ValidatorsUpdate) >= int(zb0003) {
+				z.ValidatorsUpdate = (z.ValidatorsUpdate)[:zb0003]
+			} else {
+				z.ValidatorsUpdate = make([]*Validator, zb0003)
+			}
+			for za0002 := range z.ValidatorsUpdate {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.ValidatorsUpdate[za0002] = nil
+				} else {
+					if z.ValidatorsUpdate[za0002] == nil {
+						z.ValidatorsUpdate[za0002] = new(Validator)
+					}
+					bts, err = z.ValidatorsUpdate[za0002].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+						return
+					}
+				}
+			}
+		case "PendingRewards":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewards")
+				return
+			}
+			if cap(z.PendingRewards) >= int(zb0004) {
+				z.PendingRewards = (z.PendingRewards)[:zb0004]
+			} else {
+				z.PendingRewards = make([]*PendingReward, zb0004)
+			}
+			for za0003 := range z.PendingRewards {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.PendingRewards[za0003] = nil
+				} else {
+					if z.PendingRewards[za0003] == nil {
+						z.PendingRewards[za0003] = new(PendingReward)
+					}
+					bts, err = z.PendingRewards[za0003].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewards", za0003)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *StakingInfo) Msgsize() (s int) {
+	s = 1 + 26 + msgp.Int64Size + 13 + msgp.Int64Size + 11 + msgp.ArrayHeaderSize
+	for za0001 := range z.Validators {
+		if z.Validators[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Validators[za0001].Msgsize()
+		}
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0002 := range z.ValidatorsUpdate {
+		if z.ValidatorsUpdate[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.ValidatorsUpdate[za0002].Msgsize()
+		}
+	}
+	s += 15 + msgp.ArrayHeaderSize
+	for za0003 := range z.PendingRewards {
+		if z.PendingRewards[za0003] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.PendingRewards[za0003].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *StakingInfoIndex) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ValidatorAddrs":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorAddrs")
+				return
+			}
+			if cap(z.ValidatorAddrs) >= int(zb0002) {
+				z.ValidatorAddrs = (z.ValidatorAddrs)[:zb0002]
+			} else {
+				z.ValidatorAddrs = make([][20]byte, zb0002)
+			}
+			for za0001 := range z.ValidatorAddrs {
+				err = dc.ReadExactBytes((z.ValidatorAddrs[za0001])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "ValidatorAddrs", za0001)
+					return
+				}
+			}
+		case "PendingRewardKeys":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewardKeys")
+				return
+			}
+			if cap(z.PendingRewardKeys) >= int(zb0003) {
+				z.PendingRewardKeys = (z.PendingRewardKeys)[:zb0003]
+			} else {
+				z.PendingRewardKeys = make([]*PendingRewardKey, zb0003)
+			}
+			for za0003 := range z.PendingRewardKeys {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewardKeys", za0003)
+						return
+					}
+					z.PendingRewardKeys[za0003] = nil
+				} else {
+					if z.PendingRewardKeys[za0003] == nil {
+						z.PendingRewardKeys[za0003] = new(PendingRewardKey)
+					}
+					var zb0004 uint32
+					zb0004, err = dc.ReadMapHeader()
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewardKeys", za0003)
+						return
+					}
+					for zb0004 > 0 {
+						zb0004--
+						field, err = dc.ReadMapKeyPtr()
+						if err != nil {
+							err = msgp.WrapError(err, "PendingRewardKeys", za0003)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Address":
+							err = dc.ReadExactBytes((z.PendingRewardKeys[za0003].Address)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "PendingRewardKeys", za0003, "Address")
+								return
+							}
+						case "EpochNum":
+							z.PendingRewardKeys[za0003].EpochNum, err = dc.ReadInt64()
+							if err != nil {
+								err = msgp.WrapError(err, "PendingRewardKeys", za0003, "EpochNum")
+								return
+							}
+						default:
+							err = dc.Skip()
+							if err != nil {
+								err = msgp.WrapError(err, "PendingRewardKeys", za0003)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *StakingInfoIndex) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "ValidatorAddrs"
+	err = en.Append(0x82, 0xae, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.ValidatorAddrs)))
+	if err != nil {
+		err = msgp.WrapError(err, "ValidatorAddrs")
+		return
+	}
+	for za0001 := range z.ValidatorAddrs {
+		err = en.WriteBytes((z.ValidatorAddrs[za0001])[:])
+		if err != nil {
+			err = msgp.WrapError(err, "ValidatorAddrs", za0001)
+			return
+		}
+	}
+	// write "PendingRewardKeys"
+	err = en.Append(0xb1, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.PendingRewardKeys)))
+	if err != nil {
+		err = msgp.WrapError(err, "PendingRewardKeys")
+		return
+	}
+	for za0003 := range z.PendingRewardKeys {
+		if z.PendingRewardKeys[za0003] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			// map header, size 2
+			// write "Address"
+			err = en.Append(0x82, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+			if err != nil {
+				return
+			}
+			err = en.WriteBytes((z.PendingRewardKeys[za0003].Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewardKeys", za0003, "Address")
+				return
+			}
+			// write "EpochNum"
+			err = en.Append(0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+			if err != nil {
+				return
+			}
+			err = en.WriteInt64(z.PendingRewardKeys[za0003].EpochNum)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewardKeys", za0003, "EpochNum")
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *StakingInfoIndex) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "ValidatorAddrs"
+	o = append(o, 0x82, 0xae, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidatorAddrs)))
+	for za0001 := range z.ValidatorAddrs {
+		o = msgp.AppendBytes(o, (z.ValidatorAddrs[za0001])[:])
+	}
+	// string "PendingRewardKeys"
+	o = append(o, 0xb1, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.PendingRewardKeys)))
+	for za0003 := range z.PendingRewardKeys {
+		if z.PendingRewardKeys[za0003] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Address"
+			o = append(o, 0x82, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+			o = msgp.AppendBytes(o, (z.PendingRewardKeys[za0003].Address)[:])
+			// string "EpochNum"
+			o = append(o, 0xa8, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+			o = msgp.AppendInt64(o, z.PendingRewardKeys[za0003].EpochNum)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *StakingInfoIndex) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ValidatorAddrs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorAddrs")
+				return
+			}
+			if cap(z.ValidatorAddrs) >= int(zb0002) {
+				z.ValidatorAddrs = (z.ValidatorAddrs)[:zb0002]
+			} else {
+				z.ValidatorAddrs = make([][20]byte, zb0002)
+			}
+			for za0001 := range z.ValidatorAddrs {
+				bts, err = msgp.ReadExactBytes(bts, (z.ValidatorAddrs[za0001])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "ValidatorAddrs", za0001)
+					return
+				}
+			}
+		case "PendingRewardKeys":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingRewardKeys")
+				return
+			}
+			if cap(z.PendingRewardKeys) >= int(zb0003) {
+				z.PendingRewardKeys = (z.PendingRewardKeys)[:zb0003]
+			} else {
+				z.PendingRewardKeys = make([]*PendingRewardKey, zb0003)
+			}
+			for za0003 := range z.PendingRewardKeys {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.PendingRewardKeys[za0003] = nil
+				} else {
+					if z.PendingRewardKeys[za0003] == nil {
+						z.PendingRewardKeys[za0003] = new(PendingRewardKey)
+					}
+					var zb0004 uint32
+					zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "PendingRewardKeys", za0003)
+						return
+					}
+					for zb0004 > 0 {
+						zb0004--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "PendingRewardKeys", za0003)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Address":
+							bts, err = msgp.ReadExactBytes(bts, (z.PendingRewardKeys[za0003].Address)[:])
+							if err != nil {
+								err = msgp.WrapError(err, "PendingRewardKeys", za0003, "Address")
+								return
+							}
+						case "EpochNum":
+							z.PendingRewardKeys[za0003].EpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "PendingRewardKeys", za0003, "EpochNum")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "PendingRewardKeys", za0003)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *StakingInfoIndex) Msgsize() (s int) {
+	s = 1 + 15 + msgp.ArrayHeaderSize + (len(z.ValidatorAddrs) * (20 * (msgp.ByteSize))) + 18 + msgp.ArrayHeaderSize
+	for za0003 := range z.PendingRewardKeys {
+		if z.PendingRewardKeys[za0003] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 9 + msgp.Int64Size
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SystemLog) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "Topics":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Topics")
+				return
+			}
+			if cap(z.Topics) >= int(zb0002) {
+				z.Topics = (z.Topics)[:zb0002]
+			} else {
+				z.Topics = make([][32]byte, zb0002)
+			}
+			for za0002 := range z.Topics {
+				err = dc.ReadExactBytes((z.Topics[za0002])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "Topics", za0002)
+					return
+				}
+			}
+		case "Data":
+			z.Data, err = dc.ReadBytes(z.Data)
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SystemLog) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Address"
+	err = en.Append(0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "Topics"
+	err = en.Append(0xa6, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Topics)))
+	if err != nil {
+		err = msgp.WrapError(err, "Topics")
+		return
+	}
+	for za0002 := range z.Topics {
+		err = en.WriteBytes((z.Topics[za0002])[:])
+		if err != nil {
+			err = msgp.WrapError(err, "Topics", za0002)
+			return
+		}
+	}
+	// write "Data"
+	err = en.Append(0xa4, 0x44, 0x61, 0x74, 0x61)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.Data)
+	if err != nil {
+		err = msgp.WrapError(err, "Data")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SystemLog) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Address"
+	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "Topics"
+	o = append(o, 0xa6, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Topics)))
+	for za0002 := range z.Topics {
+		o = msgp.AppendBytes(o, (z.Topics[za0002])[:])
+	}
+	// string "Data"
+	o = append(o, 0xa4, 0x44, 0x61, 0x74, 0x61)
+	o = msgp.AppendBytes(o, z.Data)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SystemLog) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "Topics":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Topics")
+				return
+			}
+			if cap(z.Topics) >= int(zb0002) {
+				z.Topics = (z.Topics)[:zb0002]
+			} else {
+				z.Topics = make([][32]byte, zb0002)
+			}
+			for za0002 := range z.Topics {
+				bts, err = msgp.ReadExactBytes(bts, (z.Topics[za0002])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "Topics", za0002)
+					return
+				}
+			}
+		case "Data":
+			z.Data, bts, err = msgp.ReadBytesBytes(bts, z.Data)
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SystemLog) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (len(z.Topics) * (32 * (msgp.ByteSize))) + 5 + msgp.BytesPrefixSize + len(z.Data)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SystemLogList) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Logs":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Logs")
+				return
+			}
+			if cap(z.Logs) >= int(zb0002) {
+				z.Logs = (z.Logs)[:zb0002]
+			} else {
+				z.Logs = make([]*SystemLog, zb0002)
+			}
+			for za0001 := range z.Logs {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Logs", za0001)
+						return
+					}
+					z.Logs[za0001] = nil
+				} else {
+					if z.Logs[za0001] == nil {
+						z.Logs[za0001] = new(SystemLog)
+					}
+					err = z.Logs[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Logs", za0001)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SystemLogList) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Logs"
+	err = en.Append(0x81, 0xa4, 0x4c, 0x6f, 0x67, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Logs)))
+	if err != nil {
+		err = msgp.WrapError(err, "Logs")
+		return
+	}
+	for za0001 := range z.Logs {
+		if z.Logs[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Logs[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Logs", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SystemLogList) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Logs"
+	o = append(o, 0x81, 0xa4, 0x4c, 0x6f, 0x67, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Logs)))
+	for za0001 := range z.Logs {
+		if z.Logs[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Logs[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Logs", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SystemLogList) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Logs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Logs")
+				return
+			}
+			if cap(z.Logs) >= int(zb0002) {
+				z.Logs = (z.Logs)[:zb0002]
+			} else {
+				z.Logs = make([]*SystemLog, zb0002)
+			}
+			for za0001 := range z.Logs {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Logs[za0001] = nil
+				} else {
+					if z.Logs[za0001] == nil {
+						z.Logs[za0001] = new(SystemLog)
+					}
+					bts, err = z.Logs[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Logs", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SystemLogList) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize
+	for za0001 := range z.Logs {
+		if z.Logs[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Logs[za0001].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *Unbonding) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "MatureEpochNum":
+			z.MatureEpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "MatureEpochNum")
+				return
+			}
+		case "Shares":
+			err = dc.ReadExactBytes((z.Shares)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Shares")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *Unbonding) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Pubkey"
+	err = en.Append(0x84, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Amount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// write "MatureEpochNum"
+	err = en.Append(0xae, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.MatureEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "MatureEpochNum")
+		return
+	}
+	// write "Shares"
+	err = en.Append(0xa6, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Shares)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Shares")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Unbonding) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Pubkey"
+	o = append(o, 0x84, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	// string "MatureEpochNum"
+	o = append(o, 0xae, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.MatureEpochNum)
+	// string "Shares"
+	o = append(o, 0xa6, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
+	o = msgp.AppendBytes(o, (z.Shares)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Unbonding) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "MatureEpochNum":
+			z.MatureEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MatureEpochNum")
+				return
+			}
+		case "Shares":
+			bts, err = msgp.ReadExactBytes(bts, (z.Shares)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Shares")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Unbonding) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *UnbondingList) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Delegator":
+			err = dc.ReadExactBytes((z.Delegator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Delegator")
+				return
+			}
+		case "Unbondings":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Unbondings")
+				return
+			}
+			if cap(z.Unbondings) >= int(zb0002) {
+				z.Unbondings = (z.Unbondings)[:zb0002]
+			} else {
+				z.Unbondings = make([]*Unbonding, zb0002)
+			}
+			for za0002 := range z.Unbondings {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Unbondings", za0002)
+						return
+					}
+					z.Unbondings[za0002] = nil
+				} else {
+					if z.Unbondings[za0002] == nil {
+						z.Unbondings[za0002] = new(Unbonding)
+					}
+					err = z.Unbondings[za0002].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Unbondings", za0002)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *UnbondingList) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Delegator"
+	err = en.Append(0x82, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Delegator)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Delegator")
+		return
+	}
+	// write "Unbondings"
+	err = en.Append(0xaa, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Unbondings)))
+	if err != nil {
+		err = msgp.WrapError(err, "Unbondings")
+		return
+	}
+	for za0002 := range z.Unbondings {
+		if z.Unbondings[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Unbondings[za0002].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbondings", za0002)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *UnbondingList) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Delegator"
+	o = append(o, 0x82, 0xa9, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendBytes(o, (z.Delegator)[:])
+	// string "Unbondings"
+	o = append(o, 0xaa, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Unbondings)))
+	for za0002 := range z.Unbondings {
+		if z.Unbondings[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Unbondings[za0002].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbondings", za0002)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *UnbondingList) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Delegator":
+			bts, err = msgp.ReadExactBytes(bts, (z.Delegator)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Delegator")
+				return
+			}
+		case "Unbondings":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbondings")
+				return
+			}
+			if cap(z.Unbondings) >= int(zb0002) {
+				z.Unbondings = (z.Unbondings)[:zb0002]
+			} else {
+				z.Unbondings = make([]*Unbonding, zb0002)
+			}
+			for za0002 := range z.Unbondings {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Unbondings[za0002] = nil
+				} else {
+					if z.Unbondings[za0002] == nil {
+						z.Unbondings[za0002] = new(Unbonding)
+					}
+					bts, err = z.Unbondings[za0002].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Unbondings", za0002)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *UnbondingList) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 11 + msgp.ArrayHeaderSize
+	for za0002 := range z.Unbondings {
+		if z.Unbondings[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Unbondings[za0002].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *UnbondingPool) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "MatureEpochNum":
+			z.MatureEpochNum, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "MatureEpochNum")
+				return
+			}
+		case "TotalAmount":
+			err = dc.ReadExactBytes((z.TotalAmount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalAmount")
+				return
+			}
+		case "TotalShares":
+			err = dc.ReadExactBytes((z.TotalShares)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalShares")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *UnbondingPool) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "Pubkey"
+	err = en.Append(0x84, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "MatureEpochNum"
+	err = en.Append(0xae, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.MatureEpochNum)
+	if err != nil {
+		err = msgp.WrapError(err, "MatureEpochNum")
+		return
+	}
+	// write "TotalAmount"
+	err = en.Append(0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.TotalAmount)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "TotalAmount")
+		return
+	}
+	// write "TotalShares"
+	err = en.Append(0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.TotalShares)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "TotalShares")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *UnbondingPool) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Pubkey"
+	o = append(o, 0x84, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "MatureEpochNum"
+	o = append(o, 0xae, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.MatureEpochNum)
+	// string "TotalAmount"
+	o = append(o, 0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.TotalAmount)[:])
+	// string "TotalShares"
+	o = append(o, 0xab, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73)
+	o = msgp.AppendBytes(o, (z.TotalShares)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *UnbondingPool) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "MatureEpochNum":
+			z.MatureEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MatureEpochNum")
+				return
+			}
+		case "TotalAmount":
+			bts, err = msgp.ReadExactBytes(bts, (z.TotalAmount)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalAmount")
+				return
+			}
+		case "TotalShares":
+			bts, err = msgp.ReadExactBytes(bts, (z.TotalShares)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "TotalShares")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *UnbondingPool) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 15 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *UptimeHistory) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Records":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Records")
+				return
+			}
+			if cap(z.Records) >= int(zb0002) {
+				z.Records = (z.Records)[:zb0002]
+			} else {
+				z.Records = make([]*UptimeRecord, zb0002)
+			}
+			for za0001 := range z.Records {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "Records", za0001)
+						return
+					}
+					z.Records[za0001] = nil
+				} else {
+					if z.Records[za0001] == nil {
+						z.Records[za0001] = new(UptimeRecord)
+					}
+					err = z.Records[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Records", za0001)
+						return
+					}
+				}
+			}
+		case "DuplicateSigs":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "DuplicateSigs")
+				return
+			}
+			if cap(z.DuplicateSigs) >= int(zb0003) {
+				z.DuplicateSigs = (z.DuplicateSigs)[:zb0003]
+			} else {
+				z.DuplicateSigs = make([]*DuplicateSigRecord, zb0003)
+			}
+			for za0002 := range z.DuplicateSigs {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "DuplicateSigs", za0002)
+						return
+					}
+					z.DuplicateSigs[za0002] = nil
+				} else {
+					if z.DuplicateSigs[za0002] == nil {
+						z.DuplicateSigs[za0002] = new(DuplicateSigRecord)
+					}
+					err = z.DuplicateSigs[za0002].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "DuplicateSigs", za0002)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *UptimeHistory) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Records"
+	err = en.Append(0x82, 0xa7, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Records)))
+	if err != nil {
+		err = msgp.WrapError(err, "Records")
+		return
+	}
+	for za0001 := range z.Records {
+		if z.Records[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.Records[za0001].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "Records", za0001)
+				return
+			}
+		}
+	}
+	// write "DuplicateSigs"
+	err = en.Append(0xad, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.DuplicateSigs)))
+	if err != nil {
+		err = msgp.WrapError(err, "DuplicateSigs")
+		return
+	}
+	for za0002 := range z.DuplicateSigs {
+		if z.DuplicateSigs[za0002] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.DuplicateSigs[za0002].EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "DuplicateSigs", za0002)
+				return
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *UptimeHistory) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Records"
+	o = append(o, 0x82, 0xa7, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Records)))
+	for za0001 := range z.Records {
+		if z.Records[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Records[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Records", za0001)
+				return
+			}
+		}
+	}
+	// string "DuplicateSigs"
+	o = append(o, 0xad, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.DuplicateSigs)))
+	for za0002 := range z.DuplicateSigs {
+		if z.DuplicateSigs[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.DuplicateSigs[za0002].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "DuplicateSigs", za0002)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *UptimeHistory) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Records":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Records")
+				return
+			}
+			if cap(z.Records) >= int(zb0002) {
+				z.Records = (z.Records)[:zb0002]
+			} else {
+				z.Records = make([]*UptimeRecord, zb0002)
+			}
+			for za0001 := range z.Records {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Records[za0001] = nil
+				} else {
+					if z.Records[za0001] == nil {
+						z.Records[za0001] = new(UptimeRecord)
+					}
+					bts, err = z.Records[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Records", za0001)
+						return
+					}
+				}
+			}
+		case "DuplicateSigs":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DuplicateSigs")
+				return
+			}
+			if cap(z.DuplicateSigs) >= int(zb0003) {
+				z.DuplicateSigs = (z.DuplicateSigs)[:zb0003]
+			} else {
+				z.DuplicateSigs = make([]*DuplicateSigRecord, zb0003)
+			}
+			for za0002 := range z.DuplicateSigs {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.DuplicateSigs[za0002] = nil
+				} else {
+					if z.DuplicateSigs[za0002] == nil {
+						z.DuplicateSigs[za0002] = new(DuplicateSigRecord)
+					}
+					bts, err = z.DuplicateSigs[za0002].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "DuplicateSigs", za0002)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *UptimeHistory) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Records {
+		if z.Records[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Records[za0001].Msgsize()
+		}
+	}
+	s += 14 + msgp.ArrayHeaderSize
+	for za0002 := range z.DuplicateSigs {
+		if z.DuplicateSigs[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.DuplicateSigs[za0002].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *UptimeRecord) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "StartHeight":
+			z.StartHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "StartHeight")
+				return
+			}
+		case "SignedBitmap":
+			z.SignedBitmap, err = dc.ReadBytes(z.SignedBitmap)
+			if err != nil {
+				err = msgp.WrapError(err, "SignedBitmap")
+				return
+			}
+		case "MissedBitmap":
+			z.MissedBitmap, err = dc.ReadBytes(z.MissedBitmap)
+			if err != nil {
+				err = msgp.WrapError(err, "MissedBitmap")
+				return
+			}
+		case "ProposedBitmap":
+			z.ProposedBitmap, err = dc.ReadBytes(z.ProposedBitmap)
+			if err != nil {
+				err = msgp.WrapError(err, "ProposedBitmap")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *UptimeRecord) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "Address"
+	err = en.Append(0x85, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "StartHeight"
+	err = en.Append(0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.StartHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "StartHeight")
+		return
+	}
+	// write "SignedBitmap"
+	err = en.Append(0xac, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.SignedBitmap)
+	if err != nil {
+		err = msgp.WrapError(err, "SignedBitmap")
+		return
+	}
+	// write "MissedBitmap"
+	err = en.Append(0xac, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.MissedBitmap)
+	if err != nil {
+		err = msgp.WrapError(err, "MissedBitmap")
+		return
+	}
+	// write "ProposedBitmap"
+	err = en.Append(0xae, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.ProposedBitmap)
+	if err != nil {
+		err = msgp.WrapError(err, "ProposedBitmap")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *UptimeRecord) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "Address"
+	o = append(o, 0x85, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "StartHeight"
+	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.StartHeight)
+	// string "SignedBitmap"
+	o = append(o, 0xac, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70)
+	o = msgp.AppendBytes(o, z.SignedBitmap)
+	// string "MissedBitmap"
+	o = append(o, 0xac, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70)
+	o = msgp.AppendBytes(o, z.MissedBitmap)
+	// string "ProposedBitmap"
+	o = append(o, 0xae, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x64, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70)
+	o = msgp.AppendBytes(o, z.ProposedBitmap)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *UptimeRecord) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "StartHeight":
+			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StartHeight")
+				return
+			}
+		case "SignedBitmap":
+			z.SignedBitmap, bts, err = msgp.ReadBytesBytes(bts, z.SignedBitmap)
+			if err != nil {
+				err = msgp.WrapError(err, "SignedBitmap")
+				return
+			}
+		case "MissedBitmap":
+			z.MissedBitmap, bts, err = msgp.ReadBytesBytes(bts, z.MissedBitmap)
+			if err != nil {
+				err = msgp.WrapError(err, "MissedBitmap")
+				return
+			}
+		case "ProposedBitmap":
+			z.ProposedBitmap, bts, err = msgp.ReadBytesBytes(bts, z.ProposedBitmap)
+			if err != nil {
+				err = msgp.WrapError(err, "ProposedBitmap")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *UptimeRecord) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 12 + msgp.Int64Size + 13 + msgp.BytesPrefixSize + len(z.SignedBitmap) + 13 + msgp.BytesPrefixSize + len(z.MissedBitmap) + 15 + msgp.BytesPrefixSize + len(z.ProposedBitmap)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *UptimeWindow) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "StartHeight":
+			z.StartHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "StartHeight")
+				return
+			}
+		case "Size":
+			z.Size, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Size")
+				return
+			}
+		case "Addresses":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Addresses")
+				return
+			}
+			if cap(z.Addresses) >= int(zb0002) {
+				z.Addresses = (z.Addresses)[:zb0002]
+			} else {
+				z.Addresses = make([][20]byte, zb0002)
+			}
+			for za0001 := range z.Addresses {
+				err = dc.ReadExactBytes((z.Addresses[za0001])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "Addresses", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *UptimeWindow) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "StartHeight"
+	err = en.Append(0x83, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.StartHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "StartHeight")
+		return
+	}
+	// write "Size"
+	err = en.Append(0xa4, 0x53, 0x69, 0x7a, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Size)
+	if err != nil {
+		err = msgp.WrapError(err, "Size")
+		return
+	}
+	// write "Addresses"
+	err = en.Append(0xa9, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Addresses)))
+	if err != nil {
+		err = msgp.WrapError(err, "Addresses")
+		return
+	}
+	for za0001 := range z.Addresses {
+		err = en.WriteBytes((z.Addresses[za0001])[:])
+		if err != nil {
+			err = msgp.WrapError(err, "Addresses", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *UptimeWindow) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "StartHeight"
+	o = append(o, 0x83, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.StartHeight)
+	// string "Size"
+	o = append(o, 0xa4, 0x53, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt64(o, z.Size)
+	// string "Addresses"
+	o = append(o, 0xa9, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Addresses)))
+	for za0001 := range z.Addresses {
+		o = msgp.AppendBytes(o, (z.Addresses[za0001])[:])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *UptimeWindow) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "StartHeight":
+			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StartHeight")
+				return
+			}
+		case "Size":
+			z.Size, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Size")
+				return
+			}
+		case "Addresses":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Addresses")
+				return
+			}
+			if cap(z.Addresses) >= int(zb0002) {
+				z.Addresses = (z.Addresses)[:zb0002]
+			} else {
+				z.Addresses = make([][20]byte, zb0002)
+			}
+			for za0001 := range z.Addresses {
+				bts, err = msgp.ReadExactBytes(bts, (z.Addresses[za0001])[:])
+				if err != nil {
+					err = msgp.WrapError(err, "Addresses", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *UptimeWindow) Msgsize() (s int) {
+	s = 1 + 12 + msgp.Int64Size + 5 + msgp.Int64Size + 10 + msgp.ArrayHeaderSize + (len(z.Addresses) * (20 * (msgp.ByteSize)))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *Validator) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "Pubkey":
+			err = dc.ReadExactBytes((z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "RewardTo":
+			err = dc.ReadExactBytes((z.RewardTo)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "RewardTo")
+				return
+			}
+		case "VotingPower":
+			z.VotingPower, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "VotingPower")
+				return
+			}
+		case "Introduction":
+			z.Introduction, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Introduction")
+				return
+			}
+		case "StakedCoins":
+			err = dc.ReadExactBytes((z.StakedCoins)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "StakedCoins")
+				return
+			}
+		case "IsRetiring":
+			z.IsRetiring, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "IsRetiring")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *Validator) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 7
+	// write "Address"
+	err = en.Append(0x87, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "Pubkey"
+	err = en.Append(0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Pubkey)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Pubkey")
+		return
+	}
+	// write "RewardTo"
+	err = en.Append(0xa8, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x6f)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.RewardTo)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "RewardTo")
+		return
+	}
+	// write "VotingPower"
+	err = en.Append(0xab, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.VotingPower)
+	if err != nil {
+		err = msgp.WrapError(err, "VotingPower")
+		return
+	}
+	// write "Introduction"
+	err = en.Append(0xac, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Introduction)
+	if err != nil {
+		err = msgp.WrapError(err, "Introduction")
+		return
+	}
+	// write "StakedCoins"
+	err = en.Append(0xab, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x69, 0x6e, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.StakedCoins)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "StakedCoins")
+		return
+	}
+	// write "IsRetiring"
+	err = en.Append(0xaa, 0x49, 0x73, 0x52, 0x65, 0x74, 0x69, 0x72, 0x69, 0x6e, 0x67)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.IsRetiring)
+	if err != nil {
+		err = msgp.WrapError(err, "IsRetiring")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Validator) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "Address"
+	o = append(o, 0x87, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "Pubkey"
+	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
+	o = msgp.AppendBytes(o, (z.Pubkey)[:])
+	// string "RewardTo"
+	o = append(o, 0xa8, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x6f)
+	o = msgp.AppendBytes(o, (z.RewardTo)[:])
+	// string "VotingPower"
+	o = append(o, 0xab, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72)
+	o = msgp.AppendInt64(o, z.VotingPower)
+	// string "Introduction"
+	o = append(o, 0xac, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Introduction)
+	// string "StakedCoins"
+	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x69, 0x6e, 0x73)
+	o = msgp.AppendBytes(o, (z.StakedCoins)[:])
+	// string "IsRetiring"
+	o = append(o, 0xaa, 0x49, 0x73, 0x52, 0x65, 0x74, 0x69, 0x72, 0x69, 0x6e, 0x67)
+	o = msgp.AppendBool(o, z.IsRetiring)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Validator) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "Pubkey":
+			bts, err = msgp.ReadExactBytes(bts, (z.Pubkey)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Pubkey")
+				return
+			}
+		case "RewardTo":
+			bts, err = msgp.ReadExactBytes(bts, (z.RewardTo)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "RewardTo")
+				return
+			}
+		case "VotingPower":
+			z.VotingPower, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VotingPower")
+				return
+			}
+		case "Introduction":
+			z.Introduction, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Introduction")
+				return
+			}
+		case "StakedCoins":
+			bts, err = msgp.ReadExactBytes(bts, (z.StakedCoins)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "StakedCoins")
+				return
+			}
+		case "IsRetiring":
+			z.IsRetiring, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IsRetiring")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Validator) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 12 + msgp.Int64Size + 13 + msgp.StringPrefixSize + len(z.Introduction) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 11 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ValidatorOnlineInfos) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "StartHeight":
+			z.StartHeight, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "StartHeight")
+				return
+			}
+		case "OnlineInfos":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "OnlineInfos")
+				return
+			}
+			if cap(z.OnlineInfos) >= int(zb0002) {
+				z.OnlineInfos = (z.OnlineInfos)[:zb0002]
+			} else {
+				z.OnlineInfos = make([]*OnlineInfo, zb0002)
+			}
+			for za0001 := range z.OnlineInfos {
+				if dc.IsNil() {
+					err = dc.ReadNil()
+					if err != nil {
+						err = msgp.WrapError(err, "OnlineInfos", za0001)
+						return
+					}
+					z.OnlineInfos[za0001] = nil
+				} else {
+					if z.OnlineInfos[za0001] == nil {
+						z.OnlineInfos[za0001] = new(OnlineInfo)
+					}
+					err = z.OnlineInfos[za0001].DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "OnlineInfos", za0001)
+						return
+					}
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ValidatorOnlineInfos) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "StartHeight"
+	err = en.Append(0x82, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.StartHeight)
+	if err != nil {
+		err = msgp.WrapError(err, "StartHeight")
+		return
+	}
+	// write "OnlineInfos"
+	err = en.Append(0xab, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.OnlineInfos)))
+	if err != nil {
+		err = msgp.WrapError(err, "OnlineInfos")
+		return
+	}
+	for za0001 := range z.OnlineInfos {
+		if z.OnlineInfos[za0001] == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.OnlineInfos[za0001].EncodeMsg(en)
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
+				err = msgp.WrapError(err, "OnlineInfos", za0001)
 				return
 			}
 		}
 	}
-	// string "PendingRewards"
-	o = append(o, 0xae, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.PendingRewards)))
-	for za0003 := range z.PendingRewards {
-		if z.PendingRewards[za0003] == nil {
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ValidatorOnlineInfos) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "StartHeight"
+	o = append(o, 0x82, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendInt64(o, z.StartHeight)
+	// string "OnlineInfos"
+	o = append(o, 0xab, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.OnlineInfos)))
+	for za0001 := range z.OnlineInfos {
+		if z.OnlineInfos[za0001] == nil {
 			o = msgp.AppendNil(o)
 		} else {
-			o, err = z.PendingRewards[za0003].MarshalMsg(o)
+			o, err = z.OnlineInfos[za0001].MarshalMsg(o)
 			if err != nil {
-				err = msgp.WrapError(err, "PendingRewards", za0003)
+				err = msgp.WrapError(err, "OnlineInfos", za0001)
 				return
 			}
 		}
@@ -1268,7 +6787,7 @@ func (z *StakingInfo) MarshalMsg(b []byte) (o []byte, err error) {
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *ValidatorOnlineInfos) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1285,107 +6804,201 @@ func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "GenesisMainnetBlockHeight":
-			z.GenesisMainnetBlockHeight, bts, err = msgp.ReadInt64Bytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "GenesisMainnetBlockHeight")
-				return
-			}
-		case "CurrEpochNum":
-			z.CurrEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
+		case "StartHeight":
+			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "CurrEpochNum")
+				err = msgp.WrapError(err, "StartHeight")
 				return
 			}
-		case "Validators":
+		case "OnlineInfos":
 			var zb0002 uint32
 			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Validators")
+				err = msgp.WrapError(err, "OnlineInfos")
 				return
 			}
-			if cap(z.Validators) >= int(zb0002) {
-				z.Validators = (z.Validators)[:zb0002]
+			if cap(z.OnlineInfos) >= int(zb0002) {
+				z.OnlineInfos = (z.OnlineInfos)[:zb0002]
 			} else {
-				z.Validators = make([]*Validator, zb0002)
+				z.OnlineInfos = make([]*OnlineInfo, zb0002)
 			}
-			for za0001 := range z.Validators {
+			for za0001 := range z.OnlineInfos {
 				if msgp.IsNil(bts) {
 					bts, err = msgp.ReadNilBytes(bts)
 					if err != nil {
 						return
 					}
-					z.Validators[za0001] = nil
+					z.OnlineInfos[za0001] = nil
 				} else {
-					if z.Validators[za0001] == nil {
-						z.Validators[za0001] = new(Validator)
+					if z.OnlineInfos[za0001] == nil {
+						z.OnlineInfos[za0001] = new(OnlineInfo)
 					}
-					bts, err = z.Validators[za0001].UnmarshalMsg(bts)
+					bts, err = z.OnlineInfos[za0001].UnmarshalMsg(bts)
 					if err != nil {
-						err = msgp.WrapError(err, "Validators", za0001)
+						err = msgp.WrapError(err, "OnlineInfos", za0001)
 						return
 					}
 				}
 			}
-		case "ValidatorsUpdate":
-			var zb0003 uint32
-			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ValidatorOnlineInfos) Msgsize() (s int) {
+	s = 1 + 12 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize
+	for za0001 := range z.OnlineInfos {
+		if z.OnlineInfos[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.OnlineInfos[za0001].Msgsize()
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ValidatorRewardAccount) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			err = dc.ReadExactBytes((z.Address)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "RewardMode":
+			z.RewardMode, err = dc.ReadUint8()
+			if err != nil {
+				err = msgp.WrapError(err, "RewardMode")
+				return
+			}
+		case "Accumulated":
+			err = dc.ReadExactBytes((z.Accumulated)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Accumulated")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ValidatorRewardAccount) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Address"
+	err = en.Append(0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Address)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Address")
+		return
+	}
+	// write "RewardMode"
+	err = en.Append(0xaa, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x4d, 0x6f, 0x64, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint8(z.RewardMode)
+	if err != nil {
+		err = msgp.WrapError(err, "RewardMode")
+		return
+	}
+	// write "Accumulated"
+	err = en.Append(0xab, 0x41, 0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes((z.Accumulated)[:])
+	if err != nil {
+		err = msgp.WrapError(err, "Accumulated")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ValidatorRewardAccount) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Address"
+	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendBytes(o, (z.Address)[:])
+	// string "RewardMode"
+	o = append(o, 0xaa, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x4d, 0x6f, 0x64, 0x65)
+	o = msgp.AppendUint8(o, z.RewardMode)
+	// string "Accumulated"
+	o = append(o, 0xab, 0x41, 0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64)
+	o = msgp.AppendBytes(o, (z.Accumulated)[:])
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ValidatorRewardAccount) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			bts, err = msgp.ReadExactBytes(bts, (z.Address)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorsUpdate")
+				err = msgp.WrapError(err, "Address")
 				return
 			}
-			if cap(z.ValidatorsUpdate) >= int(zb0003) {
-				z.ValidatorsUpdate = (z.ValidatorsUpdate)[:zb0003]
-			} else {
-				z.ValidatorsUpdate = make([]*Validator, zb0003)
-			}
-			for za0002 := range z.ValidatorsUpdate {
-				if msgp.IsNil(bts) {
-					bts, err = msgp.ReadNilBytes(bts)
-					if err != nil {
-						return
-					}
-					z.ValidatorsUpdate[za0002] = nil
-				} else {
-					if z.ValidatorsUpdate[za0002] == nil {
-						z.ValidatorsUpdate[za0002] = new(Validator)
-					}
-					bts, err = z.ValidatorsUpdate[za0002].UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "ValidatorsUpdate", za0002)
-						return
-					}
-				}
-			}
-		case "PendingRewards":
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "RewardMode":
+			z.RewardMode, bts, err = msgp.ReadUint8Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "PendingRewards")
+				err = msgp.WrapError(err, "RewardMode")
 				return
 			}
-			if cap(z.PendingRewards) >= int(zb0004) {
-				z.PendingRewards = (z.PendingRewards)[:zb0004]
-			} else {
-				z.PendingRewards = make([]*PendingReward, zb0004)
-			}
-			for za0003 := range z.PendingRewards {
-				if msgp.IsNil(bts) {
-					bts, err = msgp.ReadNilBytes(bts)
-					if err != nil {
-						return
-					}
-					z.PendingRewards[za0003] = nil
-				} else {
-					if z.PendingRewards[za0003] == nil {
-						z.PendingRewards[za0003] = new(PendingReward)
-					}
-					bts, err = z.PendingRewards[za0003].UnmarshalMsg(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "PendingRewards", za0003)
-						return
-					}
-				}
+		case "Accumulated":
+			bts, err = msgp.ReadExactBytes(bts, (z.Accumulated)[:])
+			if err != nil {
+				err = msgp.WrapError(err, "Accumulated")
+				return
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -1400,36 +7013,13 @@ func (z *StakingInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *StakingInfo) Msgsize() (s int) {
-	s = 1 + 26 + msgp.Int64Size + 13 + msgp.Int64Size + 11 + msgp.ArrayHeaderSize
-	for za0001 := range z.Validators {
-		if z.Validators[za0001] == nil {
-			s += msgp.NilSize
-		} else {
-			s += z.Validators[za0001].Msgsize()
-		}
-	}
-	s += 17 + msgp.ArrayHeaderSize
-	for za0002 := range z.ValidatorsUpdate {
-		if z.ValidatorsUpdate[za0002] == nil {
-			s += msgp.NilSize
-		} else {
-			s += z.ValidatorsUpdate[za0002].Msgsize()
-		}
-	}
-	s += 15 + msgp.ArrayHeaderSize
-	for za0003 := range z.PendingRewards {
-		if z.PendingRewards[za0003] == nil {
-			s += msgp.NilSize
-		} else {
-			s += z.PendingRewards[za0003].Msgsize()
-		}
-	}
+func (z *ValidatorRewardAccount) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 11 + msgp.Uint8Size + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize))
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *Validator) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *ValidatorUnbonding) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1464,28 +7054,22 @@ func (z *Validator) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "RewardTo")
 				return
 			}
-		case "VotingPower":
-			z.VotingPower, err = dc.ReadInt64()
-			if err != nil {
-				err = msgp.WrapError(err, "VotingPower")
-				return
-			}
-		case "Introduction":
-			z.Introduction, err = dc.ReadString()
+		case "Amount":
+			err = dc.ReadExactBytes((z.Amount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Introduction")
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
-		case "StakedCoins":
-			err = dc.ReadExactBytes((z.StakedCoins)[:])
+		case "StartEpochNum":
+			z.StartEpochNum, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "StakedCoins")
+				err = msgp.WrapError(err, "StartEpochNum")
 				return
 			}
-		case "IsRetiring":
-			z.IsRetiring, err = dc.ReadBool()
+		case "MatureEpochNum":
+			z.MatureEpochNum, err = dc.ReadInt64()
 			if err != nil {
-				err = msgp.WrapError(err, "IsRetiring")
+				err = msgp.WrapError(err, "MatureEpochNum")
 				return
 			}
 		default:
@@ -1500,10 +7084,10 @@ func (z *Validator) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *Validator) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+func (z *ValidatorUnbonding) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
 	// write "Address"
-	err = en.Append(0x87, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	err = en.Append(0x86, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
 	if err != nil {
 		return
 	}
@@ -1532,55 +7116,45 @@ func (z *Validator) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "RewardTo")
 		return
 	}
-	// write "VotingPower"
-	err = en.Append(0xab, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72)
-	if err != nil {
-		return
-	}
-	err = en.WriteInt64(z.VotingPower)
-	if err != nil {
-		err = msgp.WrapError(err, "VotingPower")
-		return
-	}
-	// write "Introduction"
-	err = en.Append(0xac, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	// write "Amount"
+	err = en.Append(0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.Introduction)
+	err = en.WriteBytes((z.Amount)[:])
 	if err != nil {
-		err = msgp.WrapError(err, "Introduction")
+		err = msgp.WrapError(err, "Amount")
 		return
 	}
-	// write "StakedCoins"
-	err = en.Append(0xab, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x69, 0x6e, 0x73)
+	// write "StartEpochNum"
+	err = en.Append(0xad, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
 	if err != nil {
 		return
 	}
-	err = en.WriteBytes((z.StakedCoins)[:])
+	err = en.WriteInt64(z.StartEpochNum)
 	if err != nil {
-		err = msgp.WrapError(err, "StakedCoins")
+		err = msgp.WrapError(err, "StartEpochNum")
 		return
 	}
-	// write "IsRetiring"
-	err = en.Append(0xaa, 0x49, 0x73, 0x52, 0x65, 0x74, 0x69, 0x72, 0x69, 0x6e, 0x67)
+	// write "MatureEpochNum"
+	err = en.Append(0xae, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.IsRetiring)
+	err = en.WriteInt64(z.MatureEpochNum)
 	if err != nil {
-		err = msgp.WrapError(err, "IsRetiring")
+		err = msgp.WrapError(err, "MatureEpochNum")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *Validator) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *ValidatorUnbonding) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// map header, size 6
 	// string "Address"
-	o = append(o, 0x87, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = append(o, 0x86, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
 	o = msgp.AppendBytes(o, (z.Address)[:])
 	// string "Pubkey"
 	o = append(o, 0xa6, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79)
@@ -1588,23 +7162,20 @@ func (z *Validator) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "RewardTo"
 	o = append(o, 0xa8, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x6f)
 	o = msgp.AppendBytes(o, (z.RewardTo)[:])
-	// string "VotingPower"
-	o = append(o, 0xab, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72)
-	o = msgp.AppendInt64(o, z.VotingPower)
-	// string "Introduction"
-	o = append(o, 0xac, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e)
-	o = msgp.AppendString(o, z.Introduction)
-	// string "StakedCoins"
-	o = append(o, 0xab, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x69, 0x6e, 0x73)
-	o = msgp.AppendBytes(o, (z.StakedCoins)[:])
-	// string "IsRetiring"
-	o = append(o, 0xaa, 0x49, 0x73, 0x52, 0x65, 0x74, 0x69, 0x72, 0x69, 0x6e, 0x67)
-	o = msgp.AppendBool(o, z.IsRetiring)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendBytes(o, (z.Amount)[:])
+	// string "StartEpochNum"
+	o = append(o, 0xad, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.StartEpochNum)
+	// string "MatureEpochNum"
+	o = append(o, 0xae, 0x4d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4e, 0x75, 0x6d)
+	o = msgp.AppendInt64(o, z.MatureEpochNum)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *Validator) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *ValidatorUnbonding) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1639,28 +7210,22 @@ func (z *Validator) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "RewardTo")
 				return
 			}
-		case "VotingPower":
-			z.VotingPower, bts, err = msgp.ReadInt64Bytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "VotingPower")
-				return
-			}
-		case "Introduction":
-			z.Introduction, bts, err = msgp.ReadStringBytes(bts)
+		case "Amount":
+			bts, err = msgp.ReadExactBytes(bts, (z.Amount)[:])
 			if err != nil {
-				err = msgp.WrapError(err, "Introduction")
+				err = msgp.WrapError(err, "Amount")
 				return
 			}
-		case "StakedCoins":
-			bts, err = msgp.ReadExactBytes(bts, (z.StakedCoins)[:])
+		case "StartEpochNum":
+			z.StartEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "StakedCoins")
+				err = msgp.WrapError(err, "StartEpochNum")
 				return
 			}
-		case "IsRetiring":
-			z.IsRetiring, bts, err = msgp.ReadBoolBytes(bts)
+		case "MatureEpochNum":
+			z.MatureEpochNum, bts, err = msgp.ReadInt64Bytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "IsRetiring")
+				err = msgp.WrapError(err, "MatureEpochNum")
 				return
 			}
 		default:
@@ -1676,13 +7241,13 @@ func (z *Validator) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *Validator) Msgsize() (s int) {
-	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 12 + msgp.Int64Size + 13 + msgp.StringPrefixSize + len(z.Introduction) + 12 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 11 + msgp.BoolSize
+func (z *ValidatorUnbonding) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 9 + msgp.ArrayHeaderSize + (20 * (msgp.ByteSize)) + 7 + msgp.ArrayHeaderSize + (32 * (msgp.ByteSize)) + 14 + msgp.Int64Size + 15 + msgp.Int64Size
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *ValidatorOnlineInfos) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *ValidatorUnbondingList) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1699,39 +7264,33 @@ func (z *ValidatorOnlineInfos) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "StartHeight":
-			z.StartHeight, err = dc.ReadInt64()
-			if err != nil {
-				err = msgp.WrapError(err, "StartHeight")
-				return
-			}
-		case "OnlineInfos":
+		case "Unbondings":
 			var zb0002 uint32
 			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "OnlineInfos")
+				err = msgp.WrapError(err, "Unbondings")
 				return
 			}
-			if cap(z.OnlineInfos) >= int(zb0002) {
-				z.OnlineInfos = (z.OnlineInfos)[:zb0002]
+			if cap(z.Unbondings) >= int(zb0002) {
+				z.Unbondings = (z.Unbondings)[:zb0002]
 			} else {
-				z.OnlineInfos = make([]*OnlineInfo, zb0002)
+				z.Unbondings = make([]*ValidatorUnbonding, zb0002)
 			}
-			for za0001 := range z.OnlineInfos {
+			for za0001 := range z.Unbondings {
 				if dc.IsNil() {
 					err = dc.ReadNil()
 					if err != nil {
-						err = msgp.WrapError(err, "OnlineInfos", za0001)
+						err = msgp.WrapError(err, "Unbondings", za0001)
 						return
 					}
-					z.OnlineInfos[za0001] = nil
+					z.Unbondings[za0001] = nil
 				} else {
-					if z.OnlineInfos[za0001] == nil {
-						z.OnlineInfos[za0001] = new(OnlineInfo)
+					if z.Unbondings[za0001] == nil {
+						z.Unbondings[za0001] = new(ValidatorUnbonding)
 					}
-					err = z.OnlineInfos[za0001].DecodeMsg(dc)
+					err = z.Unbondings[za0001].DecodeMsg(dc)
 					if err != nil {
-						err = msgp.WrapError(err, "OnlineInfos", za0001)
+						err = msgp.WrapError(err, "Unbondings", za0001)
 						return
 					}
 				}
@@ -1748,38 +7307,28 @@ func (z *ValidatorOnlineInfos) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *ValidatorOnlineInfos) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
-	// write "StartHeight"
-	err = en.Append(0x82, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	if err != nil {
-		return
-	}
-	err = en.WriteInt64(z.StartHeight)
-	if err != nil {
-		err = msgp.WrapError(err, "StartHeight")
-		return
-	}
-	// write "OnlineInfos"
-	err = en.Append(0xab, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73)
+func (z *ValidatorUnbondingList) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Unbondings"
+	err = en.Append(0x81, 0xaa, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.OnlineInfos)))
+	err = en.WriteArrayHeader(uint32(len(z.Unbondings)))
 	if err != nil {
-		err = msgp.WrapError(err, "OnlineInfos")
+		err = msgp.WrapError(err, "Unbondings")
 		return
 	}
-	for za0001 := range z.OnlineInfos {
-		if z.OnlineInfos[za0001] == nil {
+	for za0001 := range z.Unbondings {
+		if z.Unbondings[za0001] == nil {
 			err = en.WriteNil()
 			if err != nil {
 				return
 			}
 		} else {
-			err = z.OnlineInfos[za0001].EncodeMsg(en)
+			err = z.Unbondings[za0001].EncodeMsg(en)
 			if err != nil {
-				err = msgp.WrapError(err, "OnlineInfos", za0001)
+				err = msgp.WrapError(err, "Unbondings", za0001)
 				return
 			}
 		}
@@ -1788,22 +7337,19 @@ func (z *ValidatorOnlineInfos) EncodeMsg(en *msgp.Writer) (err error) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *ValidatorOnlineInfos) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *ValidatorUnbondingList) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "StartHeight"
-	o = append(o, 0x82, 0xab, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74)
-	o = msgp.AppendInt64(o, z.StartHeight)
-	// string "OnlineInfos"
-	o = append(o, 0xab, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.OnlineInfos)))
-	for za0001 := range z.OnlineInfos {
-		if z.OnlineInfos[za0001] == nil {
+	// map header, size 1
+	// string "Unbondings"
+	o = append(o, 0x81, 0xaa, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Unbondings)))
+	for za0001 := range z.Unbondings {
+		if z.Unbondings[za0001] == nil {
 			o = msgp.AppendNil(o)
 		} else {
-			o, err = z.OnlineInfos[za0001].MarshalMsg(o)
+			o, err = z.Unbondings[za0001].MarshalMsg(o)
 			if err != nil {
-				err = msgp.WrapError(err, "OnlineInfos", za0001)
+				err = msgp.WrapError(err, "Unbondings", za0001)
 				return
 			}
 		}
@@ -1812,7 +7358,7 @@ func (z *ValidatorOnlineInfos) MarshalMsg(b []byte) (o []byte, err error) {
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *ValidatorOnlineInfos) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *ValidatorUnbondingList) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1829,38 +7375,32 @@ func (z *ValidatorOnlineInfos) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "StartHeight":
-			z.StartHeight, bts, err = msgp.ReadInt64Bytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "StartHeight")
-				return
-			}
-		case "OnlineInfos":
+		case "Unbondings":
 			var zb0002 uint32
 			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "OnlineInfos")
+				err = msgp.WrapError(err, "Unbondings")
 				return
 			}
-			if cap(z.OnlineInfos) >= int(zb0002) {
-				z.OnlineInfos = (z.OnlineInfos)[:zb0002]
+			if cap(z.Unbondings) >= int(zb0002) {
+				z.Unbondings = (z.Unbondings)[:zb0002]
 			} else {
-				z.OnlineInfos = make([]*OnlineInfo, zb0002)
+				z.Unbondings = make([]*ValidatorUnbonding, zb0002)
 			}
-			for za0001 := range z.OnlineInfos {
+			for za0001 := range z.Unbondings {
 				if msgp.IsNil(bts) {
 					bts, err = msgp.ReadNilBytes(bts)
 					if err != nil {
 						return
 					}
-					z.OnlineInfos[za0001] = nil
+					z.Unbondings[za0001] = nil
 				} else {
-					if z.OnlineInfos[za0001] == nil {
-						z.OnlineInfos[za0001] = new(OnlineInfo)
+					if z.Unbondings[za0001] == nil {
+						z.Unbondings[za0001] = new(ValidatorUnbonding)
 					}
-					bts, err = z.OnlineInfos[za0001].UnmarshalMsg(bts)
+					bts, err = z.Unbondings[za0001].UnmarshalMsg(bts)
 					if err != nil {
-						err = msgp.WrapError(err, "OnlineInfos", za0001)
+						err = msgp.WrapError(err, "Unbondings", za0001)
 						return
 					}
 				}
@@ -1878,13 +7418,13 @@ func (z *ValidatorOnlineInfos) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *ValidatorOnlineInfos) Msgsize() (s int) {
-	s = 1 + 12 + msgp.Int64Size + 12 + msgp.ArrayHeaderSize
-	for za0001 := range z.OnlineInfos {
-		if z.OnlineInfos[za0001] == nil {
+func (z *ValidatorUnbondingList) Msgsize() (s int) {
+	s = 1 + 11 + msgp.ArrayHeaderSize
+	for za0001 := range z.Unbondings {
+		if z.Unbondings[za0001] == nil {
 			s += msgp.NilSize
 		} else {
-			s += z.OnlineInfos[za0001].Msgsize()
+			s += z.Unbondings[za0001].Msgsize()
 		}
 	}
 	return
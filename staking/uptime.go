@@ -0,0 +1,226 @@
+package staking
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/holiman/uint256"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	uptimeHistorySlotHashPrefix = [4]byte{'u', 'p', 't', 'm'}
+	uptimeBitmapSlotHashPrefix  = [4]byte{'u', 'p', 't', 'b'}
+)
+
+// the bitmaps of an uptime record, each of which is stored in the slots of 256 bits
+const (
+	signedBitmap byte = iota
+	missedBitmap
+	proposedBitmap
+	bitmapCount
+
+	bitsInBitmapWord = 256
+)
+
+func IsUptimeHistoryFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.UptimeHistoryForkHeight
+}
+
+func getSlotForUptimeHistory(addr [20]byte) string {
+	key := sha256.Sum256(append(uptimeHistorySlotHashPrefix[:], addr[:]...))
+	return string(key[:])
+}
+
+func getSlotForUptimeBitmapWord(addr [20]byte, bitmap byte, wordIndex int64) string {
+	var buf [4 + 20 + 1 + 8]byte
+	copy(buf[:4], uptimeBitmapSlotHashPrefix[:])
+	copy(buf[4:24], addr[:])
+	buf[24] = bitmap
+	binary.BigEndian.PutUint64(buf[25:], uint64(wordIndex))
+	key := sha256.Sum256(buf[:])
+	return string(key[:])
+}
+
+func LoadUptimeWindow(ctx *mevmtypes.Context) (window types.UptimeWindow) {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotUptimeWindow)
+	if len(bz) == 0 {
+		return
+	}
+	_, err := window.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func SaveUptimeWindow(ctx *mevmtypes.Context, window types.UptimeWindow) {
+	bz, err := window.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, SlotUptimeWindow, bz)
+}
+
+func LoadUptimeHistory(ctx *mevmtypes.Context, addr [20]byte) (history types.UptimeHistory) {
+	bz := ctx.GetStorageAt(StakingContractSequence, getSlotForUptimeHistory(addr))
+	if len(bz) == 0 {
+		return
+	}
+	_, err := history.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func SaveUptimeHistory(ctx *mevmtypes.Context, addr [20]byte, history types.UptimeHistory) {
+	bz, err := history.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, getSlotForUptimeHistory(addr), bz)
+}
+
+func setBit(bitmap []byte, i int64) {
+	bitmap[i/8] |= 1 << (i % 8)
+}
+
+func IsBitSet(bitmap []byte, i int64) bool {
+	return i >= 0 && i/8 < int64(len(bitmap)) && bitmap[i/8]&(1<<(i%8)) != 0
+}
+
+// set the i-th bit of a bitmap of the validator in current window, only rewriting the word containing it
+func setUptimeBit(ctx *mevmtypes.Context, addr [20]byte, bitmap byte, i int64) {
+	slot := getSlotForUptimeBitmapWord(addr, bitmap, i/bitsInBitmapWord)
+	word := make([]byte, bitsInBitmapWord/8)
+	copy(word, ctx.GetStorageAt(StakingContractSequence, slot))
+	setBit(word, i%bitsInBitmapWord)
+	ctx.SetStorageAt(StakingContractSequence, slot, word)
+}
+
+// Assemble the validator's record in the window from the words of its bitmaps
+func loadUptimeRecord(ctx *mevmtypes.Context, window types.UptimeWindow, addr [20]byte) *types.UptimeRecord {
+	bitmapSize := (window.Size + 7) / 8
+	var bitmaps [bitmapCount][]byte
+	for bitmap := range bitmaps {
+		bitmaps[bitmap] = make([]byte, bitmapSize)
+		for i := int64(0); i*bitsInBitmapWord/8 < bitmapSize; i++ {
+			word := ctx.GetStorageAt(StakingContractSequence, getSlotForUptimeBitmapWord(addr, byte(bitmap), i))
+			copy(bitmaps[bitmap][i*bitsInBitmapWord/8:], word)
+		}
+	}
+	return &types.UptimeRecord{
+		Address:        addr,
+		StartHeight:    window.StartHeight,
+		SignedBitmap:   bitmaps[signedBitmap],
+		MissedBitmap:   bitmaps[missedBitmap],
+		ProposedBitmap: bitmaps[proposedBitmap],
+	}
+}
+
+func deleteUptimeBitmaps(ctx *mevmtypes.Context, window types.UptimeWindow, addr [20]byte) {
+	for bitmap := byte(0); bitmap < bitmapCount; bitmap++ {
+		for i := int64(0); i*bitsInBitmapWord < window.Size; i++ {
+			ctx.DeleteStorageAt(StakingContractSequence, getSlotForUptimeBitmapWord(addr, bitmap, i))
+		}
+	}
+}
+
+// Record which active validators signed or missed the block at current height, and which proposed it.
+// The windows are aligned to the multiples of GovParamOnlineWindowSize, whose value is fixed in a window
+// once it starts. When a new window starts, the records of the last one are moved into the validators'
+// histories. SlotUptimeWindow is only written when a window starts or a validator joins it.
+func updateUptimeWindow(ctx *mevmtypes.Context, activeValidators []*types.Validator, proposer [20]byte, voters [][]byte) {
+	window := LoadUptimeWindow(ctx)
+	windowChanged := false
+	if window.Size == 0 || ctx.Height >= window.StartHeight+window.Size {
+		archiveUptimeWindow(ctx, window)
+		size := int64(GetGovParam(ctx, GovParamOnlineWindowSize))
+		startHeight := ctx.Height - ctx.Height%size
+		if lastEnd := window.StartHeight + window.Size; startHeight < lastEnd {
+			startHeight = lastEnd // the size was shrunk by governance, and the windows cannot overlap
+		}
+		window = types.UptimeWindow{StartHeight: startHeight, Size: size}
+		windowChanged = true
+	}
+	addrSet := make(map[[20]byte]bool, len(window.Addresses))
+	for _, addr := range window.Addresses {
+		addrSet[addr] = true
+	}
+	voterMap := make(map[[20]byte]bool, len(voters))
+	for _, voter := range voters {
+		var v [20]byte
+		copy(v[:], voter)
+		voterMap[v] = true
+	}
+	offset := ctx.Height - window.StartHeight
+	for _, val := range activeValidators {
+		if !addrSet[val.Address] {
+			window.Addresses = append(window.Addresses, val.Address)
+			addrSet[val.Address] = true
+			windowChanged = true
+		}
+		var consAddr [20]byte
+		copy(consAddr[:], ed25519.PubKey(val.Pubkey[:]).Address().Bytes())
+		if voterMap[consAddr] {
+			setUptimeBit(ctx, val.Address, signedBitmap, offset)
+		} else {
+			setUptimeBit(ctx, val.Address, missedBitmap, offset)
+		}
+		if consAddr == proposer {
+			setUptimeBit(ctx, val.Address, proposedBitmap, offset)
+		}
+	}
+	if windowChanged {
+		SaveUptimeWindow(ctx, window)
+	}
+}
+
+// only the latest param.UptimeHistoryWindowCount records are kept in a validator's history
+func archiveUptimeWindow(ctx *mevmtypes.Context, window types.UptimeWindow) {
+	for _, addr := range window.Addresses {
+		history := LoadUptimeHistory(ctx, addr)
+		history.Records = append(history.Records, loadUptimeRecord(ctx, window, addr))
+		if n := len(history.Records) - int(param.UptimeHistoryWindowCount); n > 0 {
+			history.Records = history.Records[n:]
+		}
+		SaveUptimeHistory(ctx, addr, history)
+		deleteUptimeBitmaps(ctx, window, addr)
+	}
+}
+
+// The validator with 'pubkey' may have retired and be in unbonding
+func recordDuplicateSig(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, slashed *uint256.Int) {
+	addr, ok := getValidatorAddrByPubkey(ctx, info, pubkey)
+	if !ok {
+		return
+	}
+	history := LoadUptimeHistory(ctx, addr)
+	history.DuplicateSigs = append(history.DuplicateSigs, &types.DuplicateSigRecord{
+		Height:  ctx.Height,
+		Pubkey:  pubkey,
+		Slashed: slashed.Bytes32(),
+	})
+	SaveUptimeHistory(ctx, addr, history)
+}
+
+// Returns a validator's uptime records of the past windows and the current one, the oldest first,
+// and the duplicate vote evidences against it.
+func GetValidatorUptime(ctx *mevmtypes.Context, addr [20]byte) (records []*types.UptimeRecord, duplicateSigs []*types.DuplicateSigRecord) {
+	history := LoadUptimeHistory(ctx, addr)
+	records = history.Records
+	window := LoadUptimeWindow(ctx)
+	for _, a := range window.Addresses {
+		if a == addr {
+			records = append(records, loadUptimeRecord(ctx, window, addr))
+			break
+		}
+	}
+	return records, history.DuplicateSigs
+}
@@ -0,0 +1,94 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestUptimeHistory(t *testing.T) {
+	valA := &stakingtypes.Validator{Address: common.Address{0xad, 0x01}, Pubkey: [32]byte{0x01}, VotingPower: 1}
+	valB := &stakingtypes.Validator{Address: common.Address{0xad, 0x02}, Pubkey: [32]byte{0x02}, VotingPower: 1}
+	consAddrA := ed25519.PubKey(valA.Pubkey[:]).Address().Bytes()
+	var proposerA [20]byte
+	copy(proposerA[:], consAddrA)
+	ctx := setupDelegationCtx(valA.Pubkey, valA.Address, common.Address{0xde, 0x01})
+	active := []*stakingtypes.Validator{valA, valB}
+
+	// B misses the first block of a window, and A proposes the second one
+	h := param.UptimeHistoryForkHeight - param.UptimeHistoryForkHeight%param.OnlineWindowSize + param.OnlineWindowSize
+	ctx.SetCurrentHeight(h)
+	updateUptimeWindow(ctx, active, [20]byte{}, [][]byte{consAddrA})
+	ctx.SetCurrentHeight(h + 1)
+	updateUptimeWindow(ctx, active, proposerA, [][]byte{consAddrA, ed25519.PubKey(valB.Pubkey[:]).Address().Bytes()})
+	records, _ := GetValidatorUptime(ctx, valB.Address)
+	require.Equal(t, 1, len(records))
+	require.Equal(t, h, records[0].StartHeight)
+	require.True(t, IsBitSet(records[0].MissedBitmap, 0))
+	require.False(t, IsBitSet(records[0].SignedBitmap, 0))
+	require.True(t, IsBitSet(records[0].SignedBitmap, 1))
+	records, _ = GetValidatorUptime(ctx, valA.Address)
+	require.True(t, IsBitSet(records[0].ProposedBitmap, 1))
+
+	// the records are archived when a new window starts, and only the latest ones are kept
+	for i := int64(1); i <= param.UptimeHistoryWindowCount+1; i++ {
+		ctx.SetCurrentHeight(h + i*param.OnlineWindowSize)
+		updateUptimeWindow(ctx, active, [20]byte{}, nil)
+	}
+	require.Equal(t, int(param.UptimeHistoryWindowCount), len(LoadUptimeHistory(ctx, valB.Address).Records))
+	records, _ = GetValidatorUptime(ctx, valB.Address)
+	require.Equal(t, int(param.UptimeHistoryWindowCount)+1, len(records))
+	require.Equal(t, h+param.OnlineWindowSize, records[0].StartHeight)
+	require.Equal(t, ctx.Height, records[len(records)-1].StartHeight)
+
+	// the duplicate vote evidences are recorded with the slashed coins
+	info := LoadStakingInfo(ctx)
+	recordDuplicateSig(ctx, &info, valA.Pubkey, bch(1))
+	_, duplicateSigs := GetValidatorUptime(ctx, valA.Address)
+	require.Equal(t, 1, len(duplicateSigs))
+	require.Equal(t, ctx.Height, duplicateSigs[0].Height)
+	require.Equal(t, bch(1).Bytes32(), duplicateSigs[0].Slashed)
+}
+
+func TestUptimeWindowSizeByGovernance(t *testing.T) {
+	val := &stakingtypes.Validator{Address: common.Address{0xad, 0x01}, Pubkey: [32]byte{0x01}, VotingPower: 1}
+	consAddr := ed25519.PubKey(val.Pubkey[:]).Address().Bytes()
+	ctx := setupDelegationCtx(val.Pubkey, val.Address, common.Address{0xde, 0x01})
+	h := param.UptimeHistoryForkHeight - param.UptimeHistoryForkHeight%param.OnlineWindowSize + param.OnlineWindowSize
+	ctx.SetCurrentHeight(h)
+	SaveGovParam(ctx, stakingtypes.GovParam{ID: GovParamOnlineWindowSize, Value: 300})
+	active := []*stakingtypes.Validator{val}
+
+	// the bitmaps are stored in words of 256 bits, and the window is only saved when it starts
+	updateUptimeWindow(ctx, active, [20]byte{}, [][]byte{consAddr})
+	window := LoadUptimeWindow(ctx)
+	require.Equal(t, h-h%300, window.StartHeight)
+	require.Equal(t, int64(300), window.Size)
+	offset := h - window.StartHeight
+	ctx.SetCurrentHeight(window.StartHeight + 299)
+	updateUptimeWindow(ctx, active, [20]byte{}, nil)
+	require.Equal(t, window, LoadUptimeWindow(ctx))
+	require.NotEmpty(t, ctx.GetStorageAt(StakingContractSequence, getSlotForUptimeBitmapWord(val.Address, missedBitmap, 1)))
+	records, _ := GetValidatorUptime(ctx, val.Address)
+	require.Equal(t, 1, len(records))
+	require.Equal(t, 38, len(records[0].SignedBitmap))
+	require.True(t, IsBitSet(records[0].SignedBitmap, offset))
+	require.True(t, IsBitSet(records[0].MissedBitmap, 299))
+
+	// a window keeps its size after the change, and the bitmaps are deleted once archived
+	SaveGovParam(ctx, stakingtypes.GovParam{ID: GovParamOnlineWindowSize, Value: 100})
+	ctx.SetCurrentHeight(window.StartHeight + 300)
+	updateUptimeWindow(ctx, active, [20]byte{}, nil)
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, getSlotForUptimeBitmapWord(val.Address, missedBitmap, 1)))
+	require.Equal(t, int64(100), LoadUptimeWindow(ctx).Size)
+	records, _ = GetValidatorUptime(ctx, val.Address)
+	require.Equal(t, 2, len(records))
+	require.Equal(t, 38, len(records[0].MissedBitmap))
+	require.Equal(t, 13, len(records[1].MissedBitmap))
+	require.True(t, IsBitSet(records[1].MissedBitmap, ctx.Height-records[1].StartHeight))
+}
@@ -0,0 +1,145 @@
+package staking
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/smartbch/moeingevm/ebp"
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+func IsValidatorUnbondingFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.ValidatorUnbondingForkHeight
+}
+
+func LoadValidatorUnbondingList(ctx *mevmtypes.Context) (list types.ValidatorUnbondingList) {
+	bz := ctx.GetStorageAt(StakingContractSequence, SlotValidatorUnbondings)
+	if len(bz) == 0 {
+		return
+	}
+	_, err := list.UnmarshalMsg(bz)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// An empty list is deleted
+func SaveValidatorUnbondingList(ctx *mevmtypes.Context, list types.ValidatorUnbondingList) {
+	if len(list.Unbondings) == 0 {
+		ctx.DeleteStorageAt(StakingContractSequence, SlotValidatorUnbondings)
+		return
+	}
+	bz, err := list.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	ctx.SetStorageAt(StakingContractSequence, SlotValidatorUnbondings, bz)
+}
+
+// Put the staked coins of the useless validators into the unbonding queue, instead of paying them back at once.
+// info.Validators is iterated to keep the queue's order deterministic.
+func startValidatorUnbondings(ctx *mevmtypes.Context, info *types.StakingInfo, uselessValMap map[[20]byte]struct{}) {
+	list := LoadValidatorUnbondingList(ctx)
+	count := len(list.Unbondings)
+	for _, val := range info.Validators {
+		if _, ok := uselessValMap[val.Address]; !ok {
+			continue
+		}
+		if uint256.NewInt(0).SetBytes32(val.StakedCoins[:]).IsZero() {
+			continue
+		}
+		list.Unbondings = append(list.Unbondings, &types.ValidatorUnbonding{
+			Address:        val.Address,
+			Pubkey:         val.Pubkey,
+			RewardTo:       val.RewardTo,
+			Amount:         val.StakedCoins,
+			StartEpochNum:  info.CurrEpochNum,
+			MatureEpochNum: info.CurrEpochNum + param.ValidatorUnbondingEpochCount,
+		})
+	}
+	if len(list.Unbondings) != count {
+		SaveValidatorUnbondingList(ctx, list)
+	}
+}
+
+// Slash 'amount' of coins from the unbonding entries of the retired validator with 'pubkey'.
+// These coins are burnt, together with the validator's pending rewards.
+func slashValidatorUnbondings(ctx *mevmtypes.Context, info *types.StakingInfo, pubkey [32]byte, amount *uint256.Int) (totalSlashed *uint256.Int) {
+	list := LoadValidatorUnbondingList(ctx)
+	totalSlashed = uint256.NewInt(0)
+	remainedAmount := amount.Clone()
+	newList := make([]*types.ValidatorUnbonding, 0, len(list.Unbondings))
+	found := false
+	for _, u := range list.Unbondings {
+		if u.Pubkey != pubkey {
+			newList = append(newList, u)
+			continue
+		}
+		if !found {
+			totalSlashed.Add(totalSlashed, info.ClearRewardsOf(u.Address))
+			found = true
+		}
+		coins := uint256.NewInt(0).SetBytes32(u.Amount[:])
+		if coins.Lt(remainedAmount) {
+			totalSlashed.Add(totalSlashed, coins)
+			remainedAmount.Sub(remainedAmount, coins)
+			continue // nothing left to be paid back
+		}
+		totalSlashed.Add(totalSlashed, remainedAmount)
+		coins.Sub(coins, remainedAmount)
+		remainedAmount.Clear()
+		if !coins.IsZero() {
+			u.Amount = coins.Bytes32()
+			newList = append(newList, u)
+		}
+	}
+	if !found {
+		return
+	}
+	list.Unbondings = newList
+	SaveValidatorUnbondingList(ctx, list)
+
+	// deduct the totalSlashed from stakingAcc and burn them, must no error, not check
+	_ = ebp.TransferFromSenderAccToBlackHoleAcc(ctx, StakingContractAddress, totalSlashed)
+	incrAllBurnt(ctx, totalSlashed)
+	return
+}
+
+// Returns the pubkeys of the validators in unbonding, with consensus addresses as keys
+func getUnbondingPubkeyMapByConsAddr(ctx *mevmtypes.Context) map[[20]byte][32]byte {
+	list := LoadValidatorUnbondingList(ctx)
+	res := make(map[[20]byte][32]byte, len(list.Unbondings))
+	var consAddr [20]byte
+	for _, u := range list.Unbondings {
+		copy(consAddr[:], ed25519.PubKey(u.Pubkey[:]).Address().Bytes())
+		res[consAddr] = u.Pubkey
+	}
+	return res
+}
+
+// Pay back the unbonding entries which are mature in currEpochNum to their rewardTo,
+// and return the ValidatorUnbondingMatured logs. An entry stakingAcc cannot pay is kept.
+func ReleaseMatureValidatorUnbondings(ctx *mevmtypes.Context, currEpochNum int64) (logs []mevmtypes.EvmLog) {
+	list := LoadValidatorUnbondingList(ctx)
+	newList := make([]*types.ValidatorUnbonding, 0, len(list.Unbondings))
+	for _, u := range list.Unbondings {
+		if u.MatureEpochNum > currEpochNum {
+			newList = append(newList, u)
+			continue
+		}
+		if err := transferFromStakingAcc(ctx, u.RewardTo, uint256.NewInt(0).SetBytes32(u.Amount[:])); err != nil {
+			newList = append(newList, u)
+			continue
+		}
+		logs = append(logs, buildValidatorUnbondingMaturedEvmLog(u))
+	}
+	if len(logs) != 0 {
+		list.Unbondings = newList
+		SaveValidatorUnbondingList(ctx, list)
+	}
+	return
+}
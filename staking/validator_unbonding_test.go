@@ -0,0 +1,67 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/smartbch/smartbch/param"
+)
+
+func TestValidatorUnbonding(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+
+	// paid back at once before the fork
+	ctx.SetCurrentHeight(param.ValidatorUnbondingForkHeight - 1)
+	info := LoadStakingInfo(ctx)
+	info.Validators[0].IsRetiring = true
+	info.Validators[0].VotingPower = 0
+	clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, 0, len(info.Validators))
+	require.Equal(t, bch(4), ctx.GetAccount(validatorAddr).Balance())
+	require.Equal(t, 0, len(LoadValidatorUnbondingList(ctx).Unbondings))
+
+	ctx = setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	ctx.SetCurrentHeight(param.ValidatorUnbondingForkHeight)
+	info = LoadStakingInfo(ctx)
+	info.Validators[0].IsRetiring = true
+	info.Validators[0].VotingPower = 0
+	clearUselessValidators(ctx, ctx.GetAccount(StakingContractAddress), &info)
+	require.Equal(t, 0, len(info.Validators))
+	require.Nil(t, ctx.GetAccount(validatorAddr))
+	require.Equal(t, bch(4), ctx.GetAccount(StakingContractAddress).Balance())
+	list := LoadValidatorUnbondingList(ctx)
+	require.Equal(t, 1, len(list.Unbondings))
+	require.Equal(t, [20]byte(validatorAddr), list.Unbondings[0].Address)
+	require.Equal(t, bch(4).Bytes32(), list.Unbondings[0].Amount)
+	require.Equal(t, int64(1), list.Unbondings[0].StartEpochNum)
+	require.Equal(t, 1+param.ValidatorUnbondingEpochCount, list.Unbondings[0].MatureEpochNum)
+
+	// still slashable during unbonding
+	var consAddr [20]byte
+	copy(consAddr[:], ed25519.PubKey(pubkey[:]).Address().Bytes())
+	require.Equal(t, pubkey, getUnbondingPubkeyMapByConsAddr(ctx)[consAddr])
+	totalSlashed := Slash(ctx, &info, pubkey, bch(1))
+	require.Equal(t, bch(1), totalSlashed)
+	require.Equal(t, bch(3), ctx.GetAccount(StakingContractAddress).Balance())
+	require.Equal(t, bch(3).Bytes32(), LoadValidatorUnbondingList(ctx).Unbondings[0].Amount)
+
+	// not mature yet
+	logs := ReleaseMatureValidatorUnbondings(ctx, param.ValidatorUnbondingEpochCount)
+	require.Equal(t, 0, len(logs))
+	require.Equal(t, 1, len(LoadValidatorUnbondingList(ctx).Unbondings))
+
+	logs = ReleaseMatureValidatorUnbondings(ctx, 1+param.ValidatorUnbondingEpochCount)
+	require.Equal(t, 1, len(logs))
+	require.Equal(t, common.Hash(HashOfEventValidatorUnbondingMatured), logs[0].Topics[0])
+	require.Equal(t, common.BytesToHash(validatorAddr[:]), logs[0].Topics[1])
+	require.Equal(t, bch(3).Bytes32(), *(*[32]byte)(logs[0].Data[32:]))
+	require.Equal(t, bch(3), ctx.GetAccount(validatorAddr).Balance())
+	require.True(t, ctx.GetAccount(StakingContractAddress).Balance().IsZero())
+	require.Equal(t, 0, len(LoadValidatorUnbondingList(ctx).Unbondings))
+	require.Empty(t, ctx.GetStorageAt(StakingContractSequence, SlotValidatorUnbondings))
+}
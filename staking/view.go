@@ -0,0 +1,172 @@
+package staking
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	mevmtypes "github.com/smartbch/moeingevm/types"
+
+	"github.com/smartbch/smartbch/param"
+	"github.com/smartbch/smartbch/staking/types"
+)
+
+var (
+	/*------selector------*/
+	/*interface Staking {
+		// following view methods are enabled after StakingViewForkHeight
+		//1904bb2e
+		function getValidator(address validator) external view returns (address rewardTo, bytes32 pubkey,
+			bytes32 introduction, uint stakedCoins, uint votingPower, bool isRetiring);
+		//9de70258
+		function getActiveValidators() external view returns (address[] memory validators,
+			bytes32[] memory pubkeys, uint[] memory votingPowers);
+		//f6ed2017
+		function getPendingRewards(address validator) external view returns (uint[] memory epochNums,
+			uint[] memory amounts);
+		//8453c794
+		function getCurrEpochNum() external view returns (uint);
+		//3fb58819
+		function getMinGasPrice() external view returns (uint);
+	}*/
+	SelectorGetValidator        = [4]byte{0x19, 0x04, 0xbb, 0x2e}
+	SelectorGetActiveValidators = [4]byte{0x9d, 0xe7, 0x02, 0x58}
+	SelectorGetPendingRewards   = [4]byte{0xf6, 0xed, 0x20, 0x17}
+	SelectorGetCurrEpochNum     = [4]byte{0x84, 0x53, 0xc7, 0x94}
+	SelectorGetMinGasPrice      = [4]byte{0x3f, 0xb5, 0x88, 0x19}
+
+	GasOfStakingViewOp uint64 = 20_000
+)
+
+func IsStakingViewFork(ctx *mevmtypes.Context) bool {
+	return ctx.Height >= param.StakingViewForkHeight
+}
+
+// Other contracts can call the view methods through Run, which reads the snapshot taken at the last Commit.
+// They are enabled since the block at StakingViewForkHeight is executed.
+func isReadonlyViewMethod(states *readonlyStates, input []byte) bool {
+	if len(input) < 4 || states == nil || states.height < param.StakingViewForkHeight {
+		return false
+	}
+	var selector [4]byte
+	copy(selector[:], input[:4])
+	switch selector {
+	case SelectorGetValidator, SelectorGetActiveValidators, SelectorGetPendingRewards,
+		SelectorGetCurrEpochNum, SelectorGetMinGasPrice:
+		return true
+	}
+	return false
+}
+
+func runViewMethod(states *readonlyStates, input []byte) ([]byte, error) {
+	var selector [4]byte
+	copy(selector[:], input[:4])
+	callData := input[4:]
+	switch selector {
+	case SelectorGetValidator:
+		return packValidator(states.stakingInfo, callData)
+	case SelectorGetActiveValidators:
+		return packActiveValidators(states.activeValidators), nil
+	case SelectorGetPendingRewards:
+		return packPendingRewards(states.stakingInfo, callData)
+	case SelectorGetCurrEpochNum:
+		return packReturnData("getCurrEpochNum", big.NewInt(states.stakingInfo.CurrEpochNum)), nil
+	case SelectorGetMinGasPrice:
+		return packReturnData("getMinGasPrice", new(big.Int).SetUint64(states.minGasPrice)), nil
+	}
+	return nil, InvalidArgument
+}
+
+func getValidator(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfStakingViewOp
+	info := LoadStakingInfo(ctx)
+	outData, err := packValidator(&info, tx.Data[4:])
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	status = StatusSuccess
+	return
+}
+
+func packValidator(info *types.StakingInfo, callData []byte) ([]byte, error) {
+	if len(callData) != 32 {
+		return nil, InvalidCallData
+	}
+	var addr common.Address
+	addr.SetBytes(callData[12:])
+	val := info.GetValidatorByAddr(addr)
+	if val == nil {
+		return nil, NoSuchValidator
+	}
+	var intro [32]byte
+	copy(intro[:], val.Introduction)
+	return packReturnData("getValidator", common.Address(val.RewardTo), val.Pubkey, intro,
+		new(big.Int).SetBytes(val.StakedCoins[:]), big.NewInt(val.VotingPower), val.IsRetiring), nil
+}
+
+func getActiveValidators(ctx *mevmtypes.Context) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	gasUsed = GasOfStakingViewOp
+	info := LoadStakingInfo(ctx)
+	outData = packActiveValidators(GetActiveValidators(ctx, info.Validators))
+	status = StatusSuccess
+	return
+}
+
+func packActiveValidators(activeValidators []*types.Validator) []byte {
+	addrs := make([]common.Address, len(activeValidators))
+	pubkeys := make([][32]byte, len(activeValidators))
+	votingPowers := make([]*big.Int, len(activeValidators))
+	for i, val := range activeValidators {
+		addrs[i] = val.Address
+		pubkeys[i] = val.Pubkey
+		votingPowers[i] = big.NewInt(val.VotingPower)
+	}
+	return packReturnData("getActiveValidators", addrs, pubkeys, votingPowers)
+}
+
+func getPendingRewards(ctx *mevmtypes.Context, tx *mevmtypes.TxToRun) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	status = StatusFailed
+	gasUsed = GasOfStakingViewOp
+	info := LoadStakingInfo(ctx)
+	outData, err := packPendingRewards(&info, tx.Data[4:])
+	if err != nil {
+		outData = []byte(err.Error())
+		return
+	}
+	status = StatusSuccess
+	return
+}
+
+func packPendingRewards(info *types.StakingInfo, callData []byte) ([]byte, error) {
+	if len(callData) != 32 {
+		return nil, InvalidCallData
+	}
+	var addr common.Address
+	addr.SetBytes(callData[12:])
+	epochNums := make([]*big.Int, 0, 2)
+	amounts := make([]*big.Int, 0, 2)
+	for _, pr := range info.PendingRewards {
+		if pr.Address == addr {
+			epochNums = append(epochNums, big.NewInt(pr.EpochNum))
+			amounts = append(amounts, new(big.Int).SetBytes(pr.Amount[:]))
+		}
+	}
+	return packReturnData("getPendingRewards", epochNums, amounts), nil
+}
+func getCurrEpochNum(ctx *mevmtypes.Context) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	gasUsed = GasOfStakingViewOp
+	info := LoadStakingInfo(ctx)
+	outData = packReturnData("getCurrEpochNum", big.NewInt(info.CurrEpochNum))
+	status = StatusSuccess
+	return
+}
+
+func getMinGasPrice(ctx *mevmtypes.Context) (status int, logs []mevmtypes.EvmLog, gasUsed uint64, outData []byte) {
+	gasUsed = GasOfStakingViewOp
+	minGasPrice := LoadMinGasPrice(ctx, false)
+	outData = packReturnData("getMinGasPrice", new(big.Int).SetUint64(minGasPrice))
+	status = StatusSuccess
+	return
+}
@@ -0,0 +1,138 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestStakingViewMethods(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	info := LoadStakingInfo(ctx)
+	info.Validators[0].Introduction = "val1"
+	info.Validators = append(info.Validators, &stakingtypes.Validator{
+		Address:     [20]byte{0xad, 0x02},
+		Pubkey:      [32]byte{0x02},
+		VotingPower: 0,
+		StakedCoins: bch(4).Bytes32(),
+	})
+	info.PendingRewards = []*stakingtypes.PendingReward{
+		{Address: validatorAddr, EpochNum: 0, Amount: uint256.NewInt(100).Bytes32()},
+		{Address: [20]byte{0xad, 0x02}, EpochNum: 1, Amount: uint256.NewInt(200).Bytes32()},
+		{Address: validatorAddr, EpochNum: 1, Amount: uint256.NewInt(300).Bytes32()},
+	}
+	SaveStakingInfo(ctx, info)
+	SaveMinGasPrice(ctx, 12345, false)
+
+	// not enabled before the fork
+	ctx.SetCurrentHeight(param.StakingViewForkHeight - 1)
+	status, _, out := execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetCurrEpochNum())
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, InvalidSelector.Error(), out)
+	ctx.SetCurrentHeight(param.StakingViewForkHeight)
+
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetValidator(validatorAddr))
+	require.Equal(t, StatusSuccess, status)
+	rewardTo, pk, intro, stakedCoins, votingPower, isRetiring := UnpackGetValidatorReturnData([]byte(out))
+	require.Equal(t, validatorAddr, rewardTo)
+	require.Equal(t, pubkey, pk)
+	require.Equal(t, "val1", stringFromBytes(intro[:]))
+	require.Equal(t, bch(4).ToBig().String(), stakedCoins.String())
+	require.Equal(t, int64(1), votingPower.Int64())
+	require.False(t, isRetiring)
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetValidator(common.Address{0xad, 0x03}))
+	require.Equal(t, StatusFailed, status)
+	require.Equal(t, NoSuchValidator.Error(), out)
+
+	// the validator without voting power is not active
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetActiveValidators())
+	require.Equal(t, StatusSuccess, status)
+	addrs, pubkeys, votingPowers := UnpackGetActiveValidatorsReturnData([]byte(out))
+	require.Equal(t, []common.Address{validatorAddr}, addrs)
+	require.Equal(t, [][32]byte{pubkey}, pubkeys)
+	require.Equal(t, 1, len(votingPowers))
+	require.Equal(t, int64(1), votingPowers[0].Int64())
+
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetPendingRewards(validatorAddr))
+	require.Equal(t, StatusSuccess, status)
+	epochNums, amounts := UnpackGetPendingRewardsReturnData([]byte(out))
+	require.Equal(t, 2, len(epochNums))
+	require.Equal(t, int64(0), epochNums[0].Int64())
+	require.Equal(t, int64(1), epochNums[1].Int64())
+	require.Equal(t, int64(100), amounts[0].Int64())
+	require.Equal(t, int64(300), amounts[1].Int64())
+
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetCurrEpochNum())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint64(1), uint256.NewInt(0).SetBytes([]byte(out)).Uint64())
+
+	status, _, out = execStakingTx(ctx, nil, validatorAddr, uint256.NewInt(0), PackGetMinGasPrice())
+	require.Equal(t, StatusSuccess, status)
+	require.Equal(t, uint64(12345), uint256.NewInt(0).SetBytes([]byte(out)).Uint64())
+}
+
+func TestStakingViewMethodsInRun(t *testing.T) {
+	pubkey := [32]byte{0x01}
+	validatorAddr := common.Address{0xad, 0x01}
+	ctx := setupDelegationCtx(pubkey, validatorAddr, common.Address{0xde, 0x01})
+	executor := &StakingContractExecutor{}
+	SaveMinGasPrice(ctx, 12345, false)
+
+	// not enabled before the fork
+	ctx.SetCurrentHeight(param.StakingViewForkHeight - 1)
+	UpdateReadonlyStates(ctx)
+	input := PackGetCurrEpochNum()
+	require.Equal(t, uint64(len(input))*SumVotingPowerGasPerByte+SumVotingPowerBaseGas, executor.RequiredGas(input))
+	_, err := executor.Run(input)
+	require.Equal(t, InvalidArgument, err)
+
+	ctx.SetCurrentHeight(param.StakingViewForkHeight)
+	UpdateReadonlyStates(ctx)
+	require.Equal(t, GasOfStakingViewOp, executor.RequiredGas(input))
+	out, err := executor.Run(input)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), uint256.NewInt(0).SetBytes(out).Uint64())
+
+	input = PackGetValidator(validatorAddr)
+	require.Equal(t, GasOfStakingViewOp, executor.RequiredGas(input))
+	out, err = executor.Run(input)
+	require.NoError(t, err)
+	rewardTo, pk, _, stakedCoins, _, _ := UnpackGetValidatorReturnData(out)
+	require.Equal(t, validatorAddr, rewardTo)
+	require.Equal(t, pubkey, pk)
+	require.Equal(t, bch(4).ToBig().String(), stakedCoins.String())
+	_, err = executor.Run(PackGetValidator(common.Address{0xad, 0x03}))
+	require.Equal(t, NoSuchValidator, err)
+	_, err = executor.Run(input[:20])
+	require.Equal(t, InvalidCallData, err)
+
+	out, err = executor.Run(PackGetActiveValidators())
+	require.NoError(t, err)
+	addrs, _, _ := UnpackGetActiveValidatorsReturnData(out)
+	require.Equal(t, []common.Address{validatorAddr}, addrs)
+
+	out, err = executor.Run(PackGetPendingRewards(validatorAddr))
+	require.NoError(t, err)
+	epochNums, _ := UnpackGetPendingRewardsReturnData(out)
+	require.Equal(t, 0, len(epochNums))
+
+	out, err = executor.Run(PackGetMinGasPrice())
+	require.NoError(t, err)
+	require.Equal(t, uint64(12345), uint256.NewInt(0).SetBytes(out).Uint64())
+	// the snapshot is only taken again at the next Commit
+	SaveMinGasPrice(ctx, 23456, false)
+	out, err = executor.Run(PackGetMinGasPrice())
+	require.NoError(t, err)
+	require.Equal(t, uint64(12345), uint256.NewInt(0).SetBytes(out).Uint64())
+	UpdateReadonlyStates(ctx)
+	out, err = executor.Run(PackGetMinGasPrice())
+	require.NoError(t, err)
+	require.Equal(t, uint64(23456), uint256.NewInt(0).SetBytes(out).Uint64())
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/holiman/uint256"
+	"github.com/tendermint/tendermint/libs/log"
 
 	mevmtypes "github.com/smartbch/moeingevm/types"
 	"github.com/smartbch/smartbch/param"
@@ -30,19 +31,24 @@ func GetAndClearPosVotes(ctx *mevmtypes.Context, xHedgeContractSeq uint64) map[[
 		copy(pubkey[:], val)
 		coindaysBz := ctx.GetAndDeleteValueAtMapKey(xHedgeContractSeq, SlotValidatorsMap, string(val))
 		coindays.SetBytes(coindaysBz)
-		coindays.Div(coindays, CoindayUnit)
-		if ctx.IsXHedgeFork() && ((param.IsAmber && ctx.Height >= 3600000) || !param.IsAmber) {
-			if !coindays.IsZero() {
-				posVotes[pubkey] = int64(coindays.Uint64())
-			}
-		} else {
-			posVotes[pubkey] = int64(coindays.Uint64())
-		}
+		addPosVote(ctx, posVotes, pubkey, coindays)
 	}
 	ctx.DeleteDynamicArray(xHedgeContractSeq, SlotValidatorsArray)
 	return posVotes
 }
 
+// convert the coindays slot to the coindays used in SwitchEpoch, and add it to posVotes
+func addPosVote(ctx *mevmtypes.Context, posVotes map[[32]byte]int64, pubkey [32]byte, coindays *uint256.Int) {
+	coindays.Div(coindays, CoindayUnit)
+	if ctx.IsXHedgeFork() && ((param.IsAmber && ctx.Height >= 3600000) || !param.IsAmber) {
+		if !coindays.IsZero() {
+			posVotes[pubkey] = int64(coindays.Uint64())
+		}
+	} else {
+		posVotes[pubkey] = int64(coindays.Uint64())
+	}
+}
+
 func GetPosVotes(ctx *mevmtypes.Context, xhedgeContractSeq uint64) map[[32]byte]*big.Int {
 	validators := ctx.GetDynamicArray(xhedgeContractSeq, SlotValidatorsArray)
 	posVotes := make(map[[32]byte]*big.Int, len(validators))
@@ -58,6 +64,40 @@ func GetPosVotes(ctx *mevmtypes.Context, xhedgeContractSeq uint64) map[[32]byte]
 	return posVotes
 }
 
+// Project the voting powers of the next epoch if it switched now with epoch, using the same PoS votes
+// as SwitchEpoch, which are the ones in xHedge plus the delegated votes. The states are not changed.
+func ProjectPubkey2Power(ctx *mevmtypes.Context, xhedgeContractSeq uint64, epoch *types.Epoch, numBlocksInEpoch int64,
+	logger log.Logger) map[[32]byte]int64 {
+	var posVotes map[[32]byte]int64
+	if ctx.IsXHedgeFork() {
+		validators := ctx.GetDynamicArray(xhedgeContractSeq, SlotValidatorsArray)
+		posVotes = make(map[[32]byte]int64, len(validators))
+		var pubkey [32]byte
+		for _, val := range validators {
+			copy(pubkey[:], val)
+			coindaysBz := ctx.GetValueAtMapKey(xhedgeContractSeq, SlotValidatorsMap, string(val))
+			addPosVote(ctx, posVotes, pubkey, uint256.NewInt(0).SetBytes(coindaysBz))
+		}
+	}
+	if IsDelegationFork(ctx) {
+		posVotes = AddDelegatedVotes(ctx, posVotes, numBlocksInEpoch)
+	}
+	info := LoadStakingInfo(ctx)
+	var jailedMapByPubkey map[[32]byte]*types.JailedValidator
+	if IsJailingFork(ctx) {
+		jailedList := LoadJailedValidatorList(ctx)
+		jailedMapByPubkey = jailedList.GetMapByPubkey()
+	}
+	maxActiveValidatorCount := int(GetGovParam(ctx, GovParamMaxActiveValidatorCount))
+	// getPubkey2Power changes the nominated counts, so the caller's epoch must not be used
+	epoch = types.CopyEpochs([]*types.Epoch{epoch})[0]
+	if IsKeyRotationFork(ctx) {
+		epoch.Nominations, posVotes = resolveRotatedPubkeys(ctx, info, epoch.Nominations, posVotes)
+	}
+	_, pubkey2power := getPubkey2Power(info, epoch, posVotes, jailedMapByPubkey, maxActiveValidatorCount, logger)
+	return pubkey2power
+}
+
 func CreateInitVotes(ctx *mevmtypes.Context, xhedgeContractSeq uint64, activeValidators []*types.Validator) {
 	pubkeys := make([][]byte, 0, len(activeValidators))
 	for _, v := range activeValidators {
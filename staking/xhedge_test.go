@@ -0,0 +1,35 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/smartbch/param"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+)
+
+func TestProjectPubkey2Power(t *testing.T) {
+	valA := &stakingtypes.Validator{Address: common.Address{0xad, 0x01}, Pubkey: [32]byte{0x01}}
+	unknownPubkey := [32]byte{0x09}
+	ctx := setupDelegationCtx(valA.Pubkey, valA.Address, common.Address{0xde, 0x01})
+	ctx.SetXHedgeForkBlock(0)
+
+	xhedgeSeq := param.XHedgeContractSequence
+	for pubkey, coindays := range map[[32]byte]uint64{valA.Pubkey: 3, unknownPubkey: 5} {
+		slot := uint256.NewInt(0).Mul(uint256.NewInt(coindays), CoindayUnit).PaddedBytes(32)
+		ctx.SetValueAtMapKey(xhedgeSeq, SlotValidatorsMap, string(pubkey[:]), slot)
+	}
+	ctx.CreateDynamicArray(xhedgeSeq, SlotValidatorsArray, [][]byte{valA.Pubkey[:], unknownPubkey[:]})
+
+	epoch := &stakingtypes.Epoch{Nominations: []*stakingtypes.Nomination{{Pubkey: valA.Pubkey, NominatedCount: 100}}}
+	pubkey2power := ProjectPubkey2Power(ctx, xhedgeSeq, epoch, param.StakingNumBlocksInEpoch, log.NewNopLogger())
+	require.Equal(t, map[[32]byte]int64{valA.Pubkey: 1}, pubkey2power)
+
+	// neither the votes nor the epoch are changed
+	require.Equal(t, int64(100), epoch.Nominations[0].NominatedCount)
+	require.Equal(t, 2, len(GetPosVotes(ctx, xhedgeSeq)))
+}
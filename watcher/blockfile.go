@@ -0,0 +1,143 @@
+package watcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cctypes "github.com/smartbch/smartbch/crosschain/types"
+	stakingtypes "github.com/smartbch/smartbch/staking/types"
+	"github.com/smartbch/smartbch/watcher/types"
+)
+
+var _ types.RpcClient = (*BlockFileClient)(nil)
+
+// A BlockFileClient serves BCH blocks recorded in a JSON or CBOR file (or a directory of such files)
+// instead of querying a BCH node. It lets a watcher be run offline, for debugging epoch
+// generation or replaying a recorded mainnet history. After all the recorded blocks are
+// replayed, the watcher just waits for new blocks which never come.
+type BlockFileClient struct {
+	blocks       map[int64]*types.BCHBlock
+	latestHeight int64
+}
+
+// NewBlockFileClient loads the recorded blocks from path, which may be a JSON or CBOR file containing
+// an array of block summaries, or a directory of such files. The recorded heights must be
+// continuous.
+func NewBlockFileClient(path string) (*BlockFileClient, error) {
+	summaries, err := LoadBlockSummaries(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, fmt.Errorf("no blocks recorded in %s", path)
+	}
+	client := &BlockFileClient{blocks: make(map[int64]*types.BCHBlock, len(summaries))}
+	for i, s := range summaries {
+		if i > 0 && s.Height != summaries[i-1].Height+1 {
+			return nil, fmt.Errorf("recorded blocks are not continuous: %d follows %d",
+				s.Height, summaries[i-1].Height)
+		}
+		blk, err := s.ToBCHBlock()
+		if err != nil {
+			return nil, err
+		}
+		client.blocks[blk.Height] = blk
+		client.latestHeight = blk.Height
+	}
+	return client, nil
+}
+
+func (client *BlockFileClient) GetLatestHeight(retry bool) int64 {
+	return client.latestHeight
+}
+
+func (client *BlockFileClient) GetBlockByHeight(height int64, retry bool) *types.BCHBlock {
+	return client.blocks[height]
+}
+
+func (client *BlockFileClient) GetEpochs(start, end uint64) []*stakingtypes.Epoch {
+	return nil
+}
+
+func (client *BlockFileClient) GetCCEpochs(start, end uint64) []*cctypes.CCEpoch {
+	return nil
+}
+
+// A file with the '.cbor' extension is in CBOR, and any other one is in JSON
+func isCBORFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".cbor")
+}
+
+// LoadBlockSummaries reads block summaries from a JSON or CBOR file, or from all the '.json' and '.cbor'
+// files in a directory, and returns them sorted by height. Duplicated heights are rejected.
+func LoadBlockSummaries(path string) ([]*types.BlockSummary, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.json", "*.cbor"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+	}
+	var summaries []*types.BlockSummary
+	for _, file := range files {
+		bz, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var fileSummaries []*types.BlockSummary
+		if isCBORFile(file) {
+			err = cborUnmarshal(bz, &fileSummaries)
+		} else {
+			err = json.Unmarshal(bz, &fileSummaries)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		summaries = append(summaries, fileSummaries...)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Height < summaries[j].Height
+	})
+	for i := 1; i < len(summaries); i++ {
+		if summaries[i].Height == summaries[i-1].Height {
+			return nil, fmt.Errorf("block %d is recorded more than once", summaries[i].Height)
+		}
+	}
+	return summaries, nil
+}
+
+// WriteBlockSummaries records blocks into a file, which can be loaded by NewBlockFileClient. The file is
+// in CBOR if its extension is '.cbor', otherwise it is in JSON.
+func WriteBlockSummaries(path string, blocks []*types.BCHBlock) error {
+	if len(blocks) == 0 {
+		return errors.New("no blocks to record")
+	}
+	summaries := make([]*types.BlockSummary, len(blocks))
+	for i, blk := range blocks {
+		summaries[i] = types.NewBlockSummary(blk)
+	}
+	var bz []byte
+	var err error
+	if isCBORFile(path) {
+		bz, err = cborMarshal(summaries)
+	} else {
+		bz, err = json.MarshalIndent(summaries, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bz, 0644)
+}
@@ -0,0 +1,353 @@
+package watcher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// A minimal CBOR (RFC 8949) codec for the recorded block files. A CBOR file holds the same document as
+// a JSON one: it is decoded into generic values which are then converted through JSON, so the field
+// names are the JSON ones. Byte strings are decoded as hex strings, the same way hashes and pubkeys
+// are recorded, and tags are ignored.
+
+const (
+	cborMajorUint   = 0
+	cborMajorNegInt = 1
+	cborMajorBytes  = 2
+	cborMajorText   = 3
+	cborMajorArray  = 4
+	cborMajorMap    = 5
+	cborMajorTag    = 6
+	cborMajorSimple = 7
+
+	cborIndefinite = 31
+	cborBreak      = 0xff
+	cborMaxDepth   = 64
+)
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+// cborUnmarshal decodes a CBOR document into v, just like json.Unmarshal does with a JSON document
+func cborUnmarshal(data []byte, v interface{}) error {
+	d := &cborDecoder{data: data}
+	value, err := d.decode(0)
+	if err != nil {
+		return err
+	}
+	if d.pos != len(d.data) {
+		return fmt.Errorf("cbor: %d bytes left after the document", len(d.data)-d.pos)
+	}
+	bz, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
+
+// cborMarshal encodes v into a CBOR document which has the same content as json.Marshal's output
+func cborMarshal(v interface{}) ([]byte, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber() // keep the integers which a float64 cannot hold exactly
+	var value interface{}
+	if err = dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return cborAppend(nil, value)
+}
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// readHead returns the major type and the argument of the next data item. The argument of an
+// indefinite-length item is meaningless, and the additional info is returned for the floats.
+func (d *cborDecoder) readHead() (major byte, info byte, arg uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, 0, errCBORTruncated
+	}
+	b := d.data[d.pos]
+	d.pos++
+	major, info = b>>5, b&0x1f
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		n := 1 << (info - 24)
+		if len(d.data)-d.pos < n {
+			return 0, 0, 0, errCBORTruncated
+		}
+		for _, c := range d.data[d.pos : d.pos+n] {
+			arg = arg<<8 | uint64(c)
+		}
+		d.pos += n
+	case info == cborIndefinite:
+		if major == cborMajorUint || major == cborMajorNegInt || major == cborMajorTag {
+			return 0, 0, 0, fmt.Errorf("cbor: invalid indefinite length for major type %d", major)
+		}
+	default:
+		return 0, 0, 0, fmt.Errorf("cbor: reserved additional info %d", info)
+	}
+	return
+}
+
+func (d *cborDecoder) isBreak() bool {
+	return d.pos < len(d.data) && d.data[d.pos] == cborBreak
+}
+
+// readString reads a definite or indefinite-length byte or text string
+func (d *cborDecoder) readString(major, info byte, arg uint64) ([]byte, error) {
+	if info != cborIndefinite {
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errCBORTruncated
+		}
+		bz := d.data[d.pos : d.pos+int(arg)]
+		d.pos += int(arg)
+		return bz, nil
+	}
+	var bz []byte
+	for !d.isBreak() {
+		chunkMajor, chunkInfo, chunkArg, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor != major || chunkInfo == cborIndefinite {
+			return nil, errors.New("cbor: invalid chunk in an indefinite-length string")
+		}
+		chunk, err := d.readString(chunkMajor, chunkInfo, chunkArg)
+		if err != nil {
+			return nil, err
+		}
+		bz = append(bz, chunk...)
+	}
+	if d.pos >= len(d.data) {
+		return nil, errCBORTruncated
+	}
+	d.pos++ // skip the break
+	return bz, nil
+}
+
+// hasNext reports whether there are more items in an array or a map, and skips the break of an
+// indefinite-length one
+func (d *cborDecoder) hasNext(info byte, count, arg uint64) (bool, error) {
+	if info != cborIndefinite {
+		return count < arg, nil
+	}
+	if d.pos >= len(d.data) {
+		return false, errCBORTruncated
+	}
+	if d.isBreak() {
+		d.pos++
+		return false, nil
+	}
+	return true, nil
+}
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("cbor: nested too deeply")
+	}
+	major, info, arg, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborMajorUint:
+		return arg, nil
+	case cborMajorNegInt:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor: negative integer overflows int64")
+		}
+		return -1 - int64(arg), nil
+	case cborMajorBytes:
+		bz, err := d.readString(major, info, arg)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(bz), nil
+	case cborMajorText:
+		bz, err := d.readString(major, info, arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(bz), nil
+	case cborMajorArray:
+		var arr []interface{}
+		for count := uint64(0); ; count++ {
+			next, err := d.hasNext(info, count, arg)
+			if err != nil {
+				return nil, err
+			}
+			if !next {
+				break
+			}
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, item)
+		}
+		if arr == nil {
+			arr = []interface{}{}
+		}
+		return arr, nil
+	case cborMajorMap:
+		m := make(map[string]interface{})
+		for count := uint64(0); ; count++ {
+			next, err := d.hasNext(info, count, arg)
+			if err != nil {
+				return nil, err
+			}
+			if !next {
+				break
+			}
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("cbor: map key %v is not a string", key)
+			}
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = value
+		}
+		return m, nil
+	case cborMajorTag:
+		return d.decode(depth + 1)
+	default: // cborMajorSimple
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		case 25:
+			return halfToFloat64(uint16(arg)), nil
+		case 26:
+			return float64(math.Float32frombits(uint32(arg))), nil
+		case 27:
+			return math.Float64frombits(arg), nil
+		default:
+			return nil, fmt.Errorf("cbor: unsupported simple value %d", arg)
+		}
+	}
+}
+
+func halfToFloat64(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
+
+func cborAppendHead(buf []byte, major byte, arg uint64) []byte {
+	var n int
+	switch {
+	case arg < 24:
+		return append(buf, major<<5|byte(arg))
+	case arg <= math.MaxUint8:
+		buf, n = append(buf, major<<5|24), 1
+	case arg <= math.MaxUint16:
+		buf, n = append(buf, major<<5|25), 2
+	case arg <= math.MaxUint32:
+		buf, n = append(buf, major<<5|26), 4
+	default:
+		buf, n = append(buf, major<<5|27), 8
+	}
+	return cborAppendBigEndian(buf, arg, n)
+}
+
+func cborAppendBigEndian(buf []byte, arg uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		buf = append(buf, byte(arg>>(8*i)))
+	}
+	return buf
+}
+
+// cborAppend encodes a value decoded by a json.Decoder with UseNumber. The integers are encoded as
+// integers and the other numbers as float64, and the keys of a map are sorted to make the output deterministic.
+func cborAppend(buf []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, cborMajorSimple<<5|22), nil
+	case bool:
+		if v {
+			return append(buf, cborMajorSimple<<5|21), nil
+		}
+		return append(buf, cborMajorSimple<<5|20), nil
+	case json.Number:
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return cborAppendHead(buf, cborMajorUint, u), nil
+		}
+		if i, err := v.Int64(); err == nil {
+			return cborAppendHead(buf, cborMajorNegInt, uint64(-1-i)), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, cborMajorSimple<<5|27)
+		return cborAppendBigEndian(buf, math.Float64bits(f), 8), nil
+	case string:
+		buf = cborAppendHead(buf, cborMajorText, uint64(len(v)))
+		return append(buf, v...), nil
+	case []interface{}:
+		buf = cborAppendHead(buf, cborMajorArray, uint64(len(v)))
+		var err error
+		for _, item := range v {
+			if buf, err = cborAppend(buf, item); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf = cborAppendHead(buf, cborMajorMap, uint64(len(v)))
+		var err error
+		for _, k := range keys {
+			if buf, err = cborAppend(buf, k); err != nil {
+				return nil, err
+			}
+			if buf, err = cborAppend(buf, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("cbor: unsupported type %T", value)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -34,6 +35,10 @@ type RpcClient struct {
 	err         error
 	contentType string
 	logger      log.Logger
+
+	lastErrMtx  sync.Mutex
+	lastErr     string
+	lastErrTime int64
 }
 
 var _ types.RpcClient = (*RpcClient)(nil)
@@ -59,6 +64,7 @@ func (client *RpcClient) GetLatestHeight(retry bool) (height int64) {
 			return height
 		}
 		if client.err != nil {
+			client.recordErr(client.err)
 			client.logger.Debug("GetLatestHeight failed", client.err.Error())
 			time.Sleep(10 * time.Second)
 		}
@@ -73,6 +79,7 @@ func (client *RpcClient) GetBlockByHeight(height int64, retry bool) *types.BCHBl
 	for hash == "" {
 		hash, err = client.getBlockHashOfHeight(height)
 		if err != nil {
+			client.recordErr(err)
 			if !retry {
 				return nil
 			}
@@ -84,6 +91,9 @@ func (client *RpcClient) GetBlockByHeight(height int64, retry bool) *types.BCHBl
 	}
 	for blk == nil {
 		blk, err = client.getBCHBlock(hash)
+		if err != nil {
+			client.recordErr(err)
+		}
 		if !retry {
 			return blk
 		}
@@ -102,6 +112,7 @@ func (client *RpcClient) GetEpochs(start, end uint64) []*stakingtypes.Epoch {
 	for epochs == nil {
 		epochs = client.getEpochs(start, end)
 		if client.err != nil {
+			client.recordErr(client.err)
 			client.logger.Debug("GetEpochs failed", client.err.Error())
 			time.Sleep(10 * time.Second)
 		}
@@ -109,6 +120,23 @@ func (client *RpcClient) GetEpochs(start, end uint64) []*stakingtypes.Epoch {
 	return epochs
 }
 
+func (client *RpcClient) recordErr(err error) {
+	client.lastErrMtx.Lock()
+	defer client.lastErrMtx.Unlock()
+	client.lastErr = err.Error()
+	client.lastErrTime = time.Now().Unix()
+}
+
+// LastError returns the last error met when querying the BCH node, and when it happened
+func (client *RpcClient) LastError() (msg string, timestamp int64) {
+	if client == nil {
+		return "", 0
+	}
+	client.lastErrMtx.Lock()
+	defer client.lastErrMtx.Unlock()
+	return client.lastErr, client.lastErrTime
+}
+
 func (client *RpcClient) sendRequest(reqStr string) ([]byte, error) {
 	body := strings.NewReader(reqStr)
 	req, err := http.NewRequest("POST", client.url, body)
@@ -156,6 +184,9 @@ func (client *RpcClient) getBCHBlock(hash string) (*types.BCHBlock, error) {
 		if nomination != nil {
 			bchBlock.Nominations = append(bchBlock.Nominations, *nomination)
 		}
+		if nominations, ok := bi.Tx[0].GetWeightedNominations(); ok {
+			bchBlock.WeightedNominations = nominations
+		}
 		//bchBlock.CCTransferInfos = append(bchBlock.CCTransferInfos, client.getCCTransferInfos(bi)...)
 	}
 	return bchBlock, nil
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"strings"
 
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
@@ -14,6 +15,11 @@ const (
 	Identifier = "73424348" // ascii code for 'sBCH'
 	Version    = "00"
 
+	// WeightedVersion is followed by at most MaxWeightedNominations entries, each of which
+	// is a 32-byte pubkey and a 1-byte non-zero weight
+	WeightedVersion        = "01"
+	MaxWeightedNominations = 6
+
 	ShaGateAddress = "14f8c7e99fd4e867c34cbd5968e35575fd5919a4"
 )
 
@@ -27,12 +33,15 @@ type RpcClient interface {
 
 // This struct contains the useful information of a BCH block
 type BCHBlock struct {
-	Height          int64
-	Timestamp       int64
-	HashId          [32]byte
-	ParentBlk       [32]byte
-	Nominations     []stakingtypes.Nomination
-	CCTransferInfos []*cctypes.CCTransferInfo
+	Height      int64
+	Timestamp   int64
+	HashId      [32]byte
+	ParentBlk   [32]byte
+	Nominations []stakingtypes.Nomination
+	// NominatedCount of each WeightedNomination is its weight. They are only counted in the epochs
+	// starting at or after param.WeightedNominationMainnetHeight, and take precedence over Nominations
+	WeightedNominations []stakingtypes.Nomination
+	CCTransferInfos     []*cctypes.CCTransferInfo
 }
 
 //not check Nominations
@@ -140,6 +149,59 @@ func (ti TxInfo) GetValidatorPubKey() (pubKey [32]byte, success bool) {
 	return
 }
 
+// GetWeightedNominations parses the first valid weighted nomination in the vouts. The weights
+// are returned as NominatedCount, which are normalized when aggregated into an epoch.
+func (ti TxInfo) GetWeightedNominations() (nominations []stakingtypes.Nomination, success bool) {
+	const entryLen = (32 + 1) * 2
+	for _, vout := range ti.VoutList {
+		asm, ok := vout.ScriptPubKey["asm"]
+		if !ok || asm == nil {
+			continue
+		}
+		script, ok := asm.(string)
+		if !ok {
+			continue
+		}
+		prefix := "OP_RETURN " + Identifier + WeightedVersion
+		if !strings.HasPrefix(script, prefix) {
+			continue
+		}
+		script = script[len(prefix):]
+		if len(script) == 0 || len(script)%entryLen != 0 || len(script)/entryLen > MaxWeightedNominations {
+			continue
+		}
+		bz, err := hex.DecodeString(script)
+		if err != nil {
+			continue
+		}
+		nominations = parseWeightedNominations(bz)
+		if nominations != nil {
+			return nominations, true
+		}
+	}
+	return nil, false
+}
+
+// returns nil if any weight is zero or any pubkey is duplicated
+func parseWeightedNominations(bz []byte) []stakingtypes.Nomination {
+	nominations := make([]stakingtypes.Nomination, 0, len(bz)/33)
+	for ; len(bz) > 0; bz = bz[33:] {
+		var n stakingtypes.Nomination
+		copy(n.Pubkey[:], bz[:32])
+		n.NominatedCount = int64(bz[32])
+		if n.NominatedCount == 0 {
+			return nil
+		}
+		for _, other := range nominations {
+			if other.Pubkey == n.Pubkey {
+				return nil
+			}
+		}
+		nominations = append(nominations, n)
+	}
+	return nominations
+}
+
 func (ti TxInfo) GetCCTransferInfos() (infos []*cctypes.CCTransferInfo) {
 	for n, vOut := range ti.VoutList {
 		asm, ok := vOut.ScriptPubKey["asm"]
@@ -193,3 +255,106 @@ type TxInfoResp struct {
 	Error  *JsonRpcError `json:"error"`
 	Id     string        `json:"id"`
 }
+
+/***recorded block data structure*/
+
+// A BlockSummary is the recorded form of a BCHBlock, which contains everything the watcher
+// needs to rebuild epochs. Hashes and pubkeys are hex-encoded to keep the files readable.
+type BlockSummary struct {
+	Height      int64               `json:"height"`
+	Timestamp   int64               `json:"timestamp"`
+	Hash        string              `json:"hash"`
+	ParentHash  string              `json:"parentHash"`
+	Nominations []NominationSummary `json:"nominations,omitempty"`
+
+	WeightedNominations []NominationSummary `json:"weightedNominations,omitempty"`
+}
+
+type NominationSummary struct {
+	Pubkey         string `json:"pubkey"`
+	NominatedCount int64  `json:"nominatedCount"`
+}
+
+func NewBlockSummary(blk *BCHBlock) *BlockSummary {
+	s := &BlockSummary{
+		Height:     blk.Height,
+		Timestamp:  blk.Timestamp,
+		Hash:       hex.EncodeToString(blk.HashId[:]),
+		ParentHash: hex.EncodeToString(blk.ParentBlk[:]),
+	}
+	s.Nominations = newNominationSummaries(blk.Nominations)
+	s.WeightedNominations = newNominationSummaries(blk.WeightedNominations)
+	return s
+}
+
+func newNominationSummaries(nominations []stakingtypes.Nomination) []NominationSummary {
+	var summaries []NominationSummary
+	for _, n := range nominations {
+		summaries = append(summaries, NominationSummary{
+			Pubkey:         hex.EncodeToString(n.Pubkey[:]),
+			NominatedCount: n.NominatedCount,
+		})
+	}
+	return summaries
+}
+
+func (s *BlockSummary) ToBCHBlock() (*BCHBlock, error) {
+	blk := &BCHBlock{
+		Height:    s.Height,
+		Timestamp: s.Timestamp,
+	}
+	if err := decodeHex32(s.Hash, &blk.HashId); err != nil {
+		return nil, fmt.Errorf("invalid hash of block %d: %w", s.Height, err)
+	}
+	if err := decodeHex32(s.ParentHash, &blk.ParentBlk); err != nil {
+		return nil, fmt.Errorf("invalid parent hash of block %d: %w", s.Height, err)
+	}
+	var err error
+	if blk.Nominations, err = toNominations(s.Nominations, false); err != nil {
+		return nil, fmt.Errorf("invalid nomination in block %d: %w", s.Height, err)
+	}
+	if blk.WeightedNominations, err = toNominations(s.WeightedNominations, true); err != nil {
+		return nil, fmt.Errorf("invalid weighted nomination in block %d: %w", s.Height, err)
+	}
+	return blk, nil
+}
+
+// The recorded nominations are checked like the ones parsed from coinbase transactions, because the
+// watcher divides the votes of a block by the sum of their counts
+func toNominations(summaries []NominationSummary, weighted bool) ([]stakingtypes.Nomination, error) {
+	if weighted && len(summaries) > MaxWeightedNominations {
+		return nil, fmt.Errorf("more than %d nominations", MaxWeightedNominations)
+	}
+	var nominations []stakingtypes.Nomination
+	for _, n := range summaries {
+		var nomination stakingtypes.Nomination
+		if err := decodeHex32(n.Pubkey, &nomination.Pubkey); err != nil {
+			return nil, err
+		}
+		if n.NominatedCount <= 0 || (weighted && n.NominatedCount > math.MaxUint8) {
+			return nil, fmt.Errorf("count %d of %s is out of range", n.NominatedCount, n.Pubkey)
+		}
+		if weighted {
+			for _, other := range nominations {
+				if other.Pubkey == nomination.Pubkey {
+					return nil, fmt.Errorf("%s is nominated more than once", n.Pubkey)
+				}
+			}
+		}
+		nomination.NominatedCount = n.NominatedCount
+		nominations = append(nominations, nomination)
+	}
+	return nominations, nil
+}
+
+func decodeHex32(s string, out *[32]byte) error {
+	bz, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return err
+	}
+	if len(bz) != 32 {
+		return fmt.Errorf("expected 32 bytes, got %d", len(bz))
+	}
+	copy(out[:], bz)
+	return nil
+}
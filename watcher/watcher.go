@@ -7,6 +7,7 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -18,8 +19,8 @@ import (
 )
 
 const (
-	NumBlocksToClearMemory = 1000
-	WaitingBlockDelayTime  = 2
+	// weighted votes are accumulated in units of 1/NominationWeightPrecision block
+	NominationWeightPrecision = 1_000_000
 )
 
 // A watcher watches the new blocks generated on bitcoin cash's mainnet, and
@@ -30,7 +31,8 @@ type Watcher struct {
 	rpcClient         types.RpcClient
 	smartBchRpcClient types.RpcClient
 
-	latestFinalizedHeight int64
+	latestFinalizedHeight int64 // written atomically, because GetStatus reads it from other goroutines
+	latestMainnetHeight   int64 // accessed atomically
 
 	heightToFinalizedBlock map[int64]*types.BCHBlock
 
@@ -40,26 +42,45 @@ type Watcher struct {
 	lastEpochEndHeight int64
 	lastKnownEpochNum  int64
 
+	weightedNominationHeight int64
+
 	CCEpochChan          chan *cctypes.CCEpoch
 	lastCCEpochEndHeight int64
 	numBlocksInCCEpoch   int64
 	ccEpochList          []*cctypes.CCEpoch
 	lastKnownCCEpochNum  int64
 
+	finalityDepth          int64
 	numBlocksToClearMemory int
 	waitingBlockDelayTime  int
 	parallelNum            int
 
 	chainConfig *param.ChainConfig
 
-	currentMainnetBlockTimestamp int64
+	currentMainnetBlockTimestamp int64 // written atomically, like latestFinalizedHeight
+
+	quitChan chan struct{} // closed by Stop to end Run
+	stopOnce sync.Once
 }
 
 func NewWatcher(logger log.Logger, lastHeight, lastCCEpochEndHeight int64, lastKnownEpochNum int64, chainConfig *param.ChainConfig) *Watcher {
+	params, err := chainConfig.AppConfig.GetWatcherParams()
+	if err != nil {
+		panic(err)
+	}
+	var rpcClient types.RpcClient = NewRpcClient(chainConfig.AppConfig.MainnetRPCUrl, chainConfig.AppConfig.MainnetRPCUsername, chainConfig.AppConfig.MainnetRPCPassword, "text/plain;", logger)
+	if blocksFile := chainConfig.AppConfig.MainnetBlocksFile; blocksFile != "" {
+		fileClient, err := NewBlockFileClient(blocksFile)
+		if err != nil {
+			panic(fmt.Sprintf("failed to load recorded BCH blocks: %s", err.Error()))
+		}
+		logger.Info("watcher uses recorded BCH blocks", "file", blocksFile, "latestHeight", fileClient.latestHeight)
+		rpcClient = fileClient
+	}
 	return &Watcher{
 		logger: logger,
 
-		rpcClient:         NewRpcClient(chainConfig.AppConfig.MainnetRPCUrl, chainConfig.AppConfig.MainnetRPCUsername, chainConfig.AppConfig.MainnetRPCPassword, "text/plain;", logger),
+		rpcClient:         rpcClient,
 		smartBchRpcClient: NewRpcClient(chainConfig.AppConfig.SmartBchRPCUrl, "", "", "application/json", logger),
 
 		lastEpochEndHeight:    lastHeight,
@@ -71,19 +92,24 @@ func NewWatcher(logger log.Logger, lastHeight, lastCCEpochEndHeight int64, lastK
 
 		EpochChan: make(chan *stakingtypes.Epoch, 10000),
 
-		numBlocksInEpoch:       param.StakingNumBlocksInEpoch,
-		numBlocksToClearMemory: NumBlocksToClearMemory,
-		waitingBlockDelayTime:  WaitingBlockDelayTime,
+		numBlocksInEpoch:       params.NumBlocksInEpoch,
+		finalityDepth:          params.FinalityDepth,
+		numBlocksToClearMemory: params.NumBlocksToClearMemory,
+		waitingBlockDelayTime:  params.WaitingBlockDelayTime,
+		parallelNum:            params.ParallelFetchNum,
+
+		weightedNominationHeight: param.WeightedNominationMainnetHeight,
 
 		CCEpochChan:          make(chan *cctypes.CCEpoch, 96*10000),
 		ccEpochList:          make([]*cctypes.CCEpoch, 0, 40),
 		lastCCEpochEndHeight: lastCCEpochEndHeight,
 		numBlocksInCCEpoch:   param.BlocksInCCEpoch,
 
-		parallelNum: 10,
 		chainConfig: chainConfig,
 		// set big enough for single node startup when no BCH node connected. it will be updated when mainnet block finalize.
 		currentMainnetBlockTimestamp: math.MaxInt64 - 14*24*3600,
+
+		quitChan: make(chan struct{}),
 	}
 }
 
@@ -91,6 +117,10 @@ func (watcher *Watcher) SetNumBlocksInEpoch(n int64) {
 	watcher.numBlocksInEpoch = n
 }
 
+func (watcher *Watcher) SetWeightedNominationHeight(h int64) {
+	watcher.weightedNominationHeight = h
+}
+
 func (watcher *Watcher) SetNumBlocksToClearMemory(n int) {
 	watcher.numBlocksToClearMemory = n
 }
@@ -99,7 +129,23 @@ func (watcher *Watcher) SetWaitingBlockDelayTime(n int) {
 	watcher.waitingBlockDelayTime = n
 }
 
-// The main function to do a watcher's job. It must be run as a goroutine
+// Stop makes Run return after the block being fetched is handled. It can be called more than once
+func (watcher *Watcher) Stop() {
+	watcher.stopOnce.Do(func() {
+		close(watcher.quitChan)
+	})
+}
+
+func (watcher *Watcher) stopped() bool {
+	select {
+	case <-watcher.quitChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// The main function to do a watcher's job. It must be run as a goroutine, and it returns after Stop is called
 func (watcher *Watcher) Run(catchupChan chan bool) {
 	if watcher.rpcClient == (*RpcClient)(nil) {
 		//for ut
@@ -107,17 +153,19 @@ func (watcher *Watcher) Run(catchupChan chan bool) {
 		return
 	}
 	latestFinalizedHeight := watcher.latestFinalizedHeight
-	latestMainnetHeight := watcher.rpcClient.GetLatestHeight(true)
+	latestMainnetHeight := watcher.getLatestMainnetHeight()
 	latestFinalizedHeight = watcher.epochSpeedup(latestFinalizedHeight, latestMainnetHeight)
 	watcher.fetchBlocks(catchupChan, latestFinalizedHeight, latestMainnetHeight)
 }
 
 func (watcher *Watcher) fetchBlocks(catchupChan chan bool, latestFinalizedHeight, latestMainnetHeight int64) {
 	catchup := false
-	for {
-		if !catchup && latestMainnetHeight <= latestFinalizedHeight+9 {
-			latestMainnetHeight = watcher.rpcClient.GetLatestHeight(true)
-			if latestMainnetHeight <= latestFinalizedHeight+9 {
+	// a block at height h gets finalityDepth confirmations when the mainnet reaches h+laterBlocks
+	laterBlocks := watcher.finalityDepth - 1
+	for !watcher.stopped() {
+		if !catchup && latestMainnetHeight <= latestFinalizedHeight+laterBlocks {
+			latestMainnetHeight = watcher.getLatestMainnetHeight()
+			if latestMainnetHeight <= latestFinalizedHeight+laterBlocks {
 				watcher.logger.Debug("Catchup")
 				catchup = true
 				catchupChan <- true
@@ -125,17 +173,16 @@ func (watcher *Watcher) fetchBlocks(catchupChan chan bool, latestFinalizedHeight
 			}
 		}
 		latestFinalizedHeight++
-		latestMainnetHeight = watcher.rpcClient.GetLatestHeight(true)
-		//10 confirms
-		if latestMainnetHeight < latestFinalizedHeight+9 {
+		latestMainnetHeight = watcher.getLatestMainnetHeight()
+		if latestMainnetHeight < latestFinalizedHeight+laterBlocks {
 			watcher.logger.Debug("waiting BCH mainnet", "height now is", latestMainnetHeight)
 			watcher.suspended(time.Duration(watcher.waitingBlockDelayTime) * time.Second) //delay half of bch mainnet block intervals
 			latestFinalizedHeight--
 			continue
 		}
-		for latestFinalizedHeight+9 <= latestMainnetHeight {
+		for latestFinalizedHeight+laterBlocks <= latestMainnetHeight && !watcher.stopped() {
 			fmt.Printf("latestFinalizedHeight:%d,latestMainnetHeight:%d\n", latestFinalizedHeight, latestMainnetHeight)
-			if latestFinalizedHeight+9+int64(watcher.parallelNum) <= latestMainnetHeight {
+			if latestFinalizedHeight+laterBlocks+int64(watcher.parallelNum) <= latestMainnetHeight {
 				watcher.parallelFetchBlocks(latestFinalizedHeight)
 				latestFinalizedHeight += int64(watcher.parallelNum)
 			} else {
@@ -199,7 +246,7 @@ func (watcher *Watcher) epochSpeedup(latestFinalizedHeight, latestMainnetHeight
 			latestFinalizedHeight += int64(len(epochs)) * watcher.numBlocksInEpoch
 			start = start + uint64(len(epochs))
 		}
-		watcher.latestFinalizedHeight = latestFinalizedHeight
+		atomic.StoreInt64(&watcher.latestFinalizedHeight, latestFinalizedHeight)
 		watcher.lastEpochEndHeight = latestFinalizedHeight
 		watcher.logger.Debug("After speedup", "latestFinalizedHeight", watcher.latestFinalizedHeight)
 	}
@@ -228,15 +275,24 @@ func (watcher *Watcher) ccEpochSpeedup() {
 	}
 }
 
+func (watcher *Watcher) getLatestMainnetHeight() int64 {
+	height := watcher.rpcClient.GetLatestHeight(true)
+	atomic.StoreInt64(&watcher.latestMainnetHeight, height)
+	return height
+}
+
 func (watcher *Watcher) suspended(delayDuration time.Duration) {
-	time.Sleep(delayDuration)
+	select {
+	case <-watcher.quitChan:
+	case <-time.After(delayDuration):
+	}
 }
 
 // Record new block and if the blocks for a new epoch is all ready, output the new epoch
 func (watcher *Watcher) addFinalizedBlock(blk *types.BCHBlock) {
 	watcher.heightToFinalizedBlock[blk.Height] = blk
-	watcher.latestFinalizedHeight++
-	watcher.currentMainnetBlockTimestamp = blk.Timestamp
+	atomic.AddInt64(&watcher.latestFinalizedHeight, 1)
+	atomic.StoreInt64(&watcher.currentMainnetBlockTimestamp, blk.Timestamp)
 
 	if watcher.latestFinalizedHeight-watcher.lastEpochEndHeight == watcher.numBlocksInEpoch {
 		watcher.generateNewEpoch()
@@ -256,11 +312,21 @@ func (watcher *Watcher) generateNewEpoch() {
 	watcher.ClearOldData()
 }
 
+// The only builder of the epochs, which are either emitted by generateNewEpoch or previewed by GetCurrEpoch
+// and GetEpochList, so a previewed epoch is the same as the one emitted later if no more blocks come.
 func (watcher *Watcher) buildNewEpoch() *stakingtypes.Epoch {
 	epoch := &stakingtypes.Epoch{
 		StartHeight: watcher.lastEpochEndHeight + 1,
 		Nominations: make([]*stakingtypes.Nomination, 0, 10),
 	}
+	if epoch.StartHeight >= watcher.weightedNominationHeight {
+		return watcher.buildNewEpochWithWeights(epoch)
+	}
+	return watcher.buildNewEpochWithCounts(epoch)
+}
+
+// Each nomination in a block counts as a vote
+func (watcher *Watcher) buildNewEpochWithCounts(epoch *stakingtypes.Epoch) *stakingtypes.Epoch {
 	var valMapByPubkey = make(map[[32]byte]*stakingtypes.Nomination)
 	for i := epoch.StartHeight; i <= watcher.latestFinalizedHeight; i++ {
 		blk, ok := watcher.heightToFinalizedBlock[i]
@@ -285,6 +351,45 @@ func (watcher *Watcher) buildNewEpoch() *stakingtypes.Epoch {
 	return epoch
 }
 
+// Each block has one vote, which is split among the validators nominated by its coinbase
+// transaction according to their weights. The votes are rounded to the nearest whole blocks.
+func (watcher *Watcher) buildNewEpochWithWeights(epoch *stakingtypes.Epoch) *stakingtypes.Epoch {
+	votes := make(map[[32]byte]int64)
+	for i := epoch.StartHeight; i <= watcher.latestFinalizedHeight; i++ {
+		blk, ok := watcher.heightToFinalizedBlock[i]
+		if !ok {
+			panic("Missing Block")
+		}
+		if epoch.EndTime < blk.Timestamp {
+			epoch.EndTime = blk.Timestamp
+		}
+		nominations := blk.WeightedNominations
+		if len(nominations) == 0 {
+			nominations = blk.Nominations
+		}
+		var totalWeight int64
+		for _, n := range nominations {
+			totalWeight += n.NominatedCount
+		}
+		if totalWeight <= 0 {
+			continue // the weights are checked when the blocks are fetched, so it never happens
+		}
+		for _, n := range nominations {
+			votes[n.Pubkey] += n.NominatedCount * NominationWeightPrecision / totalWeight
+		}
+	}
+	for pubkey, vote := range votes {
+		if count := (vote + NominationWeightPrecision/2) / NominationWeightPrecision; count > 0 {
+			epoch.Nominations = append(epoch.Nominations, &stakingtypes.Nomination{
+				Pubkey:         pubkey,
+				NominatedCount: count,
+			})
+		}
+	}
+	sortEpochNominations(epoch)
+	return epoch
+}
+
 func (watcher *Watcher) GetCurrEpoch() *stakingtypes.Epoch {
 	return watcher.buildNewEpoch()
 }
@@ -295,7 +400,31 @@ func (watcher *Watcher) GetEpochList() []*stakingtypes.Epoch {
 }
 
 func (watcher *Watcher) GetCurrMainnetBlockTimestamp() int64 {
-	return watcher.currentMainnetBlockTimestamp
+	return atomic.LoadInt64(&watcher.currentMainnetBlockTimestamp)
+}
+
+// Status shows how the watcher is following BCH mainnet
+type Status struct {
+	LatestFinalizedHeight        int64
+	LatestMainnetHeight          int64 // the BCH tip height seen by the watcher
+	CurrentMainnetBlockTimestamp int64
+	PendingEpochs                int // epochs in EpochChan which are not consumed yet
+	LastRpcError                 string
+	LastRpcErrorTime             int64
+	BeginBlockThrottled          bool // filled by the app, whose BeginBlock waits when the watcher falls behind
+}
+
+func (watcher *Watcher) GetStatus() Status {
+	status := Status{
+		LatestFinalizedHeight:        atomic.LoadInt64(&watcher.latestFinalizedHeight),
+		LatestMainnetHeight:          atomic.LoadInt64(&watcher.latestMainnetHeight),
+		CurrentMainnetBlockTimestamp: atomic.LoadInt64(&watcher.currentMainnetBlockTimestamp),
+		PendingEpochs:                len(watcher.EpochChan),
+	}
+	if client, ok := watcher.rpcClient.(interface{ LastError() (string, int64) }); ok {
+		status.LastRpcError, status.LastRpcErrorTime = client.LastError()
+	}
+	return status
 }
 
 //func (watcher *Watcher) generateNewCCEpoch() {
@@ -370,7 +499,7 @@ func (watcher *Watcher) ClearOldData() {
 		watcher.epochList = watcher.epochList[elLen-5:]
 	}
 	ccEpochLen := len(watcher.ccEpochList)
-	if ccEpochLen > 5*int(param.StakingNumBlocksInEpoch/param.BlocksInCCEpoch) {
+	if ccEpochLen > 5*int(watcher.numBlocksInEpoch/param.BlocksInCCEpoch) {
 		watcher.epochList = watcher.epochList[ccEpochLen-5:]
 	}
 }
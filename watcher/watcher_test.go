@@ -2,7 +2,11 @@ package watcher
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -10,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	cctypes "github.com/smartbch/smartbch/crosschain/types"
+	"github.com/smartbch/smartbch/internal/testutils/bchnode"
 	"github.com/smartbch/smartbch/param"
 	stakingtypes "github.com/smartbch/smartbch/staking/types"
 	"github.com/smartbch/smartbch/watcher/types"
@@ -199,3 +204,405 @@ func TestEpochSort(t *testing.T) {
 		i++
 	}
 }
+
+func TestBlockFileClient(t *testing.T) {
+	blocks := buildMockBCHNodeWithOnlyValidator1().blocks
+	dir := t.TempDir()
+	file := filepath.Join(dir, "blocks.json")
+	require.NoError(t, WriteBlockSummaries(file, blocks))
+
+	client, err := NewBlockFileClient(file)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), client.GetLatestHeight(false))
+	for _, blk := range blocks {
+		require.Equal(t, blk, client.GetBlockByHeight(blk.Height, false))
+	}
+	require.Nil(t, client.GetBlockByHeight(101, false))
+
+	// recorded in several files of a directory
+	splitDir := filepath.Join(dir, "split")
+	require.NoError(t, os.Mkdir(splitDir, 0755))
+	require.NoError(t, WriteBlockSummaries(filepath.Join(splitDir, "2.json"), blocks[50:]))
+	require.NoError(t, WriteBlockSummaries(filepath.Join(splitDir, "1.json"), blocks[:50]))
+	client, err = NewBlockFileClient(splitDir)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), client.GetLatestHeight(false))
+	require.Equal(t, blocks[50], client.GetBlockByHeight(51, false))
+
+	// recorded in CBOR, alone or mixed with JSON files
+	cborFile := filepath.Join(dir, "blocks.cbor")
+	require.NoError(t, WriteBlockSummaries(cborFile, blocks))
+	client, err = NewBlockFileClient(cborFile)
+	require.NoError(t, err)
+	for _, blk := range blocks {
+		require.Equal(t, blk, client.GetBlockByHeight(blk.Height, false))
+	}
+	require.NoError(t, os.Remove(filepath.Join(splitDir, "2.json")))
+	require.NoError(t, WriteBlockSummaries(filepath.Join(splitDir, "2.cbor"), blocks[50:]))
+	client, err = NewBlockFileClient(splitDir)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), client.GetLatestHeight(false))
+	require.Equal(t, blocks[50], client.GetBlockByHeight(51, false))
+
+	// a gap is not allowed
+	require.NoError(t, os.Remove(filepath.Join(splitDir, "1.json")))
+	require.NoError(t, WriteBlockSummaries(filepath.Join(splitDir, "1.json"), blocks[:49]))
+	_, err = NewBlockFileClient(splitDir)
+	require.Error(t, err)
+
+	// the weights are checked, so the watcher never divides votes by a zero total weight
+	badBlocks := []*types.BCHBlock{{Height: 1}, {Height: 2}}
+	badBlocks[1].WeightedNominations = []stakingtypes.Nomination{{Pubkey: testValidatorPubkey1, NominatedCount: 0}}
+	badFile := filepath.Join(dir, "bad.json")
+	require.NoError(t, WriteBlockSummaries(badFile, badBlocks))
+	_, err = NewBlockFileClient(badFile)
+	require.EqualError(t, err, "invalid weighted nomination in block 2: count 0 of "+
+		hex.EncodeToString(testValidatorPubkey1[:])+" is out of range")
+	badBlocks[1].WeightedNominations = []stakingtypes.Nomination{
+		{Pubkey: testValidatorPubkey1, NominatedCount: 1}, {Pubkey: testValidatorPubkey1, NominatedCount: 2}}
+	require.NoError(t, WriteBlockSummaries(badFile, badBlocks))
+	_, err = NewBlockFileClient(badFile)
+	require.Error(t, err)
+	badBlocks[1].WeightedNominations = nil
+	badBlocks[1].Nominations = []stakingtypes.Nomination{{Pubkey: testValidatorPubkey1, NominatedCount: -1}}
+	require.NoError(t, WriteBlockSummaries(badFile, badBlocks))
+	_, err = NewBlockFileClient(badFile)
+	require.Error(t, err)
+}
+
+func TestCBOR(t *testing.T) {
+	type item struct {
+		A int64             `json:"a"`
+		B uint64            `json:"b"`
+		C string            `json:"c"`
+		D []int64           `json:"d"`
+		E map[string]bool   `json:"e"`
+		F float64           `json:"f"`
+		G *string           `json:"g"`
+		H []*item           `json:"h,omitempty"`
+		I map[string]string `json:"i,omitempty"`
+	}
+	in := []*item{{A: -1 << 63, B: 1<<64 - 1, C: "smartBCH", D: []int64{0, 23, 24, 255, 256, 65536, 1 << 32},
+		E: map[string]bool{"x": true, "y": false}, F: 1.5, H: []*item{{A: 1}}}}
+	bz, err := cborMarshal(in)
+	require.NoError(t, err)
+	var out []*item
+	require.NoError(t, cborUnmarshal(bz, &out))
+	require.Equal(t, in, out)
+
+	// the examples in RFC 8949, including indefinite lengths, byte strings, tags and half floats
+	for hexStr, expected := range map[string]string{
+		"9f018202039f0405ffff":                         `[1,[2,3],[4,5]]`,
+		"bf61610161629f0203ffff":                       `{"a":1,"b":[2,3]}`,
+		"7f657374726561646d696e67ff":                   `"streaming"`,
+		"5f42010243030405ff":                           `"0102030405"`,
+		"c074323031332d30332d32315432303a30343a30305a": `"2013-03-21T20:04:00Z"`,
+		"f93e00":     `1.5`,
+		"fa47c35000": `100000`,
+		"3903e7":     `-1000`,
+		"f6":         `null`,
+	} {
+		data, err := hex.DecodeString(hexStr)
+		require.NoError(t, err)
+		var v interface{}
+		require.NoError(t, cborUnmarshal(data, &v))
+		var e interface{}
+		require.NoError(t, json.Unmarshal([]byte(expected), &e))
+		require.Equal(t, e, v, hexStr)
+	}
+
+	for _, hexStr := range []string{"", "18", "62616263", "9f01", "a10102", "1c", "0101", "f8ff"} {
+		data, err := hex.DecodeString(hexStr)
+		require.NoError(t, err)
+		var v interface{}
+		require.Error(t, cborUnmarshal(data, &v), hexStr)
+	}
+}
+
+// startWatcher runs w until it catches up, and returns a function which stops w and waits for Run to return
+func startWatcher(w *Watcher) (stop func()) {
+	catchupChan := make(chan bool, 1)
+	done := make(chan struct{})
+	go func() {
+		w.Run(catchupChan)
+		close(done)
+	}()
+	<-catchupChan
+	return func() {
+		w.Stop()
+		<-done
+	}
+}
+
+// waitForWatcher polls w until it has finalized the given height and generated the given number of epochs
+func waitForWatcher(t *testing.T, w *Watcher, finalizedHeight int64, pendingEpochs int) {
+	require.Eventually(t, func() bool {
+		status := w.GetStatus()
+		return status.LatestFinalizedHeight == finalizedHeight && status.PendingEpochs == pendingEpochs
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestRunWithBlockFile(t *testing.T) {
+	runWatcher := func(w *Watcher) []*stakingtypes.Epoch {
+		w.SetNumBlocksInEpoch(10)
+		stop := startWatcher(w)
+		waitForWatcher(t, w, 91, 9)
+		stop()
+		require.Equal(t, int64(91), w.latestFinalizedHeight)
+		epochs := make([]*stakingtypes.Epoch, len(w.EpochChan))
+		for i := range epochs {
+			epochs[i] = <-w.EpochChan
+		}
+		return epochs
+	}
+
+	node := buildMockBCHNodeWithOnlyValidator1()
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
+	w.rpcClient = MockRpcClient{node: node}
+	expected := runWatcher(w)
+	require.Equal(t, 9, len(expected))
+
+	// replaying the recorded blocks must generate the same epochs
+	file := filepath.Join(t.TempDir(), "blocks.json")
+	require.NoError(t, WriteBlockSummaries(file, node.blocks))
+	config := param.DefaultConfig()
+	config.AppConfig.MainnetBlocksFile = file
+	require.Equal(t, expected, runWatcher(NewWatcher(log.NewNopLogger(), 0, 0, 0, config)))
+}
+
+func TestRunWithMockBCHNode(t *testing.T) {
+	node := bchnode.NewMockBCHNode(0)
+	node.MineBlocks(100, testValidatorPubkey1)
+	require.NoError(t, node.Start("127.0.0.1:0"))
+	defer node.Stop()
+
+	config := param.DefaultConfig()
+	config.AppConfig.MainnetRPCUrl = node.URL()
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, config)
+	w.SetNumBlocksInEpoch(10)
+	w.SetWaitingBlockDelayTime(1)
+	stop := startWatcher(w)
+	defer stop()
+	waitForWatcher(t, w, 91, 9)
+
+	// blocks in a reorg which is not deeper than 10 confirmations never get finalized
+	node.Reorg(5)
+	node.MineBlocks(15, testValidatorPubkey1)
+	waitForWatcher(t, w, 101, 10)
+	stop()
+	require.Equal(t, int64(101), w.latestFinalizedHeight)
+	require.Equal(t, 10, len(w.EpochChan))
+	for i := 0; i < 10; i++ {
+		e := <-w.EpochChan
+		require.Equal(t, int64(i*10+1), e.StartHeight)
+		require.Equal(t, testValidatorPubkey1, e.Nominations[0].Pubkey)
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	node := bchnode.NewMockBCHNode(0)
+	node.MineBlocks(30, testValidatorPubkey1)
+	require.NoError(t, node.Start("127.0.0.1:0"))
+
+	config := param.DefaultConfig()
+	config.AppConfig.MainnetRPCUrl = node.URL()
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, config)
+	w.SetNumBlocksInEpoch(10)
+	stop := startWatcher(w)
+	defer stop()
+	waitForWatcher(t, w, 21, 2)
+
+	status := w.GetStatus()
+	require.Equal(t, int64(21), status.LatestFinalizedHeight)
+	require.Equal(t, int64(30), status.LatestMainnetHeight)
+	require.Equal(t, int64(21*bchnode.DefaultBlockInterval), status.CurrentMainnetBlockTimestamp)
+	require.Equal(t, 2, status.PendingEpochs)
+	require.Equal(t, "", status.LastRpcError)
+
+	node.Stop()
+	client := w.rpcClient.(*RpcClient)
+	require.Nil(t, client.GetBlockByHeight(1, false))
+	status = w.GetStatus()
+	require.NotEqual(t, "", status.LastRpcError)
+	require.NotZero(t, status.LastRpcErrorTime)
+}
+
+func TestBuildNewEpochWithWeights(t *testing.T) {
+	pubkey1, pubkey2, pubkey3 := [32]byte{0x1}, [32]byte{0x2}, [32]byte{0x3}
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, param.DefaultConfig())
+	for h := int64(1); h <= 10; h++ {
+		blk := &types.BCHBlock{Height: h, Timestamp: h * 600}
+		if h <= 6 {
+			// one third for pubkey1, two thirds for pubkey2
+			blk.WeightedNominations = []stakingtypes.Nomination{
+				{Pubkey: pubkey1, NominatedCount: 10},
+				{Pubkey: pubkey2, NominatedCount: 20},
+			}
+			// ignored when WeightedNominations exist
+			blk.Nominations = []stakingtypes.Nomination{{Pubkey: pubkey3, NominatedCount: 1}}
+		} else if h <= 9 {
+			blk.Nominations = []stakingtypes.Nomination{{Pubkey: pubkey3, NominatedCount: 1}}
+		}
+		w.heightToFinalizedBlock[h] = blk
+	}
+	w.latestFinalizedHeight = 10
+
+	// before the fork, only the legacy nominations are counted
+	epoch := w.buildNewEpoch()
+	require.Equal(t, int64(6000), epoch.EndTime)
+	require.Len(t, epoch.Nominations, 1)
+	require.Equal(t, pubkey3, epoch.Nominations[0].Pubkey)
+
+	w.SetWeightedNominationHeight(1)
+	epoch = w.buildNewEpoch()
+	require.Equal(t, int64(6000), epoch.EndTime)
+	require.Equal(t, []*stakingtypes.Nomination{
+		{Pubkey: pubkey2, NominatedCount: 4}, // 6*2/3
+		{Pubkey: pubkey3, NominatedCount: 3},
+		{Pubkey: pubkey1, NominatedCount: 2}, // 6*1/3
+	}, epoch.Nominations)
+	// the previewed epochs are built in the same way
+	require.Equal(t, epoch, w.GetCurrEpoch())
+	require.Equal(t, []*stakingtypes.Epoch{epoch}, w.GetEpochList())
+}
+
+func TestGetWeightedNominations(t *testing.T) {
+	pubkey1, pubkey2 := [32]byte{0x1}, [32]byte{0x2}
+	entry := func(pubkey [32]byte, weight byte) string {
+		return hex.EncodeToString(pubkey[:]) + hex.EncodeToString([]byte{weight})
+	}
+	txWithScript := func(script string) types.TxInfo {
+		return types.TxInfo{VoutList: []types.Vout{
+			{ScriptPubKey: map[string]interface{}{"asm": "OP_RETURN " + types.Identifier + types.WeightedVersion + script}},
+		}}
+	}
+
+	nominations, ok := txWithScript(entry(pubkey1, 3) + entry(pubkey2, 1)).GetWeightedNominations()
+	require.True(t, ok)
+	require.Equal(t, []stakingtypes.Nomination{
+		{Pubkey: pubkey1, NominatedCount: 3},
+		{Pubkey: pubkey2, NominatedCount: 1},
+	}, nominations)
+
+	invalidScripts := []string{
+		"",
+		entry(pubkey1, 0),
+		entry(pubkey1, 1) + entry(pubkey1, 2),
+		entry(pubkey1, 1) + "00",
+		entry(pubkey1, 1) + entry(pubkey2, 1) + entry([32]byte{3}, 1) + entry([32]byte{4}, 1) +
+			entry([32]byte{5}, 1) + entry([32]byte{6}, 1) + entry([32]byte{7}, 1),
+	}
+	for _, script := range invalidScripts {
+		_, ok = txWithScript(script).GetWeightedNominations()
+		require.False(t, ok)
+	}
+	// the legacy format is not a weighted nomination
+	_, ok = types.TxInfo{VoutList: []types.Vout{
+		{ScriptPubKey: map[string]interface{}{"asm": "OP_RETURN " + types.Identifier + types.Version + hex.EncodeToString(pubkey1[:])}},
+	}}.GetWeightedNominations()
+	require.False(t, ok)
+}
+
+func TestRunWithWeightedNominations(t *testing.T) {
+	pubkey1, pubkey2 := [32]byte{0x1}, [32]byte{0x2}
+	node := bchnode.NewMockBCHNode(0)
+	node.MineBlocks(10, pubkey1)
+	for i := 0; i < 20; i++ {
+		node.MineWeightedBlock(
+			bchnode.WeightedNomination{Pubkey: pubkey1, Weight: 1},
+			bchnode.WeightedNomination{Pubkey: pubkey2, Weight: 3},
+		)
+	}
+	require.NoError(t, node.Start("127.0.0.1:0"))
+	defer node.Stop()
+
+	config := param.DefaultConfig()
+	config.AppConfig.MainnetRPCUrl = node.URL()
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, config)
+	w.SetNumBlocksInEpoch(10)
+	w.SetWeightedNominationHeight(11)
+	stop := startWatcher(w)
+	defer stop()
+	waitForWatcher(t, w, 21, 2)
+
+	e := <-w.EpochChan
+	require.Len(t, e.Nominations, 1)
+	require.Equal(t, pubkey1, e.Nominations[0].Pubkey)
+	e = <-w.EpochChan
+	require.Equal(t, []*stakingtypes.Nomination{
+		{Pubkey: pubkey2, NominatedCount: 8}, // 10*3/4 = 7.5
+		{Pubkey: pubkey1, NominatedCount: 3}, // 10*1/4 = 2.5
+	}, e.Nominations)
+}
+
+func TestWatcherParams(t *testing.T) {
+	config := param.DefaultAppConfig()
+	params, err := config.GetWatcherParams()
+	require.NoError(t, err)
+	require.Equal(t, param.DefaultWatcherParams(), *params)
+	require.Equal(t, param.StakingNumBlocksInEpoch, params.NumBlocksInEpoch)
+	require.Equal(t, param.StakingEpochSwitchDelay, params.EpochSwitchDelay)
+
+	// the tunable params can be changed on any network
+	config.WatcherParallelFetchNum = 20
+	config.WatcherWaitingBlockDelayTime = 5
+	params, err = config.GetWatcherParams()
+	require.NoError(t, err)
+	require.Equal(t, 20, params.ParallelFetchNum)
+	require.Equal(t, 5, params.WaitingBlockDelayTime)
+	config.WatcherParallelFetchNum = param.MaxParallelFetchNum + 1
+	_, err = config.GetWatcherParams()
+	require.Error(t, err)
+	config.WatcherParallelFetchNum = 0
+
+	// the consensus-critical params are locked
+	config.WatcherBlocksInEpoch = 10
+	_, err = config.GetWatcherParams()
+	require.Error(t, err)
+	config.Network = param.NetworkName
+	_, err = config.GetWatcherParams()
+	require.Error(t, err)
+	config.Network = param.NetworkDevnet
+	params, err = config.GetWatcherParams()
+	require.NoError(t, err)
+	require.Equal(t, int64(10), params.NumBlocksInEpoch)
+	require.Equal(t, param.DevnetWatcherParams().FinalityDepth, params.FinalityDepth)
+	config.WatcherFinalityDepth = -1
+	_, err = config.GetWatcherParams()
+	require.Error(t, err)
+
+	config.Network = "unknown"
+	config.WatcherFinalityDepth = 0
+	_, err = config.GetWatcherParams()
+	require.EqualError(t, err, `unknown network "unknown", the supported networks are: mainnet, amber, testnet, devnet`)
+
+	// the other public networks have profiles, but their fork heights are decided by the build tags
+	config.WatcherBlocksInEpoch = 0
+	for _, network := range []string{param.NetworkMainnet, param.NetworkAmber, param.NetworkTestnet} {
+		_, err = param.GetNetworkWatcherParams(network)
+		require.NoError(t, err)
+		config.Network = network
+		_, err = config.GetWatcherParams()
+		require.Equal(t, network != param.NetworkName, err != nil)
+	}
+}
+
+func TestRunOnDevnet(t *testing.T) {
+	node := bchnode.NewMockBCHNode(0)
+	node.MineBlocks(25, testValidatorPubkey1)
+	require.NoError(t, node.Start("127.0.0.1:0"))
+	defer node.Stop()
+
+	config := param.DefaultConfig()
+	config.AppConfig.MainnetRPCUrl = node.URL()
+	config.AppConfig.Network = param.NetworkDevnet
+	config.AppConfig.WatcherFinalityDepth = 3
+	config.AppConfig.WatcherBlocksInEpoch = 5
+	w := NewWatcher(log.NewNopLogger(), 0, 0, 0, config)
+	stop := startWatcher(w)
+	defer stop()
+	waitForWatcher(t, w, 23, 4)
+
+	node.MineBlocks(2, testValidatorPubkey1)
+	waitForWatcher(t, w, 25, 5)
+}